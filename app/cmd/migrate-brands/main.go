@@ -0,0 +1,173 @@
+// Command migrate-brands moves the brands kept in the "brand" attribute of product
+// specifications to brand records. Spellings with the same slug become one brand,
+// named after the most frequent spelling. The attribute is removed from migrated products.
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ilkinabd/goods-manager/app/internal/config"
+	brandDao "github.com/ilkinabd/goods-manager/app/internal/domain/brand/dao"
+	brandService "github.com/ilkinabd/goods-manager/app/internal/domain/brand/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/client/postgresql"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/slug"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	productTableScheme = "public.product"
+	brandExpr          = "btrim(specification->>'brand')"
+)
+
+// spelling is a way a brand is written in specifications of the tenant
+type spelling struct {
+	tenantID string
+	name     string
+	count    uint64
+}
+
+// group is the spellings of a tenant sharing a slug, the first one is the most frequent
+type group struct {
+	tenantID  string
+	spellings []string
+	products  uint64
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the brands to create without changing anything")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := config.GetConfig()
+
+	ctx = logging.ContextWithLogger(ctx, logging.NewLogger())
+
+	pgConfig := postgresql.NewPgConfig(
+		cfg.PostgreSQL.Username, cfg.PostgreSQL.Password,
+		cfg.PostgreSQL.Host, cfg.PostgreSQL.Port, cfg.PostgreSQL.Database,
+	)
+	pgClient, err := postgresql.NewClient(ctx, 5, time.Second*5, pgConfig)
+	if err != nil {
+		logging.Fatal(ctx, err)
+	}
+	defer pgClient.Close()
+
+	queryBuilder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	spellings, err := loadSpellings(ctx, pgClient, queryBuilder)
+	if err != nil {
+		logging.Fatal(ctx, err)
+	}
+
+	groups := groupSpellings(spellings)
+	logging.Infof(ctx, "found %d brands in %d spellings", len(groups), len(spellings))
+
+	brands := brandService.NewBrandService(brandDao.NewBrandDAOPostgres(pgClient))
+
+	var migrated int64
+	for _, g := range groups {
+		logger := logging.WithFields(ctx, map[string]interface{}{
+			"tenant_id": g.tenantID,
+			"brand":     g.spellings[0],
+			"spellings": g.spellings,
+			"products":  g.products,
+		})
+		if *dryRun {
+			logger.Info("would migrate brand")
+			continue
+		}
+
+		tenantCtx := tenant.ContextWithTenant(ctx, g.tenantID)
+		brand, err := brands.FindOrCreate(tenantCtx, g.spellings[0])
+		if err != nil {
+			logger.WithError(err).Fatal("failed to find or create brand")
+		}
+
+		sql, args, err := queryBuilder.
+			Update(productTableScheme).
+			Set("brand_id", brand.ID).
+			Set("specification", sq.Expr("specification - 'brand'")).
+			Set("updated_at", sq.Expr("NOW()")).
+			Where(sq.Eq{"tenant_id": g.tenantID, "brand_id": nil, brandExpr: g.spellings}).
+			ToSql()
+		if err != nil {
+			logger.WithError(err).Fatal("failed to create query")
+		}
+
+		exec, err := pgClient.Exec(ctx, sql, args...)
+		if err != nil {
+			logger.WithError(err).Fatal("failed to update products")
+		}
+
+		migrated += exec.RowsAffected()
+		logger.WithField("brand_id", brand.ID).Infof("migrated %d products", exec.RowsAffected())
+	}
+
+	if !*dryRun {
+		logging.Infof(ctx, "migrated %d products to %d brands", migrated, len(groups))
+	}
+}
+
+// loadSpellings counts products without a brand per tenant and specification brand,
+// most frequent spellings first
+func loadSpellings(ctx context.Context, client *pgxpool.Pool, queryBuilder sq.StatementBuilderType) ([]*spelling, error) {
+	sql, args, err := queryBuilder.
+		Select("tenant_id").
+		Columns(brandExpr, "COUNT(*)").
+		From(productTableScheme).
+		Where(sq.Eq{"brand_id": nil}).
+		Where(brandExpr+" <> ''").
+		GroupBy("tenant_id", brandExpr).
+		OrderBy("tenant_id", "COUNT(*) DESC", brandExpr).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := client.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*spelling, 0)
+	for rows.Next() {
+		s := spelling{}
+		if err = rows.Scan(&s.tenantID, &s.name, &s.count); err != nil {
+			return nil, err
+		}
+		list = append(list, &s)
+	}
+
+	return list, rows.Err()
+}
+
+// groupSpellings joins the spellings of a tenant with the same slug in order of appearance
+func groupSpellings(spellings []*spelling) []*group {
+	groups := make([]*group, 0)
+	bySlug := make(map[string]*group)
+	for _, s := range spellings {
+		sl := slug.Make(s.name)
+		if sl == "" {
+			continue
+		}
+
+		key := s.tenantID + "/" + sl
+		g, ok := bySlug[key]
+		if !ok {
+			g = &group{tenantID: s.tenantID}
+			bySlug[key] = g
+			groups = append(groups, g)
+		}
+		g.spellings = append(g.spellings, s.name)
+		g.products += s.count
+	}
+	return groups
+}
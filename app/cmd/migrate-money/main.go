@@ -0,0 +1,576 @@
+// Command migrate-money gives currencies their ISO 4217 code and exponent and moves
+// the stored amounts to the minor unit of their currency.
+//
+// Amounts were stored before in hundredths of the currency whatever the currency was,
+// the migration assumes so for every currency without an exponent. Amounts of currencies
+// with another exponent are rescaled with banker's rounding, e.g. 12350 hundredths of
+// a yen become 124 yen and 1000 hundredths of a dinar become 10000 fils.
+//
+// Currencies are matched to ISO codes by their name or symbol being a code, the others
+// are given with -codes.
+//
+// Promotions have no currency of their own, their fixed amounts and minimum subtotals are
+// taken off the prices of the products of their tenant. They are rescaled to the exponent
+// of the currencies the tenant prices its products in once every currency is migrated.
+// A tenant pricing in currencies of different exponents has its exponent given with
+// -promotion-exponents, the migration stops until it is. The promotions still in hundredths
+// are marked in promotion.amounts_in_hundredths, which the schema migration adding the
+// currency exponents sets for every promotion there was. Run it after that one.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ilkinabd/goods-manager/app/internal/config"
+	"github.com/ilkinabd/goods-manager/app/pkg/client/postgresql"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	currencyTableScheme  = "public.currency"
+	promotionTableScheme = "public.promotion"
+	productTableScheme   = "public.product"
+
+	// storedExponent is the exponent amounts were stored with before the migration
+	storedExponent = 2
+)
+
+// amounts is a column of amounts and how its rows are tied to a currency
+type amounts struct {
+	table    string
+	column   string
+	keys     []string
+	join     string
+	currency string
+}
+
+var tables = []amounts{
+	{
+		table:    "public.product",
+		column:   "price",
+		keys:     []string{"id"},
+		currency: "t.currency_id",
+	},
+	{
+		table:    "public.product_price",
+		column:   "price",
+		keys:     []string{"id"},
+		join:     "public.product p ON p.id = t.product_id",
+		currency: "p.currency_id",
+	},
+	{
+		table:    "public.price_list_entry",
+		column:   "price",
+		keys:     []string{"price_list_id", "product_id", "min_quantity"},
+		join:     "public.price_list pl ON pl.id = t.price_list_id",
+		currency: "pl.currency_id",
+	},
+	{
+		table:    "public.product_bundle",
+		column:   "price_override",
+		keys:     []string{"product_id"},
+		join:     "public.product p ON p.id = t.product_id",
+		currency: "p.currency_id",
+	},
+}
+
+// currency is a currency row not migrated yet
+type currency struct {
+	id     uint32
+	name   string
+	symbol string
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the currencies and amounts to migrate without changing anything")
+	codes := flag.String("codes", "", "ISO codes of currencies not matched by name or symbol, e.g. 1=AZN,4=TRY")
+	promotionExponents := flag.String("promotion-exponents", "",
+		"exponents of the promotion amounts of tenants pricing in currencies of different exponents, e.g. shop=2")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := config.GetConfig()
+
+	ctx = logging.ContextWithLogger(ctx, logging.NewLogger())
+
+	overrides, err := parseCodes(*codes)
+	if err != nil {
+		logging.Fatal(ctx, err)
+	}
+
+	exponents, err := parseExponents(*promotionExponents)
+	if err != nil {
+		logging.Fatal(ctx, err)
+	}
+
+	pgConfig := postgresql.NewPgConfig(
+		cfg.PostgreSQL.Username, cfg.PostgreSQL.Password,
+		cfg.PostgreSQL.Host, cfg.PostgreSQL.Port, cfg.PostgreSQL.Database,
+	)
+	pgClient, err := postgresql.NewClient(ctx, 5, time.Second*5, pgConfig)
+	if err != nil {
+		logging.Fatal(ctx, err)
+	}
+	defer pgClient.Close()
+
+	queryBuilder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	pending, err := loadPending(ctx, pgClient, queryBuilder)
+	if err != nil {
+		logging.Fatal(ctx, err)
+	}
+
+	logging.Infof(ctx, "found %d currencies to migrate", len(pending))
+
+	var unmatched int
+	for _, c := range pending {
+		logger := logging.WithFields(ctx, map[string]interface{}{
+			"currency_id": c.id,
+			"name":        c.name,
+			"symbol":      c.symbol,
+		})
+
+		iso, ok := match(c, overrides)
+		if !ok {
+			unmatched++
+			logger.Warn("no ISO code matched, give it with -codes")
+			continue
+		}
+
+		logger = logger.WithFields(map[string]interface{}{"code": iso.Code, "exponent": iso.Exponent})
+		if *dryRun {
+			logger.Info("would migrate currency")
+			continue
+		}
+
+		var rescaled int64
+		err = pgClient.BeginFunc(ctx, func(tx pgx.Tx) error {
+			for _, a := range tables {
+				n, err := rescale(ctx, tx, queryBuilder, a, c.id, iso.Exponent)
+				if err != nil {
+					return fmt.Errorf("%s.%s: %w", a.table, a.column, err)
+				}
+				rescaled += n
+			}
+
+			sql, args, err := queryBuilder.
+				Update(currencyTableScheme).
+				Set("code", iso.Code).
+				Set("exponent", iso.Exponent).
+				Where(sq.Eq{"id": c.id}).
+				ToSql()
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, sql, args...)
+			return err
+		})
+		if err != nil {
+			logger.WithError(err).Fatal("failed to migrate currency")
+		}
+
+		logger.Infof("migrated currency, rescaled %d amounts", rescaled)
+	}
+
+	if unmatched != 0 {
+		logging.Fatalf(ctx, "%d currencies have no ISO code", unmatched)
+	}
+
+	if err = migratePromotions(ctx, pgClient, queryBuilder, exponents, *dryRun); err != nil {
+		logging.Fatal(ctx, err)
+	}
+}
+
+// parseExponents parses tenant to exponent pairs, e.g. shop=2,wholesale=3
+func parseExponents(value string) (map[string]uint8, error) {
+	exponents := make(map[string]uint8)
+	if value == "" {
+		return exponents, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		tenantID, exponentValue, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("promotion-exponents: %q must be TENANT=EXPONENT", pair)
+		}
+
+		exponent, err := strconv.ParseUint(strings.TrimSpace(exponentValue), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("promotion-exponents: %q: %w", pair, err)
+		}
+		exponents[strings.TrimSpace(tenantID)] = uint8(exponent)
+	}
+
+	return exponents, nil
+}
+
+// promotion is a promotion whose amounts are still in hundredths
+type promotion struct {
+	id          string
+	tenantID    string
+	action      string
+	value       int64
+	minSubtotal *int64
+}
+
+// hasAmount reports whether the value of the promotion is an amount, not a percentage or quantity
+func (p *promotion) hasAmount() bool {
+	return p.action == "fixed_amount" || p.action == "fixed_price"
+}
+
+// migratePromotions rescales the fixed amounts and minimum subtotals of the promotions
+// still in hundredths to the exponent of the currencies of their tenant
+func migratePromotions(
+	ctx context.Context,
+	client *pgxpool.Pool,
+	queryBuilder sq.StatementBuilderType,
+	exponents map[string]uint8,
+	dryRun bool,
+) error {
+	pending, err := loadPromotions(ctx, client, queryBuilder)
+	if err != nil {
+		return fmt.Errorf("load promotions: %w", err)
+	}
+
+	logging.Infof(ctx, "found %d promotions to migrate", len(pending))
+	if dryRun || len(pending) == 0 {
+		return nil
+	}
+
+	var ambiguous int
+	for tenantID, promotions := range pending {
+		logger := logging.WithField(ctx, "tenant_id", tenantID)
+
+		exponent, ok := exponents[tenantID]
+		if !ok {
+			exponent, ok, err = tenantExponent(ctx, client, queryBuilder, tenantID)
+			if err != nil {
+				return fmt.Errorf("tenant %s: %w", tenantID, err)
+			}
+		}
+		if !ok {
+			ambiguous++
+			logger.Warn("products are priced in currencies of different exponents, give the exponent with -promotion-exponents")
+			continue
+		}
+
+		err = client.BeginFunc(ctx, func(tx pgx.Tx) error {
+			for _, p := range promotions {
+				if err := rescalePromotion(ctx, tx, queryBuilder, p, exponent); err != nil {
+					return fmt.Errorf("promotion %s: %w", p.id, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.WithError(err).Fatal("failed to migrate promotions")
+		}
+
+		logger.WithField("exponent", exponent).Infof("migrated %d promotions", len(promotions))
+	}
+
+	if ambiguous != 0 {
+		return fmt.Errorf("%d tenants have promotions of an unknown exponent", ambiguous)
+	}
+
+	return nil
+}
+
+// loadPromotions returns the promotions still in hundredths by tenant
+func loadPromotions(
+	ctx context.Context,
+	client *pgxpool.Pool,
+	queryBuilder sq.StatementBuilderType,
+) (map[string][]*promotion, error) {
+	sql, args, err := queryBuilder.
+		Select("id").
+		Columns("tenant_id", "action", "value", "(conditions->>'min_subtotal')::bigint").
+		From(promotionTableScheme).
+		Where(sq.Eq{"amounts_in_hundredths": true}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := client.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pending := make(map[string][]*promotion)
+	for rows.Next() {
+		p := promotion{}
+		if err = rows.Scan(&p.id, &p.tenantID, &p.action, &p.value, &p.minSubtotal); err != nil {
+			return nil, err
+		}
+		pending[p.tenantID] = append(pending[p.tenantID], &p)
+	}
+
+	return pending, rows.Err()
+}
+
+// tenantExponent returns the exponent of the currencies the tenant prices its products in.
+// A tenant without products keeps hundredths, one pricing in different exponents has none.
+func tenantExponent(
+	ctx context.Context,
+	client *pgxpool.Pool,
+	queryBuilder sq.StatementBuilderType,
+	tenantID string,
+) (uint8, bool, error) {
+	sql, args, err := queryBuilder.
+		Select("DISTINCT cur.exponent").
+		From(productTableScheme + " p").
+		Join(currencyTableScheme + " cur ON cur.id = p.currency_id").
+		Where(sq.Eq{"p.tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return 0, false, err
+	}
+
+	rows, err := client.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, false, err
+	}
+
+	defer rows.Close()
+
+	found := make([]uint8, 0, 1)
+	for rows.Next() {
+		var exponent int16
+		if err = rows.Scan(&exponent); err != nil {
+			return 0, false, err
+		}
+		found = append(found, uint8(exponent))
+	}
+	if err = rows.Err(); err != nil {
+		return 0, false, err
+	}
+
+	switch len(found) {
+	case 0:
+		return storedExponent, true, nil
+	case 1:
+		return found[0], true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
+// rescalePromotion moves the amounts of the promotion from hundredths to the exponent
+func rescalePromotion(
+	ctx context.Context,
+	tx pgx.Tx,
+	queryBuilder sq.StatementBuilderType,
+	p *promotion,
+	exponent uint8,
+) error {
+	update := queryBuilder.
+		Update(promotionTableScheme).
+		Set("amounts_in_hundredths", false).
+		Where(sq.Eq{"id": p.id})
+
+	if p.hasAmount() {
+		value, err := money.Rescale(p.value, storedExponent, exponent, money.HalfEven)
+		if err != nil {
+			return err
+		}
+		update = update.Set("value", value)
+	}
+
+	if p.minSubtotal != nil {
+		minSubtotal, err := money.Rescale(*p.minSubtotal, storedExponent, exponent, money.HalfEven)
+		if err != nil {
+			return err
+		}
+		update = update.Set("conditions", sq.Expr("jsonb_set(conditions, '{min_subtotal}', to_jsonb(?::bigint))", minSubtotal))
+	}
+
+	sql, args, err := update.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// parseCodes parses currency ID to ISO code pairs, e.g. 1=AZN,4=TRY
+func parseCodes(value string) (map[uint32]money.Currency, error) {
+	overrides := make(map[uint32]money.Currency)
+	if value == "" {
+		return overrides, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		idValue, code, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("codes: %q must be ID=CODE", pair)
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSpace(idValue), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("codes: %q: %w", pair, err)
+		}
+
+		c, err := money.CurrencyOf(strings.ToUpper(strings.TrimSpace(code)))
+		if err != nil {
+			return nil, fmt.Errorf("codes: %q: %w", pair, err)
+		}
+		overrides[uint32(id)] = c
+	}
+
+	return overrides, nil
+}
+
+// match finds the ISO currency of the row, the ones given with -codes first
+func match(c *currency, overrides map[uint32]money.Currency) (money.Currency, bool) {
+	if iso, ok := overrides[c.id]; ok {
+		return iso, true
+	}
+
+	for _, candidate := range []string{c.name, c.symbol} {
+		if iso, err := money.CurrencyOf(strings.ToUpper(strings.TrimSpace(candidate))); err == nil {
+			return iso, true
+		}
+	}
+
+	return money.Currency{}, false
+}
+
+// loadPending returns the currencies without an exponent
+func loadPending(ctx context.Context, client *pgxpool.Pool, queryBuilder sq.StatementBuilderType) ([]*currency, error) {
+	sql, args, err := queryBuilder.
+		Select("id").
+		Columns("name", "symbol").
+		From(currencyTableScheme).
+		Where(sq.Eq{"exponent": nil}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := client.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*currency, 0)
+	for rows.Next() {
+		c := currency{}
+		if err = rows.Scan(&c.id, &c.name, &c.symbol); err != nil {
+			return nil, err
+		}
+		list = append(list, &c)
+	}
+
+	return list, rows.Err()
+}
+
+// rescale moves the amounts of the currency in the column from hundredths to the exponent.
+// Nothing changes for currencies with hundredths as their minor unit.
+func rescale(
+	ctx context.Context,
+	tx pgx.Tx,
+	queryBuilder sq.StatementBuilderType,
+	a amounts,
+	currencyID uint32,
+	exponent uint8,
+) (int64, error) {
+	if exponent == storedExponent {
+		return 0, nil
+	}
+
+	columns := make([]string, len(a.keys))
+	for i, k := range a.keys {
+		columns[i] = "t." + k
+	}
+
+	query := queryBuilder.
+		Select(columns...).
+		Column("t." + a.column).
+		From(a.table + " t").
+		Where(sq.Eq{a.currency: currencyID}).
+		Where(sq.NotEq{"t." + a.column: nil})
+	if a.join != "" {
+		query = query.Join(a.join)
+	}
+
+	sql, args, err := query.Suffix("FOR UPDATE OF t").ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	type row struct {
+		keys   []interface{}
+		amount int64
+	}
+
+	list := make([]row, 0)
+	for rows.Next() {
+		r := row{keys: make([]interface{}, len(a.keys))}
+		dest := make([]interface{}, 0, len(a.keys)+1)
+		for i := range r.keys {
+			dest = append(dest, &r.keys[i])
+		}
+		dest = append(dest, &r.amount)
+
+		if err = rows.Scan(dest...); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		list = append(list, r)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, r := range list {
+		amount, err := money.Rescale(r.amount, storedExponent, exponent, money.HalfEven)
+		if err != nil {
+			return 0, err
+		}
+
+		where := sq.Eq{}
+		for i, k := range a.keys {
+			where[k] = r.keys[i]
+		}
+
+		sql, args, err := queryBuilder.
+			Update(a.table).
+			Set(a.column, amount).
+			Where(where).
+			ToSql()
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(list)), nil
+}
@@ -8,16 +8,71 @@ import (
 	"net/http"
 	"time"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
 	_ "github.com/ilkinabd/goods-manager/app/docs"
 	"github.com/ilkinabd/goods-manager/app/internal/config"
 	product "github.com/ilkinabd/goods-manager/app/internal/controller/grpc/v1/product"
+	apiKeyDao "github.com/ilkinabd/goods-manager/app/internal/domain/apikey/dao"
+	apiKeyPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/apikey/policy"
+	apiKeyService "github.com/ilkinabd/goods-manager/app/internal/domain/apikey/service"
+	barcodeDao "github.com/ilkinabd/goods-manager/app/internal/domain/barcode/dao"
+	barcodePolicy "github.com/ilkinabd/goods-manager/app/internal/domain/barcode/policy"
+	barcodeService "github.com/ilkinabd/goods-manager/app/internal/domain/barcode/service"
+	brandDao "github.com/ilkinabd/goods-manager/app/internal/domain/brand/dao"
+	brandPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/brand/policy"
+	brandService "github.com/ilkinabd/goods-manager/app/internal/domain/brand/service"
+	categoryPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/category/policy"
+	categoryService "github.com/ilkinabd/goods-manager/app/internal/domain/category/service"
+	categoryStorage "github.com/ilkinabd/goods-manager/app/internal/domain/category/storage"
+	currencyStorage "github.com/ilkinabd/goods-manager/app/internal/domain/currency/storage"
+	inventoryDao "github.com/ilkinabd/goods-manager/app/internal/domain/inventory/dao"
+	inventoryPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/inventory/policy"
+	inventoryService "github.com/ilkinabd/goods-manager/app/internal/domain/inventory/service"
+	outboxDao "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
+	outboxPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/policy"
+	outboxService "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/service"
+	priceDao "github.com/ilkinabd/goods-manager/app/internal/domain/price/dao"
+	pricePolicy "github.com/ilkinabd/goods-manager/app/internal/domain/price/policy"
+	priceService "github.com/ilkinabd/goods-manager/app/internal/domain/price/service"
+	priceListDao "github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/dao"
+	priceListPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/policy"
+	priceListService "github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/service"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/dao"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/policy"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/service"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/validator"
+	promotionDao "github.com/ilkinabd/goods-manager/app/internal/domain/promotion/dao"
+	promotionPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/promotion/policy"
+	promotionService "github.com/ilkinabd/goods-manager/app/internal/domain/promotion/service"
+	relationDao "github.com/ilkinabd/goods-manager/app/internal/domain/relation/dao"
+	relationPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/relation/policy"
+	relationService "github.com/ilkinabd/goods-manager/app/internal/domain/relation/service"
+	reviewDao "github.com/ilkinabd/goods-manager/app/internal/domain/review/dao"
+	reviewPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/review/policy"
+	reviewService "github.com/ilkinabd/goods-manager/app/internal/domain/review/service"
+	slugDao "github.com/ilkinabd/goods-manager/app/internal/domain/slug/dao"
+	slugPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/slug/policy"
+	slugService "github.com/ilkinabd/goods-manager/app/internal/domain/slug/service"
+	tagDao "github.com/ilkinabd/goods-manager/app/internal/domain/tag/dao"
+	tagPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/tag/policy"
+	tagService "github.com/ilkinabd/goods-manager/app/internal/domain/tag/service"
+	taxDao "github.com/ilkinabd/goods-manager/app/internal/domain/tax/dao"
+	taxPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/tax/policy"
+	taxService "github.com/ilkinabd/goods-manager/app/internal/domain/tax/service"
+	translationDao "github.com/ilkinabd/goods-manager/app/internal/domain/translation/dao"
+	translationPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/translation/policy"
+	translationService "github.com/ilkinabd/goods-manager/app/internal/domain/translation/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/api/grpcerror"
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
 	"github.com/ilkinabd/goods-manager/app/pkg/client/postgresql"
+	"github.com/ilkinabd/goods-manager/app/pkg/events"
+	"github.com/ilkinabd/goods-manager/app/pkg/locale"
 	"github.com/ilkinabd/goods-manager/app/pkg/logging"
 	"github.com/ilkinabd/goods-manager/app/pkg/metric"
+	"github.com/ilkinabd/goods-manager/app/pkg/ratelimit"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -37,6 +92,17 @@ type App struct {
 
 	pgClient *pgxpool.Pool
 
+	authInterceptor *jwt.AuthInterceptor
+	localeResolver  *locale.Resolver
+	grpcLimiter     *ratelimit.Limiter
+	peerLimiter     *ratelimit.Limiter
+	httpLimiter     *ratelimit.Limiter
+
+	inventoryService *inventoryService.InventoryService
+	priceService     *priceService.PriceService
+	productService   *service.ProductService
+	outboxService    *outboxService.OutboxService
+
 	productServiceServer pbProducts.ProductServiceServer
 }
 
@@ -61,22 +127,146 @@ func NewApp(ctx context.Context, config *config.Config) (App, error) {
 		logging.GetLogger().Fatal(ctx, err)
 	}
 
+	localeResolver := locale.NewResolver(config.Locale.Default, config.Locale.Supported, config.Locale.Fallback)
+
+	prices := priceService.NewPriceService(priceDao.NewPriceDAOPostgres(pgClient))
+	slugs := slugService.NewSlugService(slugDao.NewSlugDAOPostgres(pgClient), localeResolver.Default())
 	productDao := dao.NewProductDAOPostgres(pgClient)
-	productService := service.NewProductService(productDao)
-	productPolicy := policy.NewProductPolicy(productService)
+	relations := relationService.NewRelationService(relationDao.NewRelationDAOPostgres(pgClient))
+	tags := tagService.NewTagService(tagDao.NewTagDAOPostgres(pgClient))
+	barcodeStore := barcodeDao.NewBarcodeDAOPostgres(pgClient)
+	barcodes := barcodeService.NewBarcodeService(barcodeStore)
+	taxes := taxDao.NewTaxDAOPostgres(pgClient)
+	currencies := currencyStorage.NewCurrencyStoragePostgres(pgClient)
+	productService := service.NewProductService(productDao, currencies, slugs, barcodes)
+	categoryStore := categoryStorage.NewCategoryStoragePostgres(pgClient)
+	catPolicy := categoryPolicy.NewCategoryPolicy(categoryService.NewCategoryService(categoryStore))
+
+	brands := brandDao.NewBrandDAOPostgres(pgClient)
+	productValidator := validator.NewProductValidator(
+		categoryStore,
+		currencies,
+		brands,
+		barcodeStore,
+		taxes,
+	)
+	productPolicy := policy.NewProductPolicy(productService, productValidator, config.JWT.AdminRoleID)
+
+	keyDao := apiKeyDao.NewAPIKeyDAOPostgres(pgClient)
+	keyService := apiKeyService.NewAPIKeyService(keyDao)
+	keyPolicy := apiKeyPolicy.NewAPIKeyPolicy(keyService)
+
+	logging.Info(ctx, "event publisher initializing")
+	publisher, err := newPublisher(ctx, config)
+	if err != nil {
+		logging.GetLogger().Fatal(ctx, err)
+	}
+	outbox := outboxService.NewOutboxService(outboxDao.NewOutboxDAOPostgres(pgClient), publisher, config.Outbox.Source)
+
+	stockService := inventoryService.NewInventoryService(inventoryDao.NewInventoryDAOPostgres(pgClient))
+	stockPolicy := inventoryPolicy.NewInventoryPolicy(
+		stockService,
+		config.Inventory.ReservationTTL,
+		config.Inventory.MaxReservationTTL,
+	)
+
 	productServiceServer := product.NewServer(
 		productPolicy,
+		keyPolicy,
+		catPolicy,
+		stockPolicy,
+		pricePolicy.NewPricePolicy(prices),
+		promotionPolicy.NewPromotionPolicy(
+			promotionService.NewPromotionService(promotionDao.NewPromotionDAOPostgres(pgClient)),
+		),
+		priceListPolicy.NewPriceListPolicy(
+			priceListService.NewPriceListService(priceListDao.NewPriceListDAOPostgres(pgClient)),
+			config.JWT.AdminRoleID,
+		),
+		translationPolicy.NewTranslationPolicy(
+			translationService.NewTranslationService(translationDao.NewTranslationDAOPostgres(pgClient)),
+			localeResolver,
+			config.Locale.TextSearch,
+		),
+		slugPolicy.NewSlugPolicy(slugs, localeResolver),
+		relationPolicy.NewRelationPolicy(relations),
+		reviewPolicy.NewReviewPolicy(
+			reviewService.NewReviewService(reviewDao.NewReviewDAOPostgres(pgClient)),
+			config.JWT.AdminRoleID,
+		),
+		tagPolicy.NewTagPolicy(tags),
+		brandPolicy.NewBrandPolicy(brandService.NewBrandService(brands)),
+		barcodePolicy.NewBarcodePolicy(barcodes),
+		taxPolicy.NewTaxPolicy(taxService.NewTaxService(taxes)),
+		outboxPolicy.NewOutboxPolicy(outbox),
 		pbProducts.UnimplementedProductServiceServer{},
 	)
 
+	logging.Info(ctx, "auth interceptor initializing")
+	authInterceptor := jwt.NewAuthInterceptor(
+		jwt.NewHelper(config.JWT.Secret),
+		grpcMethodRoles(config.JWT.AdminRoleID),
+		keyService,
+		grpcMethodScopes(),
+		config.Tenant.Default,
+	)
+
+	var grpcLimiter, peerLimiter, httpLimiter *ratelimit.Limiter
+	if config.RateLimit.Enabled {
+		logging.Info(ctx, "rate limiters initializing")
+		rateLimitStore := ratelimit.NewMemoryStore()
+		grpcLimiter = ratelimit.NewLimiter(rateLimitStore, rateLimit(config.RateLimit.Default), rateLimits(config.RateLimit.GRPC))
+		peerLimiter = ratelimit.NewLimiter(rateLimitStore, rateLimit(config.RateLimit.Peer), nil)
+		httpLimiter = ratelimit.NewLimiter(rateLimitStore, rateLimit(config.RateLimit.Default), rateLimits(config.RateLimit.HTTP))
+	}
+
 	return App{
 		cfg:                  config,
 		router:               router,
 		pgClient:             pgClient,
+		authInterceptor:      authInterceptor,
+		localeResolver:       localeResolver,
+		grpcLimiter:          grpcLimiter,
+		peerLimiter:          peerLimiter,
+		httpLimiter:          httpLimiter,
+		inventoryService:     stockService,
+		priceService:         prices,
+		productService:       productService,
+		outboxService:        outbox,
 		productServiceServer: productServiceServer,
 	}, nil
 }
 
+// newPublisher creates the publisher product events are relayed through
+func newPublisher(ctx context.Context, cfg *config.Config) (events.Publisher, error) {
+	switch cfg.Outbox.Publisher {
+	case "file":
+		return events.NewFilePublisher(cfg.Outbox.File)
+	case "nats":
+		return events.NewNATSPublisher(
+			ctx,
+			cfg.Outbox.NATS.URL,
+			cfg.Outbox.Source,
+			cfg.Outbox.NATS.SubjectPrefix,
+			cfg.Outbox.NATS.Timeout,
+		)
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q, must be file or nats", cfg.Outbox.Publisher)
+	}
+}
+
+func rateLimit(l config.RateLimit) ratelimit.Limit {
+	return ratelimit.Limit{Rate: l.Rate, Burst: l.Burst}
+}
+
+func rateLimits(limits map[string]config.RateLimit) map[string]ratelimit.Limit {
+	res := make(map[string]ratelimit.Limit, len(limits))
+	for route, l := range limits {
+		res[route] = rateLimit(l)
+	}
+	return res
+}
+
 func (a *App) Run(ctx context.Context) error {
 	grp, ctx := errgroup.WithContext(ctx)
 	grp.Go(func() error {
@@ -85,6 +275,21 @@ func (a *App) Run(ctx context.Context) error {
 	grp.Go(func() error {
 		return a.startGRPC(ctx, a.productServiceServer)
 	})
+	grp.Go(func() error {
+		return a.inventoryService.RunExpiry(ctx, a.cfg.Inventory.ExpiryInterval)
+	})
+	grp.Go(func() error {
+		return a.priceService.RunScheduler(ctx, a.cfg.Price.SchedulerInterval)
+	})
+	grp.Go(func() error {
+		return a.productService.RunLifecycle(ctx, a.cfg.Lifecycle.SchedulerInterval)
+	})
+	grp.Go(func() error {
+		return a.outboxService.RunRelay(ctx, a.cfg.Outbox.RelayInterval, a.cfg.Outbox.BatchSize, a.cfg.Outbox.Retention)
+	})
+	grp.Go(func() error {
+		return a.outboxService.RunListener(ctx, a.cfg.Outbox.ListenRetry)
+	})
 	return grp.Wait()
 }
 
@@ -100,7 +305,40 @@ func (a *App) startGRPC(ctx context.Context, server pbProducts.ProductServiceSer
 		logger.WithError(err).Fatal("failed to create listener")
 	}
 
-	serverOptions := []grpc.ServerOption{}
+	interceptors := []grpc.UnaryServerInterceptor{
+		grpcerror.UnaryServerInterceptor(),
+		grpc_ctxtags.UnaryServerInterceptor(),
+	}
+	if a.peerLimiter != nil {
+		interceptors = append(interceptors, ratelimit.PeerUnaryServerInterceptor(a.peerLimiter))
+	}
+	interceptors = append(interceptors,
+		locale.UnaryServerInterceptor(a.localeResolver),
+		grpc_auth.UnaryServerInterceptor(a.authInterceptor.AuthorizeHandler),
+	)
+	if a.grpcLimiter != nil {
+		interceptors = append(interceptors, ratelimit.UnaryServerInterceptor(a.grpcLimiter))
+	}
+
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpcerror.StreamServerInterceptor(),
+		grpc_ctxtags.StreamServerInterceptor(),
+	}
+	if a.peerLimiter != nil {
+		streamInterceptors = append(streamInterceptors, ratelimit.PeerStreamServerInterceptor(a.peerLimiter))
+	}
+	streamInterceptors = append(streamInterceptors,
+		locale.StreamServerInterceptor(a.localeResolver),
+		grpc_auth.StreamServerInterceptor(a.authInterceptor.AuthorizeHandler),
+	)
+	if a.grpcLimiter != nil {
+		streamInterceptors = append(streamInterceptors, ratelimit.StreamServerInterceptor(a.grpcLimiter))
+	}
+
+	serverOptions := []grpc.ServerOption{
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(interceptors...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+	}
 
 	a.grpcServer = grpc.NewServer(serverOptions...)
 
@@ -142,7 +380,11 @@ func (a *App) startHTTP(ctx context.Context) error {
 		Debug:              a.cfg.HTTP.CORS.Debug,
 	})
 
-	handler := c.Handler(a.router)
+	var handler http.Handler = locale.Middleware(a.router, a.localeResolver)
+	if a.httpLimiter != nil {
+		handler = ratelimit.Middleware(handler, a.httpLimiter)
+	}
+	handler = c.Handler(handler)
 
 	a.httpServer = &http.Server{
 		Handler:      handler,
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	apiKeyModel "github.com/ilkinabd/goods-manager/app/internal/domain/apikey/model"
+)
+
+func productMethod(name string) string {
+	return fmt.Sprintf("/%s/%s", pbProducts.ProductService_ServiceDesc.ServiceName, name)
+}
+
+// grpcMethodRoles lists methods which require a bearer token with one of the roles,
+// methods without roles require a bearer token of any role
+func grpcMethodRoles(adminRoleID uint64) map[string][]uint64 {
+	admin := []uint64{adminRoleID}
+	signedIn := []uint64{}
+	return map[string][]uint64{
+		productMethod("CreateProduct"):              admin,
+		productMethod("UpdateProduct"):              admin,
+		productMethod("DeleteProduct"):              admin,
+		productMethod("SetProductOptionAxes"):       admin,
+		productMethod("TransitionProduct"):          admin,
+		productMethod("ScheduleProductPublication"): admin,
+		productMethod("CreateWarehouse"):            admin,
+		productMethod("AllWarehouses"):              admin,
+		productMethod("SetStock"):                   admin,
+		productMethod("ReserveStock"):               admin,
+		productMethod("ReleaseReservation"):         admin,
+		productMethod("CommitReservation"):          admin,
+		productMethod("SchedulePrice"):              admin,
+		productMethod("CancelScheduledPrice"):       admin,
+		productMethod("PriceHistory"):               admin,
+		productMethod("PriceAt"):                    admin,
+		productMethod("CreatePromotion"):            admin,
+		productMethod("AllPromotions"):              admin,
+		productMethod("DeletePromotion"):            admin,
+		productMethod("CreatePriceList"):            admin,
+		productMethod("AllPriceLists"):              admin,
+		productMethod("DeletePriceList"):            admin,
+		productMethod("SetPriceListPrices"):         admin,
+		productMethod("ProductTranslations"):        admin,
+		productMethod("SetProductTranslations"):     admin,
+		productMethod("DeleteProductTranslation"):   admin,
+		productMethod("MissingTranslations"):        admin,
+		productMethod("SetProductSlug"):             admin,
+		productMethod("ProductSlugs"):               admin,
+		productMethod("CreateProductRelation"):      admin,
+		productMethod("DeleteProductRelation"):      admin,
+		productMethod("SetProductBundle"):           admin,
+		productMethod("CreateReview"):               signedIn,
+		productMethod("ModerateReview"):             admin,
+		productMethod("DeleteReview"):               admin,
+		productMethod("CreateTag"):                  admin,
+		productMethod("DeleteTag"):                  admin,
+		productMethod("AssignTags"):                 admin,
+		productMethod("UnassignTags"):               admin,
+		productMethod("CreateBrand"):                admin,
+		productMethod("UpdateBrand"):                admin,
+		productMethod("DeleteBrand"):                admin,
+		productMethod("ImportProducts"):             admin,
+		productMethod("SetCategoryShippingClass"):   admin,
+		productMethod("CreateTaxClass"):             admin,
+		productMethod("AllTaxClasses"):              admin,
+		productMethod("DeleteTaxClass"):             admin,
+		productMethod("SetCategoryTaxClass"):        admin,
+		productMethod("SetTaxRegion"):               admin,
+		productMethod("AllTaxRegions"):              admin,
+		productMethod("ScheduleTaxRate"):            admin,
+		productMethod("CancelScheduledTaxRate"):     admin,
+		productMethod("TaxRates"):                   admin,
+		productMethod("WatchProducts"):              admin,
+		productMethod("CreateAPIKey"):               admin,
+		productMethod("AllAPIKeys"):                 admin,
+		productMethod("RevokeAPIKey"):               admin,
+	}
+}
+
+// grpcMethodScopes lists methods which can be called with an API key having the scope
+func grpcMethodScopes() map[string]string {
+	return map[string]string{
+		productMethod("CreateProduct"):              apiKeyModel.ScopeProductsWrite,
+		productMethod("UpdateProduct"):              apiKeyModel.ScopeProductsWrite,
+		productMethod("DeleteProduct"):              apiKeyModel.ScopeProductsWrite,
+		productMethod("SetProductOptionAxes"):       apiKeyModel.ScopeProductsWrite,
+		productMethod("TransitionProduct"):          apiKeyModel.ScopeProductsWrite,
+		productMethod("ScheduleProductPublication"): apiKeyModel.ScopeProductsWrite,
+		productMethod("SetStock"):                   apiKeyModel.ScopeInventoryWrite,
+		productMethod("ReserveStock"):               apiKeyModel.ScopeInventoryWrite,
+		productMethod("ReleaseReservation"):         apiKeyModel.ScopeInventoryWrite,
+		productMethod("CommitReservation"):          apiKeyModel.ScopeInventoryWrite,
+		productMethod("SchedulePrice"):              apiKeyModel.ScopeProductsWrite,
+		productMethod("CancelScheduledPrice"):       apiKeyModel.ScopeProductsWrite,
+		productMethod("CreatePromotion"):            apiKeyModel.ScopeProductsWrite,
+		productMethod("DeletePromotion"):            apiKeyModel.ScopeProductsWrite,
+		productMethod("CreatePriceList"):            apiKeyModel.ScopeProductsWrite,
+		productMethod("DeletePriceList"):            apiKeyModel.ScopeProductsWrite,
+		productMethod("SetPriceListPrices"):         apiKeyModel.ScopeProductsWrite,
+		productMethod("SetProductTranslations"):     apiKeyModel.ScopeProductsWrite,
+		productMethod("DeleteProductTranslation"):   apiKeyModel.ScopeProductsWrite,
+		productMethod("SetProductSlug"):             apiKeyModel.ScopeProductsWrite,
+		productMethod("CreateProductRelation"):      apiKeyModel.ScopeProductsWrite,
+		productMethod("DeleteProductRelation"):      apiKeyModel.ScopeProductsWrite,
+		productMethod("SetProductBundle"):           apiKeyModel.ScopeProductsWrite,
+		productMethod("CreateReview"):               apiKeyModel.ScopeProductsWrite,
+		productMethod("ModerateReview"):             apiKeyModel.ScopeProductsWrite,
+		productMethod("DeleteReview"):               apiKeyModel.ScopeProductsWrite,
+		productMethod("CreateTag"):                  apiKeyModel.ScopeProductsWrite,
+		productMethod("DeleteTag"):                  apiKeyModel.ScopeProductsWrite,
+		productMethod("AssignTags"):                 apiKeyModel.ScopeProductsWrite,
+		productMethod("UnassignTags"):               apiKeyModel.ScopeProductsWrite,
+		productMethod("CreateBrand"):                apiKeyModel.ScopeProductsWrite,
+		productMethod("UpdateBrand"):                apiKeyModel.ScopeProductsWrite,
+		productMethod("DeleteBrand"):                apiKeyModel.ScopeProductsWrite,
+		productMethod("ImportProducts"):             apiKeyModel.ScopeProductsWrite,
+		productMethod("SetCategoryShippingClass"):   apiKeyModel.ScopeProductsWrite,
+		productMethod("CreateTaxClass"):             apiKeyModel.ScopeProductsWrite,
+		productMethod("DeleteTaxClass"):             apiKeyModel.ScopeProductsWrite,
+		productMethod("SetCategoryTaxClass"):        apiKeyModel.ScopeProductsWrite,
+		productMethod("SetTaxRegion"):               apiKeyModel.ScopeProductsWrite,
+		productMethod("ScheduleTaxRate"):            apiKeyModel.ScopeProductsWrite,
+		productMethod("CancelScheduledTaxRate"):     apiKeyModel.ScopeProductsWrite,
+		productMethod("WatchProducts"):              apiKeyModel.ScopeProductsRead,
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"sync"
@@ -10,6 +11,11 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+type RateLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
 type Config struct {
 	IsDebug       bool `yaml:"is-debug" env:"IS_DEBUG" env-default:"false"`
 	IsDevelopment bool `yaml:"is-development" env:"IS_DEV" env-default:"false"`
@@ -39,6 +45,52 @@ type Config struct {
 			Password string `yaml:"password" env:"ADMIN_PWD" env-default:"admin"`
 		} `yaml:"admin"`
 	} `yaml:"app"`
+	JWT struct {
+		Secret      string `yaml:"secret" env:"JWT_SECRET" env-required:"true"`
+		AdminRoleID uint64 `yaml:"admin-role-id" env:"JWT_ADMIN_ROLE_ID" env-default:"1"`
+	} `yaml:"jwt"`
+	Tenant struct {
+		// Default is the tenant of anonymous calls naming none
+		Default string `yaml:"default" env:"TENANT_DEFAULT" env-default:"default"`
+	} `yaml:"tenant"`
+	RateLimit struct {
+		Enabled bool                 `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"false"`
+		Default RateLimit            `yaml:"default"`
+		Peer    RateLimit            `yaml:"peer"`
+		GRPC    map[string]RateLimit `yaml:"grpc"`
+		HTTP    map[string]RateLimit `yaml:"http"`
+	} `yaml:"rate-limit"`
+	Inventory struct {
+		ReservationTTL    time.Duration `yaml:"reservation-ttl" env:"INVENTORY_RESERVATION_TTL" env-default:"15m"`
+		MaxReservationTTL time.Duration `yaml:"max-reservation-ttl" env:"INVENTORY_MAX_RESERVATION_TTL" env-default:"24h"`
+		ExpiryInterval    time.Duration `yaml:"expiry-interval" env:"INVENTORY_EXPIRY_INTERVAL" env-default:"30s"`
+	} `yaml:"inventory"`
+	Price struct {
+		SchedulerInterval time.Duration `yaml:"scheduler-interval" env:"PRICE_SCHEDULER_INTERVAL" env-default:"1m"`
+	} `yaml:"price"`
+	Lifecycle struct {
+		SchedulerInterval time.Duration `yaml:"scheduler-interval" env:"LIFECYCLE_SCHEDULER_INTERVAL" env-default:"1m"`
+	} `yaml:"lifecycle"`
+	Outbox struct {
+		RelayInterval time.Duration `yaml:"relay-interval" env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+		BatchSize     uint64        `yaml:"batch-size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+		Retention     time.Duration `yaml:"retention" env:"OUTBOX_RETENTION" env-default:"168h"`
+		Source        string        `yaml:"source" env:"OUTBOX_SOURCE" env-default:"goods-manager"`
+		ListenRetry   time.Duration `yaml:"listen-retry" env:"OUTBOX_LISTEN_RETRY" env-default:"5s"`
+		Publisher     string        `yaml:"publisher" env:"OUTBOX_PUBLISHER" env-default:"file"`
+		File          string        `yaml:"file" env:"OUTBOX_FILE"`
+		NATS          struct {
+			URL           string        `yaml:"url" env:"OUTBOX_NATS_URL" env-default:"nats://127.0.0.1:4222"`
+			SubjectPrefix string        `yaml:"subject-prefix" env:"OUTBOX_NATS_SUBJECT_PREFIX" env-default:"goods"`
+			Timeout       time.Duration `yaml:"timeout" env:"OUTBOX_NATS_TIMEOUT" env-default:"5s"`
+		} `yaml:"nats"`
+	} `yaml:"outbox"`
+	Locale struct {
+		Default    string            `yaml:"default" env:"LOCALE_DEFAULT" env-default:"en"`
+		Supported  []string          `yaml:"supported" env:"LOCALE_SUPPORTED" env-default:"en"`
+		Fallback   map[string]string `yaml:"fallback"`
+		TextSearch map[string]string `yaml:"text-search"`
+	} `yaml:"locale"`
 	PostgreSQL struct {
 		Username string `yaml:"username" env:"PSQL_USERNAME" env-required:"true"`
 		Password string `yaml:"password" env:"PSQL_PASSWORD" env-required:"true"`
@@ -81,6 +133,34 @@ func GetConfig() *Config {
 			log.Fatal(err)
 		}
 
+		if err := instance.validate(); err != nil {
+			log.Fatal(err)
+		}
 	})
 	return instance
 }
+
+// validate refuses settings the app can not run with, e.g. a zero interval
+// makes the background jobs panic on their ticker
+func (c *Config) validate() error {
+	intervals := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"inventory.expiry-interval", c.Inventory.ExpiryInterval},
+		{"price.scheduler-interval", c.Price.SchedulerInterval},
+		{"lifecycle.scheduler-interval", c.Lifecycle.SchedulerInterval},
+		{"outbox.relay-interval", c.Outbox.RelayInterval},
+	}
+	for _, interval := range intervals {
+		if interval.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", interval.name, interval.value)
+		}
+	}
+
+	// a relay reading no events at a time never gets through them
+	if c.Outbox.BatchSize == 0 {
+		return fmt.Errorf("outbox.batch-size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+func (s *Server) CreateAPIKey(
+	ctx context.Context,
+	req *pbProducts.CreateAPIKeyRequest,
+) (*pbProducts.CreateAPIKeyResponse, error) {
+	key, plain, err := s.apiKeyPolicy.Create(ctx, req.GetName(), req.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreateAPIKeyResponse{
+		ApiKey: key.ToProto(),
+		Key:    plain,
+	}, nil
+}
+
+func (s *Server) AllAPIKeys(
+	ctx context.Context,
+	_ *pbProducts.AllAPIKeysRequest,
+) (*pbProducts.AllAPIKeysResponse, error) {
+	all, err := s.apiKeyPolicy.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keysProto := make([]*pbProducts.APIKey, len(all))
+	for i, k := range all {
+		keysProto[i] = k.ToProto()
+	}
+
+	return &pbProducts.AllAPIKeysResponse{
+		ApiKeys: keysProto,
+	}, nil
+}
+
+func (s *Server) RevokeAPIKey(
+	ctx context.Context,
+	req *pbProducts.RevokeAPIKeyRequest,
+) (*pbProducts.RevokeAPIKeyResponse, error) {
+	err := s.apiKeyPolicy.Revoke(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.RevokeAPIKeyResponse{}, nil
+}
@@ -0,0 +1,104 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+// maxImportSize limits the products created by a single import request
+const maxImportSize = 1000
+
+// ProductByBarcode finds the product scanned by an EAN-8, UPC-A, EAN-13 or GTIN-14 code
+func (s *Server) ProductByBarcode(
+	ctx context.Context,
+	req *pbProducts.ProductByBarcodeRequest,
+) (*pbProducts.ProductByBarcodeResponse, error) {
+	id, err := s.barcodePolicy.ProductID(ctx, req.GetBarcode())
+	if err != nil {
+		return nil, err
+	}
+
+	one, err := s.policy.OneDetailed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.present(ctx, req, []*model.Product{one}); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ProductByBarcodeResponse{
+		Product: one.ToProto(),
+	}, nil
+}
+
+// ImportProducts creates the products one by one. Products failing validation, e.g. with
+// an invalid or taken barcode, are reported by their position and do not stop the import.
+func (s *Server) ImportProducts(
+	ctx context.Context,
+	req *pbProducts.ImportProductsRequest,
+) (*pbProducts.ImportProductsResponse, error) {
+	if len(req.GetProducts()) > maxImportSize {
+		var violations errors.FieldViolations
+		violations.Add("products", fmt.Sprintf("must have at most %d items", maxImportSize))
+		return nil, violations.Err()
+	}
+
+	res := &pbProducts.ImportProductsResponse{}
+	for i, productPB := range req.GetProducts() {
+		p, err := s.policy.CreateProduct(ctx, model.NewProductFromPB(productPB))
+		if errors.KindOf(err) == errors.KindValidation {
+			res.Failures = append(res.Failures, importFailure(i, err))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		res.Products = append(res.Products, p.ToProto())
+	}
+
+	return res, nil
+}
+
+func importFailure(index int, err error) *pbProducts.ImportFailure {
+	failure := &pbProducts.ImportFailure{Index: uint32(index)}
+	if domainErr, ok := errors.AsDomain(err); ok {
+		failure.Message = domainErr.Message
+	}
+	for _, v := range errors.ViolationsOf(err) {
+		failure.Violations = append(failure.Violations, &pbProducts.FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	return failure
+}
+
+// applyBarcodes attaches the barcodes to the products and their variants
+func (s *Server) applyBarcodes(ctx context.Context, products []*model.Product) error {
+	flat := withVariants(products)
+
+	ids := make([]string, len(flat))
+	for i, p := range flat {
+		ids[i] = p.ID
+	}
+
+	barcodes, err := s.barcodePolicy.ProductBarcodes(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range flat {
+		p.Barcodes = make([]*model.Barcode, len(barcodes[p.ID]))
+		for i, b := range barcodes[p.ID] {
+			p.Barcodes[i] = &model.Barcode{Code: b.Code, Type: b.Type, GTIN: b.GTIN}
+		}
+	}
+
+	return nil
+}
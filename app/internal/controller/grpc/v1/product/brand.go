@@ -0,0 +1,87 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/brand/model"
+)
+
+func (s *Server) CreateBrand(
+	ctx context.Context,
+	req *pbProducts.CreateBrandRequest,
+) (*pbProducts.CreateBrandResponse, error) {
+	brand, err := s.brandPolicy.Create(ctx, model.NewBrandFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreateBrandResponse{
+		Brand: brand.ToProto(),
+	}, nil
+}
+
+func (s *Server) AllBrands(
+	ctx context.Context,
+	_ *pbProducts.AllBrandsRequest,
+) (*pbProducts.AllBrandsResponse, error) {
+	all, err := s.brandPolicy.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	brandsProto := make([]*pbProducts.Brand, len(all))
+	for i, b := range all {
+		brandsProto[i] = b.ToProto()
+	}
+
+	return &pbProducts.AllBrandsResponse{
+		Brands: brandsProto,
+	}, nil
+}
+
+func (s *Server) BrandByID(
+	ctx context.Context,
+	req *pbProducts.BrandByIDRequest,
+) (*pbProducts.BrandByIDResponse, error) {
+	brand, err := s.brandPolicy.One(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.BrandByIDResponse{
+		Brand: brand.ToProto(),
+	}, nil
+}
+
+func (s *Server) UpdateBrand(
+	ctx context.Context,
+	req *pbProducts.UpdateBrandRequest,
+) (*pbProducts.UpdateBrandResponse, error) {
+	brand, err := s.brandPolicy.One(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	brand.UpdateFromPB(req)
+
+	if brand, err = s.brandPolicy.Update(ctx, brand); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.UpdateBrandResponse{
+		Brand: brand.ToProto(),
+	}, nil
+}
+
+// DeleteBrand removes a brand, a brand products still refer to can not be deleted
+func (s *Server) DeleteBrand(
+	ctx context.Context,
+	req *pbProducts.DeleteBrandRequest,
+) (*pbProducts.DeleteBrandResponse, error) {
+	if err := s.brandPolicy.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeleteBrandResponse{}, nil
+}
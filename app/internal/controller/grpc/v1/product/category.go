@@ -0,0 +1,33 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+func (s *Server) CategorySchema(
+	ctx context.Context,
+	req *pbProducts.CategorySchemaRequest,
+) (*pbProducts.CategorySchemaResponse, error) {
+	schema, err := s.categoryPolicy.Schema(ctx, req.GetCategoryId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CategorySchemaResponse{
+		CategoryId: schema.CategoryID,
+		Attributes: schema.ToProto(),
+	}, nil
+}
+
+func (s *Server) SetCategoryShippingClass(
+	ctx context.Context,
+	req *pbProducts.SetCategoryShippingClassRequest,
+) (*pbProducts.SetCategoryShippingClassResponse, error) {
+	if err := s.categoryPolicy.SetShippingClass(ctx, req.GetCategoryId(), req.GetShippingClass()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetCategoryShippingClassResponse{}, nil
+}
@@ -0,0 +1,115 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/inventory/model"
+)
+
+func (s *Server) CreateWarehouse(
+	ctx context.Context,
+	req *pbProducts.CreateWarehouseRequest,
+) (*pbProducts.CreateWarehouseResponse, error) {
+	warehouse, err := s.inventoryPolicy.CreateWarehouse(ctx, model.NewWarehouse(req.GetName(), req.GetCode()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreateWarehouseResponse{
+		Warehouse: warehouse.ToProto(),
+	}, nil
+}
+
+func (s *Server) AllWarehouses(
+	ctx context.Context,
+	_ *pbProducts.AllWarehousesRequest,
+) (*pbProducts.AllWarehousesResponse, error) {
+	all, err := s.inventoryPolicy.AllWarehouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	warehousesProto := make([]*pbProducts.Warehouse, len(all))
+	for i, w := range all {
+		warehousesProto[i] = w.ToProto()
+	}
+
+	return &pbProducts.AllWarehousesResponse{
+		Warehouses: warehousesProto,
+	}, nil
+}
+
+func (s *Server) ProductStock(
+	ctx context.Context,
+	req *pbProducts.ProductStockRequest,
+) (*pbProducts.ProductStockResponse, error) {
+	stock, err := s.inventoryPolicy.Stock(ctx, req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	stockProto := make([]*pbProducts.StockLevel, len(stock))
+	for i, st := range stock {
+		stockProto[i] = st.ToProto()
+	}
+
+	return &pbProducts.ProductStockResponse{
+		Stock: stockProto,
+	}, nil
+}
+
+func (s *Server) SetStock(
+	ctx context.Context,
+	req *pbProducts.SetStockRequest,
+) (*pbProducts.SetStockResponse, error) {
+	err := s.inventoryPolicy.SetStock(ctx, req.GetProductId(), req.GetWarehouseId(), req.GetOnHand())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetStockResponse{}, nil
+}
+
+func (s *Server) ReserveStock(
+	ctx context.Context,
+	req *pbProducts.ReserveStockRequest,
+) (*pbProducts.ReserveStockResponse, error) {
+	reservation, err := s.inventoryPolicy.Reserve(
+		ctx,
+		req.GetProductId(),
+		req.GetWarehouseId(),
+		req.GetQuantity(),
+		time.Duration(req.GetTtlSeconds())*time.Second,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ReserveStockResponse{
+		Reservation: reservation.ToProto(),
+	}, nil
+}
+
+func (s *Server) ReleaseReservation(
+	ctx context.Context,
+	req *pbProducts.ReleaseReservationRequest,
+) (*pbProducts.ReleaseReservationResponse, error) {
+	if err := s.inventoryPolicy.Release(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ReleaseReservationResponse{}, nil
+}
+
+func (s *Server) CommitReservation(
+	ctx context.Context,
+	req *pbProducts.CommitReservationRequest,
+) (*pbProducts.CommitReservationResponse, error) {
+	if err := s.inventoryPolicy.Commit(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CommitReservationResponse{}, nil
+}
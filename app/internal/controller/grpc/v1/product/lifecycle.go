@@ -0,0 +1,49 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+func (s *Server) TransitionProduct(
+	ctx context.Context,
+	req *pbProducts.TransitionProductRequest,
+) (*pbProducts.TransitionProductResponse, error) {
+	product, err := s.policy.Transition(ctx, req.GetId(), req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.TransitionProductResponse{
+		Product: product.ToProto(),
+	}, nil
+}
+
+// ScheduleProductPublication sets or, when the moments are not set, clears
+// the publishing schedule of the product
+func (s *Server) ScheduleProductPublication(
+	ctx context.Context,
+	req *pbProducts.ScheduleProductPublicationRequest,
+) (*pbProducts.ScheduleProductPublicationResponse, error) {
+	err := s.policy.SchedulePublication(
+		ctx,
+		req.GetProductId(),
+		fromUnixMilli(req.PublishAt),
+		fromUnixMilli(req.UnpublishAt),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ScheduleProductPublicationResponse{}, nil
+}
+
+func fromUnixMilli(ms *int64) *time.Time {
+	if ms == nil {
+		return nil
+	}
+	t := time.UnixMilli(*ms)
+	return &t
+}
@@ -0,0 +1,73 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/price/model"
+)
+
+func (s *Server) SchedulePrice(
+	ctx context.Context,
+	req *pbProducts.SchedulePriceRequest,
+) (*pbProducts.SchedulePriceResponse, error) {
+	price, err := s.pricePolicy.Schedule(ctx, model.NewPriceFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SchedulePriceResponse{
+		Price: price.ToProto(),
+	}, nil
+}
+
+func (s *Server) CancelScheduledPrice(
+	ctx context.Context,
+	req *pbProducts.CancelScheduledPriceRequest,
+) (*pbProducts.CancelScheduledPriceResponse, error) {
+	if err := s.pricePolicy.CancelScheduled(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CancelScheduledPriceResponse{}, nil
+}
+
+func (s *Server) PriceHistory(
+	ctx context.Context,
+	req *pbProducts.PriceHistoryRequest,
+) (*pbProducts.PriceHistoryResponse, error) {
+	history, err := s.pricePolicy.History(ctx, req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	pricesProto := make([]*pbProducts.PriceEntry, len(history))
+	for i, p := range history {
+		pricesProto[i] = p.ToProto()
+	}
+
+	return &pbProducts.PriceHistoryResponse{
+		Prices: pricesProto,
+	}, nil
+}
+
+// PriceAt answers what the product cost at the moment, now if it is not set
+func (s *Server) PriceAt(
+	ctx context.Context,
+	req *pbProducts.PriceAtRequest,
+) (*pbProducts.PriceAtResponse, error) {
+	var at time.Time
+	if req.GetAt() != 0 {
+		at = time.UnixMilli(req.GetAt())
+	}
+
+	price, err := s.pricePolicy.At(ctx, req.GetProductId(), at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.PriceAtResponse{
+		Price: price.ToProto(),
+	}, nil
+}
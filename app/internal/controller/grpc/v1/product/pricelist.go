@@ -0,0 +1,138 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/model"
+	productModel "github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+)
+
+// priceGroupRequest is implemented by requests which may ask for a price list
+type priceGroupRequest interface {
+	GetCustomerGroup() string
+	GetChannel() string
+}
+
+func (s *Server) CreatePriceList(
+	ctx context.Context,
+	req *pbProducts.CreatePriceListRequest,
+) (*pbProducts.CreatePriceListResponse, error) {
+	priceList, err := s.priceListPolicy.Create(ctx, model.NewPriceListFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreatePriceListResponse{
+		PriceList: priceList.ToProto(),
+	}, nil
+}
+
+func (s *Server) AllPriceLists(
+	ctx context.Context,
+	_ *pbProducts.AllPriceListsRequest,
+) (*pbProducts.AllPriceListsResponse, error) {
+	all, err := s.priceListPolicy.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listsProto := make([]*pbProducts.PriceList, len(all))
+	for i, pl := range all {
+		listsProto[i] = pl.ToProto()
+	}
+
+	return &pbProducts.AllPriceListsResponse{
+		PriceLists: listsProto,
+	}, nil
+}
+
+func (s *Server) DeletePriceList(
+	ctx context.Context,
+	req *pbProducts.DeletePriceListRequest,
+) (*pbProducts.DeletePriceListResponse, error) {
+	if err := s.priceListPolicy.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeletePriceListResponse{}, nil
+}
+
+func (s *Server) SetPriceListPrices(
+	ctx context.Context,
+	req *pbProducts.SetPriceListPricesRequest,
+) (*pbProducts.SetPriceListPricesResponse, error) {
+	err := s.priceListPolicy.SetTiers(ctx, req.GetPriceListId(), req.GetProductId(), model.NewTiersFromPB(req.GetTiers()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetPriceListPricesResponse{}, nil
+}
+
+// resolvePrices picks a price list for every product, products without one keep the base price
+func (s *Server) resolvePrices(
+	ctx context.Context,
+	req priceGroupRequest,
+	products []*productModel.Product,
+) (map[string]*model.Resolved, error) {
+	ids := make([]string, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+
+	group := s.priceListPolicy.CustomerGroup(ctx, req.GetCustomerGroup())
+	return s.priceListPolicy.Resolve(ctx, group, req.GetChannel(), ids)
+}
+
+// applyPriceLists replaces the base price of the products and their variants
+// with the single unit price of the price list resolved for the caller
+func (s *Server) applyPriceLists(ctx context.Context, req priceGroupRequest, products []*productModel.Product) error {
+	flat := withVariants(products)
+
+	resolved, err := s.resolvePrices(ctx, req, flat)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range flat {
+		r, ok := resolved[p.ID]
+		if !ok {
+			continue
+		}
+		price, ok := r.PriceFor(1)
+		if !ok {
+			continue
+		}
+
+		unitPrice, err := money.FromMinor(price, r.Currency)
+		if err != nil {
+			return err
+		}
+
+		priceListID := r.PriceListID
+		p.Price = unitPrice
+		p.CurrencyID = r.CurrencyID
+		p.PriceListID = &priceListID
+		p.PriceTiers = make([]*productModel.PriceTier, len(r.Tiers))
+		for i, t := range r.Tiers {
+			tierPrice, err := money.FromMinor(t.Price, r.Currency)
+			if err != nil {
+				return err
+			}
+			p.PriceTiers[i] = &productModel.PriceTier{MinQuantity: t.MinQuantity, Price: tierPrice}
+		}
+	}
+
+	return nil
+}
+
+func withVariants(products []*productModel.Product) []*productModel.Product {
+	var flat []*productModel.Product
+	for _, p := range products {
+		flat = append(flat, p)
+		flat = append(flat, p.Variants...)
+	}
+	return flat
+}
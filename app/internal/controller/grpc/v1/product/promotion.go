@@ -0,0 +1,146 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	productModel "github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/promotion/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+)
+
+func (s *Server) CreatePromotion(
+	ctx context.Context,
+	req *pbProducts.CreatePromotionRequest,
+) (*pbProducts.CreatePromotionResponse, error) {
+	p, err := model.NewPromotionFromPB(req)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err := s.promotionPolicy.Create(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreatePromotionResponse{
+		Promotion: promotion.ToProto(),
+	}, nil
+}
+
+func (s *Server) AllPromotions(
+	ctx context.Context,
+	_ *pbProducts.AllPromotionsRequest,
+) (*pbProducts.AllPromotionsResponse, error) {
+	all, err := s.promotionPolicy.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	promotionsProto := make([]*pbProducts.Promotion, len(all))
+	for i, p := range all {
+		promotionsProto[i] = p.ToProto()
+	}
+
+	return &pbProducts.AllPromotionsResponse{
+		Promotions: promotionsProto,
+	}, nil
+}
+
+func (s *Server) DeletePromotion(
+	ctx context.Context,
+	req *pbProducts.DeletePromotionRequest,
+) (*pbProducts.DeletePromotionResponse, error) {
+	if err := s.promotionPolicy.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeletePromotionResponse{}, nil
+}
+
+func (s *Server) QuotePrices(
+	ctx context.Context,
+	req *pbProducts.QuotePricesRequest,
+) (*pbProducts.QuotePricesResponse, error) {
+	products := make([]*productModel.Product, len(req.GetLines()))
+	for i, l := range req.GetLines() {
+		product, err := s.policy.OneDetailed(ctx, l.GetProductId())
+		if err != nil {
+			return nil, err
+		}
+		products[i] = product
+	}
+
+	resolved, err := s.resolvePrices(ctx, req, products)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]model.Line, len(products))
+	for i, l := range req.GetLines() {
+		lines[i] = newLine(products[i], l.GetQuantity())
+		if r, ok := resolved[products[i].ID]; ok {
+			if price, ok := r.PriceFor(l.GetQuantity()); ok {
+				lines[i].UnitPrice = price
+			}
+		}
+	}
+
+	quotes, err := s.promotionPolicy.Quote(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbProducts.QuotePricesResponse{
+		Lines: make([]*pbProducts.QuoteLine, len(quotes)),
+	}
+	for i, q := range quotes {
+		resp.Lines[i] = q.ToProto()
+		resp.Subtotal += q.Subtotal
+		resp.Discount += q.Discount
+		resp.Total += q.Total
+	}
+
+	return resp, nil
+}
+
+// applyPromotions sets the discounted unit price of the products and their variants
+func (s *Server) applyPromotions(ctx context.Context, products []*productModel.Product) error {
+	flat := withVariants(products)
+	if len(flat) == 0 {
+		return nil
+	}
+
+	lines := make([]model.Line, len(flat))
+	for i, p := range flat {
+		lines[i] = newLine(p, 1)
+	}
+
+	// a listing is no cart, every product is priced on its own
+	quotes, err := s.promotionPolicy.QuoteEach(ctx, lines)
+	if err != nil {
+		return err
+	}
+
+	for i, q := range quotes {
+		if q.Discount != 0 {
+			discounted, err := money.FromMinor(q.Total, flat[i].Price.Currency)
+			if err != nil {
+				return err
+			}
+			flat[i].DiscountedPrice = &discounted
+		}
+	}
+
+	return nil
+}
+
+func newLine(p *productModel.Product, quantity uint64) model.Line {
+	return model.Line{
+		ProductID:     p.ID,
+		CategoryID:    p.CategoryID,
+		Specification: p.Specification,
+		UnitPrice:     p.Price.Minor(),
+		Quantity:      quantity,
+	}
+}
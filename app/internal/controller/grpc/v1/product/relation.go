@@ -0,0 +1,200 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/filter"
+	productModel "github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/relation/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+func (s *Server) CreateProductRelation(
+	ctx context.Context,
+	req *pbProducts.CreateProductRelationRequest,
+) (*pbProducts.CreateProductRelationResponse, error) {
+	relation, err := s.relationPolicy.Create(ctx, model.NewRelationFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreateProductRelationResponse{
+		Relation: relation.ToProto(),
+	}, nil
+}
+
+func (s *Server) ProductRelations(
+	ctx context.Context,
+	req *pbProducts.ProductRelationsRequest,
+) (*pbProducts.ProductRelationsResponse, error) {
+	relations, err := s.relationPolicy.All(ctx, req.GetProductId(), req.GetTypes())
+	if err != nil {
+		return nil, err
+	}
+
+	relationsProto := make([]*pbProducts.ProductRelation, len(relations))
+	for i, r := range relations {
+		relationsProto[i] = r.ToProto()
+	}
+
+	return &pbProducts.ProductRelationsResponse{
+		Relations: relationsProto,
+	}, nil
+}
+
+func (s *Server) DeleteProductRelation(
+	ctx context.Context,
+	req *pbProducts.DeleteProductRelationRequest,
+) (*pbProducts.DeleteProductRelationResponse, error) {
+	if err := s.relationPolicy.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeleteProductRelationResponse{}, nil
+}
+
+// SetProductBundle makes the product a bundle of the components, no components remove the bundle
+func (s *Server) SetProductBundle(
+	ctx context.Context,
+	req *pbProducts.SetProductBundleRequest,
+) (*pbProducts.SetProductBundleResponse, error) {
+	bundle := model.NewBundleFromPB(req)
+	if err := s.relationPolicy.SetBundle(ctx, bundle); err != nil {
+		return nil, err
+	}
+
+	if len(bundle.Components) == 0 {
+		return &pbProducts.SetProductBundleResponse{}, nil
+	}
+
+	bundleProto, err := s.bundleToProto(ctx, &pbProducts.ProductBundleRequest{ProductId: bundle.ProductID}, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetProductBundleResponse{
+		Bundle: bundleProto,
+	}, nil
+}
+
+func (s *Server) ProductBundle(
+	ctx context.Context,
+	req *pbProducts.ProductBundleRequest,
+) (*pbProducts.ProductBundleResponse, error) {
+	bundle, err := s.relationPolicy.Bundle(ctx, req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	bundleProto, err := s.bundleToProto(ctx, req, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ProductBundleResponse{
+		Bundle: bundleProto,
+	}, nil
+}
+
+// relatedRequest is implemented by requests which may ask for linked products
+type relatedRequest interface {
+	presentRequest
+	GetIncludeRelations() []string
+	GetIncludeBundle() bool
+}
+
+// applyRelations adds the related products of the asked types and the bundle
+// to the product response. Related products the caller can not see are left out.
+func (s *Server) applyRelations(ctx context.Context, req relatedRequest, product *pbProducts.Product) error {
+	if len(req.GetIncludeRelations()) != 0 {
+		relations, err := s.relationPolicy.All(ctx, product.GetId(), req.GetIncludeRelations())
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, len(relations))
+		for i, r := range relations {
+			ids[i] = r.RelatedID
+		}
+
+		related, err := s.productsByID(ctx, req, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range relations {
+			p, ok := related[r.RelatedID]
+			if !ok {
+				continue
+			}
+			product.Related = append(product.Related, &pbProducts.RelatedProduct{
+				Relation: r.ToProto(),
+				Product:  p.ToProto(),
+			})
+		}
+	}
+
+	if req.GetIncludeBundle() {
+		bundle, err := s.relationPolicy.Bundle(ctx, product.GetId())
+		if errors.KindOf(err) == errors.KindNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if product.Bundle, err = s.bundleToProto(ctx, req, bundle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bundleToProto prices the bundle by the prices of its components the caller gets
+func (s *Server) bundleToProto(
+	ctx context.Context,
+	req presentRequest,
+	bundle *model.Bundle,
+) (*pbProducts.ProductBundle, error) {
+	components, err := s.productsByID(ctx, req, bundle.ComponentIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]uint64, len(components))
+	for id, p := range components {
+		prices[id] = p.Price.Minor()
+	}
+
+	price, _ := bundle.Price(prices)
+	return bundle.ToProto(price), nil
+}
+
+// productsByID loads the products visible to the caller and prepares them for a response
+func (s *Server) productsByID(
+	ctx context.Context,
+	req presentRequest,
+	ids []string,
+) (map[string]*productModel.Product, error) {
+	if len(ids) == 0 {
+		return map[string]*productModel.Product{}, nil
+	}
+
+	products, err := s.policy.All(ctx, []filter.Criteria{filter.NewIDCriteria(ids)}, filter.NewSort("name", "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.present(ctx, req, products); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*productModel.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+
+	return byID, nil
+}
@@ -0,0 +1,70 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/review/model"
+)
+
+func (s *Server) CreateReview(
+	ctx context.Context,
+	req *pbProducts.CreateReviewRequest,
+) (*pbProducts.CreateReviewResponse, error) {
+	review, err := s.reviewPolicy.Create(ctx, model.NewReviewFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreateReviewResponse{
+		Review: review.ToProto(),
+	}, nil
+}
+
+// ProductReviews returns a page of the product reviews together with their summary
+func (s *Server) ProductReviews(
+	ctx context.Context,
+	req *pbProducts.ProductReviewsRequest,
+) (*pbProducts.ProductReviewsResponse, error) {
+	reviews, err := s.reviewPolicy.All(ctx, req.GetProductId(), req.GetStatus(), req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.reviewPolicy.Summary(ctx, req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	reviewsProto := make([]*pbProducts.Review, len(reviews))
+	for i, r := range reviews {
+		reviewsProto[i] = r.ToProto()
+	}
+
+	return &pbProducts.ProductReviewsResponse{
+		Reviews: reviewsProto,
+		Summary: summary.ToProto(),
+	}, nil
+}
+
+func (s *Server) ModerateReview(
+	ctx context.Context,
+	req *pbProducts.ModerateReviewRequest,
+) (*pbProducts.ModerateReviewResponse, error) {
+	if err := s.reviewPolicy.Moderate(ctx, req.GetId(), req.GetStatus()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ModerateReviewResponse{}, nil
+}
+
+func (s *Server) DeleteReview(
+	ctx context.Context,
+	req *pbProducts.DeleteReviewRequest,
+) (*pbProducts.DeleteReviewResponse, error) {
+	if err := s.reviewPolicy.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeleteReviewResponse{}, nil
+}
@@ -3,23 +3,82 @@ package product
 import (
 	"context"
 	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	apiKeyPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/apikey/policy"
+	barcodePolicy "github.com/ilkinabd/goods-manager/app/internal/domain/barcode/policy"
+	brandPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/brand/policy"
+	categoryPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/category/policy"
+	inventoryPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/inventory/policy"
+	outboxPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/policy"
+	pricePolicy "github.com/ilkinabd/goods-manager/app/internal/domain/price/policy"
+	priceListPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/policy"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/filter"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/policy"
-	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	promotionPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/promotion/policy"
+	relationPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/relation/policy"
+	reviewPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/review/policy"
+	slugPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/slug/policy"
+	tagPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/tag/policy"
+	taxPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/tax/policy"
+	translationPolicy "github.com/ilkinabd/goods-manager/app/internal/domain/translation/policy"
 )
 
 type Server struct {
-	policy *policy.ProductPolicy
+	policy            *policy.ProductPolicy
+	apiKeyPolicy      *apiKeyPolicy.APIKeyPolicy
+	categoryPolicy    *categoryPolicy.CategoryPolicy
+	inventoryPolicy   *inventoryPolicy.InventoryPolicy
+	pricePolicy       *pricePolicy.PricePolicy
+	promotionPolicy   *promotionPolicy.PromotionPolicy
+	priceListPolicy   *priceListPolicy.PriceListPolicy
+	translationPolicy *translationPolicy.TranslationPolicy
+	slugPolicy        *slugPolicy.SlugPolicy
+	relationPolicy    *relationPolicy.RelationPolicy
+	reviewPolicy      *reviewPolicy.ReviewPolicy
+	tagPolicy         *tagPolicy.TagPolicy
+	brandPolicy       *brandPolicy.BrandPolicy
+	barcodePolicy     *barcodePolicy.BarcodePolicy
+	taxPolicy         *taxPolicy.TaxPolicy
+	outboxPolicy      *outboxPolicy.OutboxPolicy
 	pbProducts.UnimplementedProductServiceServer
 }
 
 func NewServer(
 	policy *policy.ProductPolicy,
+	apiKeyPolicy *apiKeyPolicy.APIKeyPolicy,
+	categoryPolicy *categoryPolicy.CategoryPolicy,
+	inventoryPolicy *inventoryPolicy.InventoryPolicy,
+	pricePolicy *pricePolicy.PricePolicy,
+	promotionPolicy *promotionPolicy.PromotionPolicy,
+	priceListPolicy *priceListPolicy.PriceListPolicy,
+	translationPolicy *translationPolicy.TranslationPolicy,
+	slugPolicy *slugPolicy.SlugPolicy,
+	relationPolicy *relationPolicy.RelationPolicy,
+	reviewPolicy *reviewPolicy.ReviewPolicy,
+	tagPolicy *tagPolicy.TagPolicy,
+	brandPolicy *brandPolicy.BrandPolicy,
+	barcodePolicy *barcodePolicy.BarcodePolicy,
+	taxPolicy *taxPolicy.TaxPolicy,
+	outboxPolicy *outboxPolicy.OutboxPolicy,
 	srv pbProducts.UnimplementedProductServiceServer,
 ) *Server {
 	return &Server{
 		policy:                            policy,
+		apiKeyPolicy:                      apiKeyPolicy,
+		categoryPolicy:                    categoryPolicy,
+		inventoryPolicy:                   inventoryPolicy,
+		pricePolicy:                       pricePolicy,
+		promotionPolicy:                   promotionPolicy,
+		priceListPolicy:                   priceListPolicy,
+		translationPolicy:                 translationPolicy,
+		slugPolicy:                        slugPolicy,
+		relationPolicy:                    relationPolicy,
+		reviewPolicy:                      reviewPolicy,
+		tagPolicy:                         tagPolicy,
+		brandPolicy:                       brandPolicy,
+		barcodePolicy:                     barcodePolicy,
+		taxPolicy:                         taxPolicy,
+		outboxPolicy:                      outboxPolicy,
 		UnimplementedProductServiceServer: srv,
 	}
 }
@@ -30,23 +89,76 @@ func (s *Server) AllProducts(
 ) (*pbProducts.AllProductsResponse, error) {
 
 	sort := filter.NewSortFromPB(request)
-	criteria := make([]filter.Criteria, 1)
+	criteria := make([]filter.Criteria, 0)
 
 	categoryCriteria := filter.NewCategoryCriteriaFromPB(request)
 	criteria = append(criteria, categoryCriteria)
 
+	collapseCriteria := filter.NewCollapseVariantsCriteriaFromPB(request)
+	criteria = append(criteria, collapseCriteria)
+
+	inStockCriteria := filter.NewInStockCriteriaFromPB(request)
+	criteria = append(criteria, inStockCriteria)
+
+	statusCriteria := filter.NewStatusCriteriaFromPB(request)
+	criteria = append(criteria, statusCriteria)
+
+	tagCriteria := filter.NewTagCriteriaFromPB(request)
+	criteria = append(criteria, tagCriteria)
+
+	searchCriteria := filter.NewSearchCriteriaFromPB(
+		request,
+		s.translationPolicy.Locale(ctx),
+		s.translationPolicy.TextSearchConfig(ctx),
+	)
+	criteria = append(criteria, searchCriteria)
+
+	measureCriteria, err := filter.NewMeasureCriteriaFromPB(request)
+	if err != nil {
+		return nil, err
+	}
+	criteria = append(criteria, measureCriteria...)
+
+	// brand facets leave the brand filter out, so the other brands stay selectable
+	var brandFacetsProto []*pbProducts.BrandFacet
+	if request.GetBrandFacets() {
+		facets, err := s.policy.BrandFacets(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+
+		brandFacetsProto = make([]*pbProducts.BrandFacet, len(facets))
+		for i, f := range facets {
+			brandFacetsProto[i] = f.ToProto()
+		}
+	}
+
+	brandCriteria := filter.NewBrandCriteriaFromPB(request)
+	criteria = append(criteria, brandCriteria)
+
 	all, err := s.policy.All(ctx, criteria, sort)
 	if err != nil {
 		return nil, err
 	}
 
+	if request.GetCollapseVariants() {
+		if err = s.policy.NestVariants(ctx, all); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.present(ctx, request, all); err != nil {
+		return nil, err
+	}
+
 	productsProto := make([]*pbProducts.Product, len(all))
 	for i, p := range all {
 		productsProto[i] = p.ToProto()
 	}
 
 	return &pbProducts.AllProductsResponse{
-		Products: productsProto,
+		Products:    productsProto,
+		BrandFacets: brandFacetsProto,
 	}, nil
 }
 
@@ -54,13 +166,22 @@ func (s *Server) ProductByID(
 	ctx context.Context,
 	req *pbProducts.ProductByIDRequest,
 ) (*pbProducts.ProductByIDResponse, error) {
-	one, err := s.policy.One(ctx, req.Id)
+	one, err := s.policy.OneDetailed(ctx, req.Id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = s.present(ctx, req, []*model.Product{one}); err != nil {
+		return nil, err
+	}
+
+	productProto := one.ToProto()
+	if err = s.applyRelations(ctx, req, productProto); err != nil {
+		return nil, err
+	}
+
 	return &pbProducts.ProductByIDResponse{
-		Product: one.ToProto(),
+		Product: productProto,
 	}, nil
 }
 
@@ -99,18 +220,60 @@ func (s *Server) CreateProduct(
 	ctx context.Context,
 	req *pbProducts.CreateProductRequest,
 ) (*pbProducts.CreateProductResponse, error) {
-	p, err := model.NewProductFromPB(req)
+	product, err := s.policy.CreateProduct(ctx, model.NewProductFromPB(req))
 	if err != nil {
-		logging.WithError(ctx, err).WithField("product in pb", req).Error("model.NewProductFromPB")
 		return nil, err
 	}
 
-	product, err := s.policy.CreateProduct(ctx, p)
+	return &pbProducts.CreateProductResponse{
+		Product: product.ToProto(),
+	}, nil
+}
+
+func (s *Server) SetProductOptionAxes(
+	ctx context.Context,
+	req *pbProducts.SetProductOptionAxesRequest,
+) (*pbProducts.SetProductOptionAxesResponse, error) {
+	err := s.policy.SetOptionAxes(ctx, req.GetProductId(), model.NewOptionAxesFromPB(req.GetOptionAxes()))
 	if err != nil {
 		return nil, err
 	}
 
-	return &pbProducts.CreateProductResponse{
-		Product: product.ToProto(),
-	}, nil
+	return &pbProducts.SetProductOptionAxesResponse{}, nil
+}
+
+// presentRequest is implemented by requests reading products for a customer
+type presentRequest interface {
+	priceGroupRequest
+	GetTaxRegion() string
+}
+
+// present prepares products for a response: localized content, slugs, tags and barcodes,
+// the price of the caller's price list, the promotion discounted price and the taxes of the region
+func (s *Server) present(ctx context.Context, req presentRequest, products []*model.Product) error {
+	if err := s.localize(ctx, products); err != nil {
+		return err
+	}
+
+	if err := s.applySlugs(ctx, products); err != nil {
+		return err
+	}
+
+	if err := s.applyTags(ctx, products); err != nil {
+		return err
+	}
+
+	if err := s.applyBarcodes(ctx, products); err != nil {
+		return err
+	}
+
+	if err := s.applyPriceLists(ctx, req, products); err != nil {
+		return err
+	}
+
+	if err := s.applyPromotions(ctx, products); err != nil {
+		return err
+	}
+
+	return s.applyTaxes(ctx, req, products)
 }
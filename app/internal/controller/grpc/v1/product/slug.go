@@ -0,0 +1,89 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	productModel "github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+)
+
+// ProductBySlug returns the product and tells whether the slug is a former one
+// the client should redirect from to the current slug
+func (s *Server) ProductBySlug(
+	ctx context.Context,
+	req *pbProducts.ProductBySlugRequest,
+) (*pbProducts.ProductBySlugResponse, error) {
+	lookup, err := s.slugPolicy.Lookup(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	one, err := s.policy.OneDetailed(ctx, lookup.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.present(ctx, req, []*productModel.Product{one}); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ProductBySlugResponse{
+		Product:     one.ToProto(),
+		Redirect:    lookup.Redirect,
+		CurrentSlug: lookup.CurrentSlug,
+	}, nil
+}
+
+func (s *Server) SetProductSlug(
+	ctx context.Context,
+	req *pbProducts.SetProductSlugRequest,
+) (*pbProducts.SetProductSlugResponse, error) {
+	if err := s.slugPolicy.Set(ctx, req.GetProductId(), req.GetLocale(), req.GetSlug()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetProductSlugResponse{}, nil
+}
+
+func (s *Server) ProductSlugs(
+	ctx context.Context,
+	req *pbProducts.ProductSlugsRequest,
+) (*pbProducts.ProductSlugsResponse, error) {
+	history, err := s.slugPolicy.History(ctx, req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	slugsProto := make([]*pbProducts.ProductSlug, len(history))
+	for i, sl := range history {
+		slugsProto[i] = sl.ToProto()
+	}
+
+	return &pbProducts.ProductSlugsResponse{
+		Slugs: slugsProto,
+	}, nil
+}
+
+// applySlugs sets the current slug of the products and their variants in the request locale
+func (s *Server) applySlugs(ctx context.Context, products []*productModel.Product) error {
+	flat := withVariants(products)
+	if len(flat) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(flat))
+	for i, p := range flat {
+		ids[i] = p.ID
+	}
+
+	current, err := s.slugPolicy.Current(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range flat {
+		p.Slug = current[p.ID]
+	}
+
+	return nil
+}
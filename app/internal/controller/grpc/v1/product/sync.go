@@ -0,0 +1,57 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	outboxDao "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/model"
+)
+
+// SyncProducts returns the products changed since the sync token as upserts and tombstones,
+// page by page, with the token to go on from. Products deleted or no longer visible to the
+// caller are tombstones. A client without a token or with one too old to go on from is told
+// to resync in full: it loads the products anew and syncs from the token returned.
+func (s *Server) SyncProducts(
+	ctx context.Context,
+	req *pbProducts.SyncProductsRequest,
+) (*pbProducts.SyncProductsResponse, error) {
+	changes, err := s.outboxPolicy.Sync(ctx, req.GetToken(), req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbProducts.SyncProductsResponse{
+		NextToken:  model.EncodeSyncToken(changes.Position),
+		HasMore:    changes.More,
+		FullResync: req.GetToken() == "" || changes.Expired,
+	}
+
+	latest := changes.Latest()
+
+	ids := make([]string, 0, len(latest))
+	for _, e := range latest {
+		if e.Type != outboxDao.ProductDeleted {
+			ids = append(ids, e.ProductID)
+		}
+	}
+
+	products, err := s.productsByID(ctx, req, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range latest {
+		if p, ok := products[e.ProductID]; ok && e.Type != outboxDao.ProductDeleted {
+			resp.Upserts = append(resp.Upserts, p.ToProto())
+			continue
+		}
+
+		resp.Tombstones = append(resp.Tombstones, &pbProducts.ProductTombstone{
+			ProductId: e.ProductID,
+			RemovedAt: e.CreatedAt.UnixMilli(),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,120 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	productModel "github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tag/model"
+)
+
+func (s *Server) CreateTag(
+	ctx context.Context,
+	req *pbProducts.CreateTagRequest,
+) (*pbProducts.CreateTagResponse, error) {
+	tag, err := s.tagPolicy.Create(ctx, model.NewTagFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreateTagResponse{
+		Tag: tag.ToProto(),
+	}, nil
+}
+
+func (s *Server) AllTags(
+	ctx context.Context,
+	_ *pbProducts.AllTagsRequest,
+) (*pbProducts.AllTagsResponse, error) {
+	all, err := s.tagPolicy.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tagsProto := make([]*pbProducts.Tag, len(all))
+	for i, t := range all {
+		tagsProto[i] = t.ToProto()
+	}
+
+	return &pbProducts.AllTagsResponse{
+		Tags: tagsProto,
+	}, nil
+}
+
+func (s *Server) DeleteTag(
+	ctx context.Context,
+	req *pbProducts.DeleteTagRequest,
+) (*pbProducts.DeleteTagResponse, error) {
+	if err := s.tagPolicy.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeleteTagResponse{}, nil
+}
+
+// AssignTags labels every product with every tag
+func (s *Server) AssignTags(
+	ctx context.Context,
+	req *pbProducts.AssignTagsRequest,
+) (*pbProducts.AssignTagsResponse, error) {
+	if err := s.tagPolicy.Assign(ctx, req.GetProductIds(), req.GetTagIds()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.AssignTagsResponse{}, nil
+}
+
+func (s *Server) UnassignTags(
+	ctx context.Context,
+	req *pbProducts.UnassignTagsRequest,
+) (*pbProducts.UnassignTagsResponse, error) {
+	if err := s.tagPolicy.Unassign(ctx, req.GetProductIds(), req.GetTagIds()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.UnassignTagsResponse{}, nil
+}
+
+// TagCloud counts the products the caller can see per tag, the most used tags first
+func (s *Server) TagCloud(
+	ctx context.Context,
+	_ *pbProducts.TagCloudRequest,
+) (*pbProducts.TagCloudResponse, error) {
+	cloud, err := s.tagPolicy.Cloud(ctx, s.policy.VisibleStatuses(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	tagsProto := make([]*pbProducts.TagCount, len(cloud))
+	for i, tc := range cloud {
+		tagsProto[i] = tc.ToProto()
+	}
+
+	return &pbProducts.TagCloudResponse{
+		Tags: tagsProto,
+	}, nil
+}
+
+// applyTags attaches the tags to the products and their variants
+func (s *Server) applyTags(ctx context.Context, products []*productModel.Product) error {
+	flat := withVariants(products)
+
+	ids := make([]string, len(flat))
+	for i, p := range flat {
+		ids[i] = p.ID
+	}
+
+	tags, err := s.tagPolicy.ProductTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range flat {
+		p.Tags = make([]*productModel.Tag, len(tags[p.ID]))
+		for i, t := range tags[p.ID] {
+			p.Tags[i] = &productModel.Tag{ID: t.ID, Name: t.Name, Slug: t.Slug}
+		}
+	}
+
+	return nil
+}
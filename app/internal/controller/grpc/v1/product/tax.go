@@ -0,0 +1,183 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	productModel "github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tax/model"
+)
+
+func (s *Server) CreateTaxClass(
+	ctx context.Context,
+	req *pbProducts.CreateTaxClassRequest,
+) (*pbProducts.CreateTaxClassResponse, error) {
+	class, err := s.taxPolicy.CreateClass(ctx, model.NewClassFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CreateTaxClassResponse{
+		TaxClass: class.ToProto(),
+	}, nil
+}
+
+func (s *Server) AllTaxClasses(
+	ctx context.Context,
+	_ *pbProducts.AllTaxClassesRequest,
+) (*pbProducts.AllTaxClassesResponse, error) {
+	all, err := s.taxPolicy.AllClasses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	classesProto := make([]*pbProducts.TaxClass, len(all))
+	for i, c := range all {
+		classesProto[i] = c.ToProto()
+	}
+
+	return &pbProducts.AllTaxClassesResponse{
+		TaxClasses: classesProto,
+	}, nil
+}
+
+func (s *Server) DeleteTaxClass(
+	ctx context.Context,
+	req *pbProducts.DeleteTaxClassRequest,
+) (*pbProducts.DeleteTaxClassResponse, error) {
+	if err := s.taxPolicy.DeleteClass(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeleteTaxClassResponse{}, nil
+}
+
+func (s *Server) SetCategoryTaxClass(
+	ctx context.Context,
+	req *pbProducts.SetCategoryTaxClassRequest,
+) (*pbProducts.SetCategoryTaxClassResponse, error) {
+	if err := s.taxPolicy.SetCategoryClass(ctx, req.GetCategoryId(), req.GetTaxClassId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetCategoryTaxClassResponse{}, nil
+}
+
+func (s *Server) SetTaxRegion(
+	ctx context.Context,
+	req *pbProducts.SetTaxRegionRequest,
+) (*pbProducts.SetTaxRegionResponse, error) {
+	region, err := s.taxPolicy.SetRegion(ctx, model.NewRegionFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetTaxRegionResponse{
+		TaxRegion: region.ToProto(),
+	}, nil
+}
+
+func (s *Server) AllTaxRegions(
+	ctx context.Context,
+	_ *pbProducts.AllTaxRegionsRequest,
+) (*pbProducts.AllTaxRegionsResponse, error) {
+	all, err := s.taxPolicy.AllRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	regionsProto := make([]*pbProducts.TaxRegion, len(all))
+	for i, r := range all {
+		regionsProto[i] = r.ToProto()
+	}
+
+	return &pbProducts.AllTaxRegionsResponse{
+		TaxRegions: regionsProto,
+	}, nil
+}
+
+func (s *Server) ScheduleTaxRate(
+	ctx context.Context,
+	req *pbProducts.ScheduleTaxRateRequest,
+) (*pbProducts.ScheduleTaxRateResponse, error) {
+	rate, err := s.taxPolicy.ScheduleRate(ctx, model.NewRateFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.ScheduleTaxRateResponse{
+		TaxRate: rate.ToProto(),
+	}, nil
+}
+
+func (s *Server) CancelScheduledTaxRate(
+	ctx context.Context,
+	req *pbProducts.CancelScheduledTaxRateRequest,
+) (*pbProducts.CancelScheduledTaxRateResponse, error) {
+	if err := s.taxPolicy.CancelScheduledRate(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.CancelScheduledTaxRateResponse{}, nil
+}
+
+func (s *Server) TaxRates(
+	ctx context.Context,
+	req *pbProducts.TaxRatesRequest,
+) (*pbProducts.TaxRatesResponse, error) {
+	rates, err := s.taxPolicy.Rates(ctx, req.GetRegionCode())
+	if err != nil {
+		return nil, err
+	}
+
+	ratesProto := make([]*pbProducts.TaxRate, len(rates))
+	for i, r := range rates {
+		ratesProto[i] = r.ToProto()
+	}
+
+	return &pbProducts.TaxRatesResponse{
+		TaxRates: ratesProto,
+	}, nil
+}
+
+// applyTaxes splits the prices of the products and their variants into net, tax and gross
+// for the region asked for. The price the product sells at is taxed, the discounted one
+// when a promotion applies. Products without a rate in the region are left without taxes.
+func (s *Server) applyTaxes(ctx context.Context, req presentRequest, products []*productModel.Product) error {
+	if req.GetTaxRegion() == "" {
+		return nil
+	}
+
+	rates, err := s.taxPolicy.RatesAt(ctx, req.GetTaxRegion(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, p := range withVariants(products) {
+		price := p.Price
+		if p.DiscountedPrice != nil {
+			price = *p.DiscountedPrice
+		}
+
+		rate, breakdown, err := rates.Breakdown(p.EffectiveTaxClassID, price)
+		if err != nil {
+			return err
+		}
+		if rate == nil {
+			continue
+		}
+
+		p.Tax = &productModel.TaxBreakdown{
+			Region:           rates.Region.Code,
+			TaxClassID:       rate.ClassID,
+			BasisPoints:      uint32(rate.BasisPoints),
+			PricesIncludeTax: rates.Region.PricesIncludeTax,
+			Net:              breakdown.Net,
+			Tax:              breakdown.Tax,
+			Gross:            breakdown.Gross,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package product
+
+import (
+	"context"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	productModel "github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/translation/model"
+)
+
+func (s *Server) ProductTranslations(
+	ctx context.Context,
+	req *pbProducts.ProductTranslationsRequest,
+) (*pbProducts.ProductTranslationsResponse, error) {
+	all, err := s.translationPolicy.All(ctx, req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	translationsProto := make([]*pbProducts.ProductTranslation, len(all))
+	for i, t := range all {
+		translationsProto[i] = t.ToProto()
+	}
+
+	return &pbProducts.ProductTranslationsResponse{
+		Translations: translationsProto,
+	}, nil
+}
+
+func (s *Server) SetProductTranslations(
+	ctx context.Context,
+	req *pbProducts.SetProductTranslationsRequest,
+) (*pbProducts.SetProductTranslationsResponse, error) {
+	if err := s.translationPolicy.Set(ctx, model.NewTranslationsFromPB(req.GetTranslations())); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.SetProductTranslationsResponse{}, nil
+}
+
+func (s *Server) DeleteProductTranslation(
+	ctx context.Context,
+	req *pbProducts.DeleteProductTranslationRequest,
+) (*pbProducts.DeleteProductTranslationResponse, error) {
+	if err := s.translationPolicy.Delete(ctx, req.GetProductId(), req.GetLocale()); err != nil {
+		return nil, err
+	}
+
+	return &pbProducts.DeleteProductTranslationResponse{}, nil
+}
+
+func (s *Server) MissingTranslations(
+	ctx context.Context,
+	req *pbProducts.MissingTranslationsRequest,
+) (*pbProducts.MissingTranslationsResponse, error) {
+	missing, err := s.translationPolicy.Missing(ctx, req.GetLocale(), req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	productsProto := make([]*pbProducts.UntranslatedProduct, len(missing))
+	for i, u := range missing {
+		productsProto[i] = u.ToProto()
+	}
+
+	return &pbProducts.MissingTranslationsResponse{
+		Products: productsProto,
+	}, nil
+}
+
+// localize replaces the base content of the products and their variants with the
+// translation found along the fallback chain of the request locale. A variant
+// without a translation of its own that inherited the parent name follows the parent.
+func (s *Server) localize(ctx context.Context, products []*productModel.Product) error {
+	flat := withVariants(products)
+	if len(flat) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(flat))
+	for i, p := range flat {
+		ids[i] = p.ID
+	}
+
+	localized, err := s.translationPolicy.Localized(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, parent := range products {
+		baseName, baseDescription := parent.Name, parent.Description
+		applyTranslation(parent, localized[parent.ID])
+
+		for _, v := range parent.Variants {
+			if t, ok := localized[v.ID]; ok {
+				applyTranslation(v, t)
+				continue
+			}
+			if v.Name == baseName {
+				v.Name = parent.Name
+			}
+			if v.Description == baseDescription {
+				v.Description = parent.Description
+			}
+			v.Locale = parent.Locale
+			v.SpecificationLabels = parent.SpecificationLabels
+		}
+	}
+
+	return nil
+}
+
+func applyTranslation(p *productModel.Product, t *model.Translation) {
+	if t == nil {
+		return
+	}
+
+	p.Name = t.Name
+	if t.Description != "" {
+		p.Description = t.Description
+	}
+	p.Locale = t.Locale
+	p.SpecificationLabels = t.SpecificationLabels
+}
@@ -0,0 +1,51 @@
+package product
+
+import (
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	outboxDao "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/model"
+)
+
+// WatchProducts streams the changes of the products as they are committed. A client
+// reconnecting passes the position of the last event it got and misses none. Created
+// and updated products come as they are now, the ones gone since come without a product,
+// as do the ones moved out of the categories watched.
+func (s *Server) WatchProducts(
+	req *pbProducts.WatchProductsRequest,
+	stream pbProducts.ProductService_WatchProductsServer,
+) error {
+	ctx := stream.Context()
+
+	watched := make(map[uint32]bool, len(req.GetCategoryIds()))
+	for _, id := range req.GetCategoryIds() {
+		watched[id] = true
+	}
+
+	return s.outboxPolicy.Watch(ctx, req.GetAfter(), req.GetCategoryIds(), func(list []*model.Event) error {
+		ids := make([]string, 0, len(list))
+		for _, e := range list {
+			if e.Type != outboxDao.ProductDeleted {
+				ids = append(ids, e.ProductID)
+			}
+		}
+
+		products, err := s.productsByID(ctx, req, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range list {
+			event := e.ToProto()
+			p, ok := products[e.ProductID]
+			if ok && e.Type != outboxDao.ProductDeleted && (len(watched) == 0 || watched[p.CategoryID]) {
+				event.Product = p.ToProto()
+			}
+
+			if err = stream.Send(event); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
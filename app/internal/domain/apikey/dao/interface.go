@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	Begin(context.Context) (pgx.Tx, error)
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	BeginTxFunc(ctx context.Context, txOptions pgx.TxOptions, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type APIKeyDAO interface {
+	All(context.Context) ([]*APIKey, error)
+	OneByHash(context.Context, string) (*APIKey, error)
+	Create(context.Context, map[string]interface{}) error
+	Revoke(context.Context, string) error
+	Touch(context.Context, string) error
+}
@@ -0,0 +1,245 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type apiKeyDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewAPIKeyDAOPostgres(client PostgreSQLClient) APIKeyDAO {
+	return &apiKeyDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme      = "public"
+	table       = "api_key"
+	tableScheme = scheme + "." + table
+
+	// touchInterval is how stale last_used_at gets before a use of the key updates it
+	touchInterval = "1 minute"
+)
+
+func (s *apiKeyDAOPostgres) selectQuery() sq.SelectBuilder {
+	return s.queryBuilder.
+		Select("id").
+		Columns(
+			"tenant_id",
+			"name",
+			"prefix",
+			"hash",
+			"scopes",
+			"created_at",
+			"last_used_at",
+			"revoked_at",
+		).
+		From(tableScheme)
+}
+
+func (s *apiKeyDAOPostgres) All(ctx context.Context) ([]*APIKey, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.selectQuery().
+		Where(sq.Eq{"tenant_id": tenantID}).
+		OrderBy("created_at DESC").
+		ToSql()
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*APIKey, 0)
+
+	for rows.Next() {
+		k := APIKey{}
+		if err = rows.Scan(
+			&k.ID,
+			&k.TenantID,
+			&k.Name,
+			&k.Prefix,
+			&k.Hash,
+			&k.Scopes,
+			&k.CreatedAt,
+			&k.LastUsedAt,
+			&k.RevokedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &k)
+	}
+
+	return list, nil
+}
+
+func (s *apiKeyDAOPostgres) OneByHash(ctx context.Context, hash string) (*APIKey, error) {
+	sql, args, buildErr := s.selectQuery().
+		Where(sq.Eq{"hash": hash}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return nil, buildErr
+	}
+
+	var k APIKey
+
+	err := s.client.QueryRow(ctx, sql, args...).Scan(
+		&k.ID,
+		&k.TenantID,
+		&k.Name,
+		&k.Prefix,
+		&k.Hash,
+		&k.Scopes,
+		&k.CreatedAt,
+		&k.LastUsedAt,
+		&k.RevokedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// an unknown key is the caller's mistake, not ours
+		return nil, jwt.ErrBadAPIKey
+	}
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+func (s *apiKeyDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	sql, args, buildErr := s.queryBuilder.
+		Insert(tableScheme).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 || !exec.Insert() {
+		execErr = db.ErrDoQuery(errors.New("api key was not created. 0 rows were affected"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *apiKeyDAOPostgres) Revoke(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Update(tableScheme).
+		Set("revoked_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id, "tenant_id": tenantID, "revoked_at": nil}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 || !exec.Update() {
+		execErr = db.ErrDoQuery(errors.NotFound("api key not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// Touch records the use of the key. Uses within touchInterval of the recorded one
+// leave the row alone, so a busy key does not write on every call.
+func (s *apiKeyDAOPostgres) Touch(ctx context.Context, id string) error {
+	sql, args, buildErr := s.queryBuilder.
+		Update(tableScheme).
+		Set("last_used_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Or{
+			sq.Eq{"last_used_at": nil},
+			sq.Expr("last_used_at < NOW() - INTERVAL '" + touchInterval + "'"),
+		}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/apikey/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	ScopeProductsRead   = "products:read"
+	ScopeProductsWrite  = "products:write"
+	ScopeInventoryWrite = "inventory:write"
+
+	keyPrefix    = "gm_"
+	keyBytes     = 32
+	prefixLength = 8
+)
+
+var ErrUnknownScope = errors.Validation("unknown api key scope")
+
+type APIKey struct {
+	ID         string     `mapstructure:"id"`
+	TenantID   string     `mapstructure:"tenant_id"`
+	Name       string     `mapstructure:"name"`
+	Prefix     string     `mapstructure:"prefix"`
+	Hash       string     `mapstructure:"hash"`
+	Scopes     []string   `mapstructure:"scopes"`
+	CreatedAt  time.Time  `mapstructure:"created_at"`
+	LastUsedAt *time.Time `mapstructure:"last_used_at"`
+	RevokedAt  *time.Time `mapstructure:"revoked_at"`
+}
+
+// NewAPIKey generates a new key. The plain key is returned only once,
+// the model keeps its hash.
+func NewAPIKey(tenantID, name string, scopes []string) (*APIKey, string, error) {
+	for _, scope := range scopes {
+		if !isKnownScope(scope) {
+			return nil, "", errors.Wrap(ErrUnknownScope, scope)
+		}
+	}
+
+	secret := make([]byte, keyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", errors.Wrap(err, "rand.Read")
+	}
+	plain := keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    plain[:len(keyPrefix)+prefixLength],
+		Hash:      Hash(plain),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+	}, plain, nil
+}
+
+func isKnownScope(scope string) bool {
+	switch scope {
+	case ScopeProductsRead, ScopeProductsWrite, ScopeInventoryWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// Hash returns hex encoded sha256 of the plain key
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) ToMap() (map[string]interface{}, error) {
+	var apiKeyMap map[string]interface{}
+	err := mapstructure.Decode(k, &apiKeyMap)
+	if err != nil {
+		return apiKeyMap, errors.Wrap(err, "mapstructure.Decode(apiKey)")
+	}
+	// mapstructure turns time.Time into an empty map
+	apiKeyMap["created_at"] = k.CreatedAt
+
+	return apiKeyMap, nil
+}
+
+func (k *APIKey) ToProto() *pbProducts.APIKey {
+	var lastUsedAt, revokedAt int64
+	if k.LastUsedAt != nil {
+		lastUsedAt = k.LastUsedAt.UnixMilli()
+	}
+	if k.RevokedAt != nil {
+		revokedAt = k.RevokedAt.UnixMilli()
+	}
+
+	return &pbProducts.APIKey{
+		Id:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		CreatedAt:  k.CreatedAt.UnixMilli(),
+		LastUsedAt: lastUsedAt,
+		RevokedAt:  revokedAt,
+	}
+}
+
+func NewAPIKeyFromDAO(k *dao.APIKey) *APIKey {
+	var lastUsedAt, revokedAt *time.Time
+	if k.LastUsedAt.Valid {
+		lastUsedAt = &k.LastUsedAt.Time
+	}
+	if k.RevokedAt.Valid {
+		revokedAt = &k.RevokedAt.Time
+	}
+
+	return &APIKey{
+		ID:         k.ID,
+		TenantID:   k.TenantID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Hash:       k.Hash,
+		Scopes:     k.Scopes,
+		CreatedAt:  k.CreatedAt.Time,
+		LastUsedAt: lastUsedAt,
+		RevokedAt:  revokedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/apikey/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/apikey/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type APIKeyPolicy struct {
+	apiKeyService *service.APIKeyService
+}
+
+func NewAPIKeyPolicy(apiKeyService *service.APIKeyService) *APIKeyPolicy {
+	return &APIKeyPolicy{apiKeyService: apiKeyService}
+}
+
+func (p *APIKeyPolicy) All(ctx context.Context) ([]*model.APIKey, error) {
+	keys, err := p.apiKeyService.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "apiKeyService.All")
+	}
+
+	return keys, nil
+}
+
+func (p *APIKeyPolicy) Create(ctx context.Context, name string, scopes []string) (*model.APIKey, string, error) {
+	if name == "" {
+		return nil, "", errors.Validation("api key name is empty")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.Validation("api key must have at least one scope")
+	}
+
+	return p.apiKeyService.Create(ctx, name, scopes)
+}
+
+func (p *APIKeyPolicy) Revoke(ctx context.Context, id string) error {
+	return p.apiKeyService.Revoke(ctx, id)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/apikey/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/apikey/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+)
+
+type APIKeyService struct {
+	repository dao.APIKeyDAO
+}
+
+func NewAPIKeyService(repository dao.APIKeyDAO) *APIKeyService {
+	return &APIKeyService{repository: repository}
+}
+
+func (s *APIKeyService) All(ctx context.Context) ([]*model.APIKey, error) {
+	dbKeys, err := s.repository.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.All")
+	}
+
+	keys := make([]*model.APIKey, 0, len(dbKeys))
+	for _, dbK := range dbKeys {
+		keys = append(keys, model.NewAPIKeyFromDAO(dbK))
+	}
+
+	return keys, nil
+}
+
+// Create stores a new key and returns it along with the plain key
+func (s *APIKeyService) Create(ctx context.Context, name string, scopes []string) (*model.APIKey, string, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key, plain, err := model.NewAPIKey(tenantID, name, scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keyStorageMap, err := key.ToMap()
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = s.repository.Create(ctx, keyStorageMap)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, plain, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id string) error {
+	return s.repository.Revoke(ctx, id)
+}
+
+// ValidateAPIKey implements jwt.APIKeyValidator
+func (s *APIKeyService) ValidateAPIKey(ctx context.Context, plain string) (*jwt.APIKeyClaims, error) {
+	one, err := s.repository.OneByHash(ctx, model.Hash(plain))
+	if errors.Is(err, jwt.ErrBadAPIKey) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrap(jwt.ErrBadAPIKey, err.Error())
+	}
+
+	key := model.NewAPIKeyFromDAO(one)
+	if key.IsRevoked() {
+		return nil, jwt.ErrRevokedAPIKey
+	}
+
+	if err = s.repository.Touch(ctx, key.ID); err != nil {
+		logging.WithError(ctx, err).WithField("api_key_id", key.ID).Warning("failed to record api key usage")
+	}
+
+	return &jwt.APIKeyClaims{
+		KeyID:    key.ID,
+		TenantID: key.TenantID,
+		Scopes:   key.Scopes,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type BarcodeDAO interface {
+	Set(ctx context.Context, tx pgx.Tx, productID string, barcodes []*Barcode) error
+	ProductBarcodes(ctx context.Context, productIDs []string) ([]*Barcode, error)
+	ByGTIN(ctx context.Context, gtins []string) ([]*Barcode, error)
+}
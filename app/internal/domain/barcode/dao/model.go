@@ -0,0 +1,13 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Barcode struct {
+	ProductID string
+	Code      string
+	Type      string
+	GTIN      string
+	CreatedAt sql.NullTime
+}
@@ -0,0 +1,178 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type barcodeDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewBarcodeDAOPostgres(client PostgreSQLClient) BarcodeDAO {
+	return &barcodeDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme             = "public"
+	table              = "product_barcode"
+	tableScheme        = scheme + "." + table
+	productTableScheme = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+// Set replaces the barcodes of the product of the tenant in the transaction writing the
+// product. A GTIN is unique within the tenant, a barcode of another product is a conflict.
+func (s *barcodeDAOPostgres) Set(ctx context.Context, tx pgx.Tx, productID string, barcodes []*Barcode) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":      tableScheme,
+		"product_id": productID,
+	})
+
+	sql, args, buildErr := s.queryBuilder.
+		Select("id").
+		From(productTableScheme).
+		Where(sq.Eq{"id": productID, tenantColumn: tenantID}).
+		Suffix("FOR SHARE").
+		ToSql()
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	var id string
+	err = tx.QueryRow(ctx, sql, args...).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.NotFound("product not found")
+	}
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return err
+	}
+
+	sql, args, buildErr = s.queryBuilder.
+		Delete(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID, "product_id": productID}).
+		ToSql()
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return err
+	}
+
+	if len(barcodes) == 0 {
+		return nil
+	}
+
+	insert := s.queryBuilder.
+		Insert(tableScheme).
+		Columns(tenantColumn, "product_id", "code", "type", "gtin", "position")
+	for i, b := range barcodes {
+		insert = insert.Values(tenantID, productID, b.Code, b.Type, b.GTIN, i)
+	}
+
+	sql, args, buildErr = insert.ToSql()
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ProductBarcodes returns the barcodes of the products in their order with a single query
+func (s *barcodeDAOPostgres) ProductBarcodes(ctx context.Context, productIDs []string) ([]*Barcode, error) {
+	return s.all(ctx, sq.Eq{"product_id": productIDs})
+}
+
+// ByGTIN returns the barcodes with the GTINs, whichever product they belong to
+func (s *barcodeDAOPostgres) ByGTIN(ctx context.Context, gtins []string) ([]*Barcode, error) {
+	return s.all(ctx, sq.Eq{"gtin": gtins})
+}
+
+func (s *barcodeDAOPostgres) all(ctx context.Context, where sq.Eq) ([]*Barcode, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("product_id").
+		Columns(
+			"code",
+			"type",
+			"gtin",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID}).
+		Where(where).
+		OrderBy("product_id", "position").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Barcode, 0)
+
+	for rows.Next() {
+		b := Barcode{}
+		if err = rows.Scan(&b.ProductID, &b.Code, &b.Type, &b.GTIN, &b.CreatedAt); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &b)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/barcode/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/barcode"
+)
+
+// Barcode is a GTIN family code a product is scanned by. Codes are compared by their GTIN,
+// so a UPC-A and the EAN-13 with a leading zero are the same barcode.
+type Barcode struct {
+	ProductID string
+	Code      string
+	Type      string
+	GTIN      string
+	CreatedAt time.Time
+}
+
+func NewBarcode(productID string, b barcode.Barcode) *Barcode {
+	return &Barcode{
+		ProductID: productID,
+		Code:      b.Code,
+		Type:      string(b.Type),
+		GTIN:      b.GTIN,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (b *Barcode) ToDAO() *dao.Barcode {
+	return &dao.Barcode{
+		ProductID: b.ProductID,
+		Code:      b.Code,
+		Type:      b.Type,
+		GTIN:      b.GTIN,
+	}
+}
+
+func (b *Barcode) ToProto() *pbProducts.Barcode {
+	return &pbProducts.Barcode{
+		Code: b.Code,
+		Type: b.Type,
+		Gtin: b.GTIN,
+	}
+}
+
+func NewBarcodeFromDAO(b *dao.Barcode) *Barcode {
+	return &Barcode{
+		ProductID: b.ProductID,
+		Code:      b.Code,
+		Type:      b.Type,
+		GTIN:      b.GTIN,
+		CreatedAt: b.CreatedAt.Time,
+	}
+}
+
+// NewProductBarcodesFromDAO groups the barcodes by product
+func NewProductBarcodesFromDAO(barcodes []*dao.Barcode) map[string][]*Barcode {
+	byProduct := make(map[string][]*Barcode)
+	for _, b := range barcodes {
+		byProduct[b.ProductID] = append(byProduct[b.ProductID], NewBarcodeFromDAO(b))
+	}
+	return byProduct
+}
@@ -0,0 +1,34 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/barcode/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/barcode/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/barcode"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type BarcodePolicy struct {
+	barcodeService *service.BarcodeService
+}
+
+func NewBarcodePolicy(barcodeService *service.BarcodeService) *BarcodePolicy {
+	return &BarcodePolicy{barcodeService: barcodeService}
+}
+
+// ProductID returns the id of the product scanned by the code
+func (p *BarcodePolicy) ProductID(ctx context.Context, code string) (string, error) {
+	b, err := barcode.Parse(code)
+	if err != nil {
+		var violations errors.FieldViolations
+		violations.Add("barcode", err.Error())
+		return "", violations.Err()
+	}
+
+	return p.barcodeService.ProductID(ctx, b)
+}
+
+func (p *BarcodePolicy) ProductBarcodes(ctx context.Context, productIDs []string) (map[string][]*model.Barcode, error) {
+	return p.barcodeService.ProductBarcodes(ctx, productIDs)
+}
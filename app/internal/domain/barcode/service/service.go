@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/barcode/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/barcode/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/barcode"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/jackc/pgx/v4"
+)
+
+var errBarcodeNotFound = errors.NotFound("barcode not found")
+
+type BarcodeService struct {
+	repository dao.BarcodeDAO
+}
+
+func NewBarcodeService(repository dao.BarcodeDAO) *BarcodeService {
+	return &BarcodeService{repository: repository}
+}
+
+// Set replaces the barcodes of the product in the transaction writing the product,
+// codes are expected to be validated
+func (s *BarcodeService) Set(ctx context.Context, tx pgx.Tx, productID string, codes []string) error {
+	barcodes := make([]*dao.Barcode, 0, len(codes))
+	for _, code := range codes {
+		b, err := barcode.Parse(code)
+		if err != nil {
+			return errors.WithKind(err, errors.KindValidation, "invalid barcode "+code)
+		}
+		barcodes = append(barcodes, model.NewBarcode(productID, b).ToDAO())
+	}
+
+	return s.repository.Set(ctx, tx, productID, barcodes)
+}
+
+// ProductID returns the id of the product scanned by the code
+func (s *BarcodeService) ProductID(ctx context.Context, b barcode.Barcode) (string, error) {
+	dbBarcodes, err := s.repository.ByGTIN(ctx, []string{b.GTIN})
+	if err != nil {
+		return "", errors.Wrap(err, "repository.ByGTIN")
+	}
+	if len(dbBarcodes) == 0 {
+		return "", errBarcodeNotFound
+	}
+
+	return dbBarcodes[0].ProductID, nil
+}
+
+// ProductBarcodes returns the barcodes of every product which has them
+func (s *BarcodeService) ProductBarcodes(ctx context.Context, productIDs []string) (map[string][]*model.Barcode, error) {
+	if len(productIDs) == 0 {
+		return map[string][]*model.Barcode{}, nil
+	}
+
+	dbBarcodes, err := s.repository.ProductBarcodes(ctx, productIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.ProductBarcodes")
+	}
+
+	return model.NewProductBarcodesFromDAO(dbBarcodes), nil
+}
@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type BrandDAO interface {
+	All(context.Context) ([]*Brand, error)
+	One(context.Context, string) (*Brand, error)
+	BySlug(context.Context, string) (*Brand, error)
+	Exists(context.Context, string) (bool, error)
+	Create(context.Context, map[string]interface{}) error
+	Update(context.Context, string, map[string]interface{}) error
+	Delete(context.Context, string) error
+}
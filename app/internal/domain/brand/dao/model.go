@@ -0,0 +1,16 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Brand struct {
+	ID          string
+	Name        string
+	Slug        string
+	LogoImageID sql.NullString
+	Country     sql.NullString
+	Website     sql.NullString
+	CreatedAt   sql.NullTime
+	UpdatedAt   sql.NullTime
+}
@@ -0,0 +1,346 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type brandDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewBrandDAOPostgres(client PostgreSQLClient) BrandDAO {
+	return &brandDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme             = "public"
+	table              = "brand"
+	tableScheme        = scheme + "." + table
+	productTableScheme = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+var (
+	errBrandNotFound = errors.NotFound("brand not found")
+	// ErrBrandInUse is returned on deletion of a brand products still refer to
+	ErrBrandInUse = errors.Conflict("brand is used by products")
+)
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (s *brandDAOPostgres) selectQuery(tenantID string) sq.SelectBuilder {
+	return s.queryBuilder.
+		Select("id").
+		Columns(
+			"name",
+			"slug",
+			"logo_image_id",
+			"country",
+			"website",
+			"created_at",
+			"updated_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID})
+}
+
+func scanBrand(row scanner, b *Brand) error {
+	return row.Scan(
+		&b.ID,
+		&b.Name,
+		&b.Slug,
+		&b.LogoImageID,
+		&b.Country,
+		&b.Website,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+}
+
+func (s *brandDAOPostgres) All(ctx context.Context) ([]*Brand, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.selectQuery(tenantID).
+		OrderBy("name").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Brand, 0)
+
+	for rows.Next() {
+		b := Brand{}
+		if err = scanBrand(rows, &b); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &b)
+	}
+
+	return list, nil
+}
+
+func (s *brandDAOPostgres) One(ctx context.Context, id string) (*Brand, error) {
+	return s.one(ctx, sq.Eq{"id": id})
+}
+
+// BySlug finds the brand by its normalized name
+func (s *brandDAOPostgres) BySlug(ctx context.Context, slug string) (*Brand, error) {
+	return s.one(ctx, sq.Eq{"slug": slug})
+}
+
+func (s *brandDAOPostgres) one(ctx context.Context, where sq.Eq) (*Brand, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.selectQuery(tenantID).
+		Where(where).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	b := Brand{}
+	err = scanBrand(s.client.QueryRow(ctx, sql, args...), &b)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errBrandNotFound
+	}
+	if err != nil {
+		err = db.ErrScan(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (s *brandDAOPostgres) Exists(ctx context.Context, id string) (bool, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From(tableScheme).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		Suffix(")").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return false, buildErr
+	}
+
+	var exists bool
+	if err := s.client.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// Create stores a brand, a brand with the same slug is a conflict
+func (s *brandDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(tableScheme).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *brandDAOPostgres) Update(ctx context.Context, id string, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Update(tableScheme).
+		SetMap(m).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errBrandNotFound)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// Delete removes a brand no product refers to
+func (s *brandDAOPostgres) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table": tableScheme,
+		"id":    id,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Select("id").
+			From(tableScheme).
+			Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+			Suffix("FOR UPDATE").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var brandID string
+		err := tx.QueryRow(ctx, sql, args...).Scan(&brandID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errBrandNotFound
+		}
+		if err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Select("1").
+			Prefix("SELECT EXISTS (").
+			From(productTableScheme).
+			Where(sq.Eq{"brand_id": id, tenantColumn: tenantID}).
+			Suffix(")").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var used bool
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&used); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+		if used {
+			return ErrBrandInUse
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Delete(tableScheme).
+			Where(sq.Eq{"id": id}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/brand/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/slug"
+)
+
+// Brand is the maker products are sold under. Brands are told apart by the slug
+// of their name, so spellings differing in case or punctuation are one brand.
+type Brand struct {
+	ID          string
+	Name        string
+	Slug        string
+	LogoImageID *string
+	// Country is an ISO 3166-1 alpha-2 code
+	Country   *string
+	Website   *string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewBrand(name string) *Brand {
+	return &Brand{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Slug:      slug.Make(name),
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewBrandFromPB(req *pbProducts.CreateBrandRequest) *Brand {
+	b := NewBrand(req.GetName())
+	b.LogoImageID = nonEmpty(req.LogoImageId)
+	b.Country = nonEmpty(req.Country)
+	b.Website = nonEmpty(req.Website)
+	return b
+}
+
+// UpdateFromPB applies the fields set in the request, an empty optional field clears it
+func (b *Brand) UpdateFromPB(req *pbProducts.UpdateBrandRequest) {
+	if req.Name != nil {
+		b.Name = req.GetName()
+		b.Slug = slug.Make(req.GetName())
+	}
+	if req.LogoImageId != nil {
+		b.LogoImageID = nonEmpty(req.LogoImageId)
+	}
+	if req.Country != nil {
+		b.Country = nonEmpty(req.Country)
+	}
+	if req.Website != nil {
+		b.Website = nonEmpty(req.Website)
+	}
+}
+
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+func (b *Brand) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            b.ID,
+		"name":          b.Name,
+		"slug":          b.Slug,
+		"logo_image_id": b.LogoImageID,
+		"country":       b.Country,
+		"website":       b.Website,
+		"created_at":    b.CreatedAt,
+	}
+}
+
+func (b *Brand) ToProto() *pbProducts.Brand {
+	var updatedAt int64
+	if b.UpdatedAt != nil {
+		updatedAt = b.UpdatedAt.UnixMilli()
+	}
+
+	var country string
+	if b.Country != nil {
+		country = *b.Country
+	}
+
+	return &pbProducts.Brand{
+		Id:          b.ID,
+		Name:        b.Name,
+		Slug:        b.Slug,
+		LogoImageId: b.LogoImageID,
+		Country:     country,
+		Website:     b.Website,
+		CreatedAt:   b.CreatedAt.UnixMilli(),
+		UpdatedAt:   updatedAt,
+	}
+}
+
+func NewBrandFromDAO(b *dao.Brand) *Brand {
+	var logoImageID, country, website *string
+	if b.LogoImageID.Valid {
+		logoImageID = &b.LogoImageID.String
+	}
+	if b.Country.Valid {
+		country = &b.Country.String
+	}
+	if b.Website.Valid {
+		website = &b.Website.String
+	}
+
+	var updatedAt *time.Time
+	if b.UpdatedAt.Valid {
+		updatedAt = &b.UpdatedAt.Time
+	}
+
+	return &Brand{
+		ID:          b.ID,
+		Name:        b.Name,
+		Slug:        b.Slug,
+		LogoImageID: logoImageID,
+		Country:     country,
+		Website:     website,
+		CreatedAt:   b.CreatedAt.Time,
+		UpdatedAt:   updatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/brand/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/brand/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+const NameMaxLength = 128
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+type BrandPolicy struct {
+	brandService *service.BrandService
+}
+
+func NewBrandPolicy(brandService *service.BrandService) *BrandPolicy {
+	return &BrandPolicy{brandService: brandService}
+}
+
+func (p *BrandPolicy) All(ctx context.Context) ([]*model.Brand, error) {
+	brands, err := p.brandService.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "brandService.All")
+	}
+
+	return brands, nil
+}
+
+func (p *BrandPolicy) One(ctx context.Context, id string) (*model.Brand, error) {
+	return p.brandService.One(ctx, id)
+}
+
+func (p *BrandPolicy) Create(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	if err := validate(brand); err != nil {
+		return nil, err
+	}
+
+	return p.brandService.Create(ctx, brand)
+}
+
+func (p *BrandPolicy) Update(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	if err := validate(brand); err != nil {
+		return nil, err
+	}
+
+	return p.brandService.Update(ctx, brand)
+}
+
+func (p *BrandPolicy) Delete(ctx context.Context, id string) error {
+	return p.brandService.Delete(ctx, id)
+}
+
+func validate(brand *model.Brand) error {
+	var violations errors.FieldViolations
+	if utf8.RuneCountInString(brand.Name) > NameMaxLength {
+		violations.Add("name", fmt.Sprintf("must be at most %d characters long", NameMaxLength))
+	}
+	if brand.Slug == "" {
+		violations.Add("name", "must contain letters or digits")
+	}
+	if brand.Country != nil && !countryCode.MatchString(*brand.Country) {
+		violations.Add("country", "must be an ISO 3166-1 alpha-2 code, e.g. DE")
+	}
+	if brand.Website != nil {
+		u, err := url.Parse(*brand.Website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			violations.Add("website", "must be an http or https URL")
+		}
+	}
+	return violations.Err()
+}
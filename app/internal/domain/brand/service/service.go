@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/brand/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/brand/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/slug"
+)
+
+type BrandService struct {
+	repository dao.BrandDAO
+}
+
+func NewBrandService(repository dao.BrandDAO) *BrandService {
+	return &BrandService{repository: repository}
+}
+
+func (s *BrandService) All(ctx context.Context) ([]*model.Brand, error) {
+	dbBrands, err := s.repository.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.All")
+	}
+
+	brands := make([]*model.Brand, 0, len(dbBrands))
+	for _, dbB := range dbBrands {
+		brands = append(brands, model.NewBrandFromDAO(dbB))
+	}
+
+	return brands, nil
+}
+
+func (s *BrandService) One(ctx context.Context, id string) (*model.Brand, error) {
+	dbB, err := s.repository.One(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewBrandFromDAO(dbB), nil
+}
+
+func (s *BrandService) Create(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	if err := s.repository.Create(ctx, brand.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+func (s *BrandService) Update(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	m := brand.ToMap()
+	delete(m, "id")
+	delete(m, "created_at")
+
+	if err := s.repository.Update(ctx, brand.ID, m); err != nil {
+		return nil, err
+	}
+
+	return s.One(ctx, brand.ID)
+}
+
+func (s *BrandService) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *BrandService) Exists(ctx context.Context, id string) (bool, error) {
+	return s.repository.Exists(ctx, id)
+}
+
+// FindOrCreate returns the brand spelled like the name, creating it when there is none
+func (s *BrandService) FindOrCreate(ctx context.Context, name string) (*model.Brand, error) {
+	dbB, err := s.repository.BySlug(ctx, slug.Make(name))
+	if err == nil {
+		return model.NewBrandFromDAO(dbB), nil
+	}
+	if errors.KindOf(err) != errors.KindNotFound {
+		return nil, errors.Wrap(err, "repository.BySlug")
+	}
+
+	return s.Create(ctx, model.NewBrand(name))
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type AttributeType string
+
+const (
+	AttributeTypeInt     AttributeType = "int"
+	AttributeTypeDecimal AttributeType = "decimal"
+	AttributeTypeEnum    AttributeType = "enum"
+	AttributeTypeBool    AttributeType = "bool"
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeList    AttributeType = "list"
+)
+
+// Attribute describes a single specification attribute of a category
+type Attribute struct {
+	Name          string
+	Type          AttributeType
+	Unit          string
+	Required      bool
+	AllowedValues []string
+}
+
+// Schema lists the specification attributes products of a category may have
+type Schema struct {
+	CategoryID uint32
+	Attributes []*Attribute
+}
+
+// IsEmpty reports that the category has no schema and any specification is accepted
+func (s *Schema) IsEmpty() bool {
+	return len(s.Attributes) == 0
+}
+
+// Validate checks spec against the schema and adds a violation per bad attribute
+func (s *Schema) Validate(spec map[string]interface{}, violations *errors.FieldViolations) {
+	if s.IsEmpty() {
+		return
+	}
+
+	known := make(map[string]*Attribute, len(s.Attributes))
+	for _, attr := range s.Attributes {
+		known[attr.Name] = attr
+
+		value, ok := spec[attr.Name]
+		if !ok {
+			if attr.Required {
+				violations.Add("specification."+attr.Name, "is required")
+			}
+			continue
+		}
+
+		if msg := attr.check(value); msg != "" {
+			violations.Add("specification."+attr.Name, msg)
+		}
+	}
+
+	unknown := make([]string, 0)
+	for key := range spec {
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		violations.Add("specification."+key, fmt.Sprintf("is not defined for category %d", s.CategoryID))
+	}
+}
+
+// check returns a violation description or an empty string for a valid value
+func (a *Attribute) check(value interface{}) string {
+	switch a.Type {
+	case AttributeTypeInt:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return "must be an integer"
+		}
+	case AttributeTypeDecimal:
+		if _, ok := value.(float64); !ok {
+			return "must be a number"
+		}
+	case AttributeTypeBool:
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean"
+		}
+	case AttributeTypeEnum, AttributeTypeString:
+		str, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if !a.allows(str) {
+			return fmt.Sprintf("must be one of %v", a.AllowedValues)
+		}
+	case AttributeTypeList:
+		items, ok := value.([]interface{})
+		if !ok {
+			return "must be a list"
+		}
+		for _, item := range items {
+			str, ok := item.(string)
+			if !ok || !a.allows(str) {
+				return fmt.Sprintf("items must be one of %v", a.AllowedValues)
+			}
+		}
+	default:
+		return fmt.Sprintf("has unsupported type %q", a.Type)
+	}
+
+	return ""
+}
+
+func (a *Attribute) allows(value string) bool {
+	if len(a.AllowedValues) == 0 {
+		return a.Type != AttributeTypeEnum
+	}
+	for _, allowed := range a.AllowedValues {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) ToProto() []*pbProducts.CategoryAttribute {
+	attributes := make([]*pbProducts.CategoryAttribute, len(s.Attributes))
+	for i, attr := range s.Attributes {
+		attributes[i] = &pbProducts.CategoryAttribute{
+			Name:          attr.Name,
+			Type:          string(attr.Type),
+			Unit:          attr.Unit,
+			Required:      attr.Required,
+			AllowedValues: attr.AllowedValues,
+		}
+	}
+	return attributes
+}
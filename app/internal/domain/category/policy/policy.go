@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/category/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/category/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/shipping"
+)
+
+type CategoryPolicy struct {
+	categoryService *service.CategoryService
+}
+
+func NewCategoryPolicy(categoryService *service.CategoryService) *CategoryPolicy {
+	return &CategoryPolicy{categoryService: categoryService}
+}
+
+func (p *CategoryPolicy) Schema(ctx context.Context, categoryID uint32) (*model.Schema, error) {
+	schema, err := p.categoryService.Schema(ctx, categoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "categoryService.Schema")
+	}
+
+	return schema, nil
+}
+
+// SetShippingClass sets the class inherited by products of the category, an empty class removes it
+func (p *CategoryPolicy) SetShippingClass(ctx context.Context, categoryID uint32, class string) error {
+	if class == "" {
+		return p.categoryService.SetShippingClass(ctx, categoryID, nil)
+	}
+
+	if !shipping.IsClass(class) {
+		var violations errors.FieldViolations
+		violations.Add("shipping_class", "must be one of standard, bulky, fragile")
+		return violations.Err()
+	}
+
+	return p.categoryService.SetShippingClass(ctx, categoryID, &class)
+}
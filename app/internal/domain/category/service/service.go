@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/category/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/category/storage"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type CategoryService struct {
+	storage storage.CategoryStorage
+}
+
+func NewCategoryService(storage storage.CategoryStorage) *CategoryService {
+	return &CategoryService{storage: storage}
+}
+
+func (s *CategoryService) Schema(ctx context.Context, categoryID uint32) (*model.Schema, error) {
+	exists, err := s.storage.Exists(ctx, categoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "storage.Exists")
+	}
+	if !exists {
+		return nil, errors.NotFound("category not found")
+	}
+
+	return s.storage.Schema(ctx, categoryID)
+}
+
+func (s *CategoryService) SetShippingClass(ctx context.Context, categoryID uint32, class *string) error {
+	return s.storage.SetShippingClass(ctx, categoryID, class)
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/category/model"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type CategoryStorage interface {
+	Exists(context.Context, uint32) (bool, error)
+	Schema(context.Context, uint32) (*model.Schema, error)
+	SetShippingClass(ctx context.Context, id uint32, class *string) error
+}
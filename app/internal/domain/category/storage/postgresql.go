@@ -1 +1,157 @@
 package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/category/model"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+)
+
+type categoryStoragePostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewCategoryStoragePostgres(client PostgreSQLClient) CategoryStorage {
+	return &categoryStoragePostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme      = "public"
+	table       = "category"
+	tableScheme = scheme + "." + table
+
+	attributeTableScheme = scheme + ".category_attribute"
+)
+
+func (s *categoryStoragePostgres) Exists(ctx context.Context, id uint32) (bool, error) {
+	sql, args, buildErr := s.queryBuilder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From(tableScheme).
+		Where(sq.Eq{"id": id}).
+		Suffix(")").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return false, buildErr
+	}
+
+	var exists bool
+	if err := s.client.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (s *categoryStoragePostgres) Schema(ctx context.Context, categoryID uint32) (*model.Schema, error) {
+	sql, args, buildErr := s.queryBuilder.
+		Select("name").
+		Columns(
+			"type",
+			"unit",
+			"required",
+			"allowed_values",
+		).
+		From(attributeTableScheme).
+		Where(sq.Eq{"category_id": categoryID}).
+		OrderBy("position", "name").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": attributeTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return nil, buildErr
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	schema := &model.Schema{
+		CategoryID: categoryID,
+		Attributes: make([]*model.Attribute, 0),
+	}
+
+	for rows.Next() {
+		var (
+			attr     model.Attribute
+			attrType string
+		)
+		if err = rows.Scan(
+			&attr.Name,
+			&attrType,
+			&attr.Unit,
+			&attr.Required,
+			&attr.AllowedValues,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+		attr.Type = model.AttributeType(attrType)
+
+		schema.Attributes = append(schema.Attributes, &attr)
+	}
+
+	return schema, nil
+}
+
+// SetShippingClass sets the class products of the category ship in unless they have their own,
+// no class falls back to the standard one
+func (s *categoryStoragePostgres) SetShippingClass(ctx context.Context, id uint32, class *string) error {
+	sql, args, buildErr := s.queryBuilder.
+		Update(tableScheme).
+		Set("shipping_class", class).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("category not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
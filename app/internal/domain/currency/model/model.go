@@ -1,7 +1,18 @@
 package model
 
-type Product struct {
-	ID     string `json:"id"`
+import (
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+)
+
+type Currency struct {
+	ID     uint32 `json:"id"`
 	Name   string `json:"name"`
 	Symbol string `json:"symbol"`
+	// Code is the ISO 4217 code, Exponent the number of decimal digits of the minor unit
+	Code     string `json:"code"`
+	Exponent uint8  `json:"exponent"`
+}
+
+func (c *Currency) Money() money.Currency {
+	return money.Currency{Code: c.Code, Exponent: c.Exponent}
 }
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/currency/model"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type CurrencyStorage interface {
+	Exists(context.Context, uint32) (bool, error)
+	One(context.Context, uint32) (*model.Currency, error)
+}
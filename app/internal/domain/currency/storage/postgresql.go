@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/currency/model"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/jackc/pgx/v4"
+)
+
+type currencyStoragePostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewCurrencyStoragePostgres(client PostgreSQLClient) CurrencyStorage {
+	return &currencyStoragePostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme      = "public"
+	table       = "currency"
+	tableScheme = scheme + "." + table
+)
+
+func (s *currencyStoragePostgres) Exists(ctx context.Context, id uint32) (bool, error) {
+	sql, args, buildErr := s.queryBuilder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From(tableScheme).
+		Where(sq.Eq{"id": id}).
+		Suffix(")").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return false, buildErr
+	}
+
+	var exists bool
+	if err := s.client.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (s *currencyStoragePostgres) One(ctx context.Context, id uint32) (*model.Currency, error) {
+	sql, args, buildErr := s.queryBuilder.
+		Select("id").
+		Columns(
+			"name",
+			"symbol",
+			"code",
+			"exponent",
+		).
+		From(tableScheme).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return nil, buildErr
+	}
+
+	c := model.Currency{}
+	err := s.client.QueryRow(ctx, sql, args...).Scan(&c.ID, &c.Name, &c.Symbol, &c.Code, &c.Exponent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.NotFound("currency not found")
+	}
+	if err != nil {
+		err = db.ErrScan(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &c, nil
+}
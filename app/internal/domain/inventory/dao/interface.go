@@ -0,0 +1,28 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	Begin(context.Context) (pgx.Tx, error)
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	BeginTxFunc(ctx context.Context, txOptions pgx.TxOptions, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type InventoryDAO interface {
+	AllWarehouses(context.Context) ([]*Warehouse, error)
+	CreateWarehouse(context.Context, map[string]interface{}) error
+	Stock(context.Context, string) ([]*Stock, error)
+	SetStock(context.Context, string, string, uint64) error
+	Reserve(context.Context, map[string]interface{}) error
+	Release(context.Context, string) error
+	Commit(context.Context, string) error
+	ExpireReservations(context.Context) (int64, error)
+}
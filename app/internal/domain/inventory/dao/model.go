@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Warehouse struct {
+	ID        string
+	Name      string
+	Code      string
+	CreatedAt sql.NullTime
+}
+
+type Stock struct {
+	ProductID   string
+	WarehouseID string
+	OnHand      uint64
+	Reserved    uint64
+}
+
+type Reservation struct {
+	ID          string
+	ProductID   string
+	WarehouseID string
+	Quantity    uint64
+	Status      string
+	ExpiresAt   sql.NullTime
+	CreatedAt   sql.NullTime
+}
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusReleased  = "released"
+	ReservationStatusCommitted = "committed"
+	ReservationStatusExpired   = "expired"
+)
@@ -0,0 +1,397 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type inventoryDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewInventoryDAOPostgres(client PostgreSQLClient) InventoryDAO {
+	return &inventoryDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme               = "public"
+	warehouseTableScheme = scheme + ".warehouse"
+	stockTableScheme     = scheme + ".inventory_stock"
+	reservationScheme    = scheme + ".inventory_reservation"
+	productTableScheme   = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+var ErrInsufficientStock = errors.Conflict("insufficient stock")
+
+// tenantWarehouses is a sub-query of warehouse ids available to the tenant in context
+func (s *inventoryDAOPostgres) tenantWarehouses(ctx context.Context) (sq.Sqlizer, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// nested queries keep the question placeholders, the outer query numbers them
+	warehouses := sq.Select("id").
+		From(warehouseTableScheme).
+		Where(sq.Eq{tenantColumn: tenantID})
+
+	return sq.Expr("warehouse_id IN (?)", warehouses), nil
+}
+
+func (s *inventoryDAOPostgres) AllWarehouses(ctx context.Context) ([]*Warehouse, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("id").
+		Columns(
+			"name",
+			"code",
+			"created_at",
+		).
+		From(warehouseTableScheme).
+		Where(sq.Eq{tenantColumn: tenantID}).
+		OrderBy("code").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": warehouseTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Warehouse, 0)
+
+	for rows.Next() {
+		w := Warehouse{}
+		if err = rows.Scan(&w.ID, &w.Name, &w.Code, &w.CreatedAt); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &w)
+	}
+
+	return list, nil
+}
+
+func (s *inventoryDAOPostgres) CreateWarehouse(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(warehouseTableScheme).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": warehouseTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *inventoryDAOPostgres) Stock(ctx context.Context, productID string) ([]*Stock, error) {
+	scope, err := s.tenantWarehouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("product_id").
+		Columns(
+			"warehouse_id",
+			"on_hand",
+			"reserved",
+		).
+		From(stockTableScheme).
+		Where(sq.Eq{"product_id": productID}).
+		Where(scope).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": stockTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Stock, 0)
+
+	for rows.Next() {
+		st := Stock{}
+		if err = rows.Scan(&st.ProductID, &st.WarehouseID, &st.OnHand, &st.Reserved); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &st)
+	}
+
+	return list, nil
+}
+
+// SetStock upserts the on-hand quantity. It never drops below the reserved quantity.
+func (s *inventoryDAOPostgres) SetStock(ctx context.Context, productID, warehouseID string, onHand uint64) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(stockTableScheme).
+		Columns("product_id", "warehouse_id", "on_hand", "reserved").
+		Select(s.queryBuilder.
+			Select().
+			Column("?", productID).
+			Column("id").
+			Column("?", onHand).
+			Column("0").
+			From(warehouseTableScheme).
+			Where(sq.Eq{"id": warehouseID, tenantColumn: tenantID}).
+			Where(sq.Expr("EXISTS (SELECT 1 FROM "+productTableScheme+" WHERE id = ? AND tenant_id = ?)", productID, tenantID))).
+		Suffix("ON CONFLICT (product_id, warehouse_id) DO UPDATE SET on_hand = EXCLUDED.on_hand " +
+			"WHERE inventory_stock.reserved <= EXCLUDED.on_hand").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": stockTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.Conflict("warehouse not found or on-hand quantity is below reserved"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// Reserve holds quantity of the product in the warehouse. The conditional update locks the
+// stock row, so concurrent reservations are serialized and can not oversell.
+func (s *inventoryDAOPostgres) Reserve(ctx context.Context, m map[string]interface{}) error {
+	scope, err := s.tenantWarehouses(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, _ := tenant.FromContext(ctx)
+	m[tenantColumn] = tenantID
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":       reservationScheme,
+		"reservation": m,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		quantity := m["quantity"]
+		sql, args, buildErr := s.queryBuilder.
+			Update(stockTableScheme).
+			Set("reserved", sq.Expr("reserved + ?", quantity)).
+			Where(sq.Eq{"product_id": m["product_id"], "warehouse_id": m["warehouse_id"]}).
+			Where(sq.Expr("on_hand - reserved >= ?", quantity)).
+			Where(scope).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		exec, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+		if exec.RowsAffected() == 0 {
+			return ErrInsufficientStock
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Insert(reservationScheme).
+			SetMap(m).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *inventoryDAOPostgres) Release(ctx context.Context, id string) error {
+	return s.finishReservation(ctx, id, ReservationStatusReleased, false)
+}
+
+func (s *inventoryDAOPostgres) Commit(ctx context.Context, id string) error {
+	return s.finishReservation(ctx, id, ReservationStatusCommitted, true)
+}
+
+// finishReservation moves an active reservation to status and returns its quantity
+// to the stock. Committed quantity leaves the warehouse.
+func (s *inventoryDAOPostgres) finishReservation(ctx context.Context, id, status string, ship bool) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":          reservationScheme,
+		"reservation_id": id,
+		"status":         status,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Update(reservationScheme).
+			Set("status", status).
+			Where(sq.Eq{"id": id, tenantColumn: tenantID, "status": ReservationStatusActive}).
+			Where(sq.Expr("expires_at > NOW()")).
+			Suffix("RETURNING product_id, warehouse_id, quantity").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var r Reservation
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&r.ProductID, &r.WarehouseID, &r.Quantity); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.NotFound("active reservation not found")
+			}
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		update := s.queryBuilder.
+			Update(stockTableScheme).
+			Set("reserved", sq.Expr("reserved - ?", r.Quantity)).
+			Where(sq.Eq{"product_id": r.ProductID, "warehouse_id": r.WarehouseID})
+		if ship {
+			update = update.Set("on_hand", sq.Expr("on_hand - ?", r.Quantity))
+		}
+
+		sql, args, buildErr = update.ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// ExpireReservations releases all overdue reservations of every tenant in one statement
+func (s *inventoryDAOPostgres) ExpireReservations(ctx context.Context) (int64, error) {
+	const sql = `
+WITH expired AS (
+	UPDATE ` + reservationScheme + `
+	SET status = $1
+	WHERE status = $2 AND expires_at <= NOW()
+	RETURNING product_id, warehouse_id, quantity
+), totals AS (
+	SELECT product_id, warehouse_id, SUM(quantity) AS quantity
+	FROM expired
+	GROUP BY product_id, warehouse_id
+)
+UPDATE ` + stockTableScheme + ` AS s
+SET reserved = s.reserved - totals.quantity
+FROM totals
+WHERE s.product_id = totals.product_id AND s.warehouse_id = totals.warehouse_id`
+
+	exec, err := s.client.Exec(ctx, sql, ReservationStatusExpired, ReservationStatusActive)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logging.WithError(ctx, err).WithField("table", reservationScheme).Error("failed to expire reservations")
+		return 0, err
+	}
+
+	return exec.RowsAffected(), nil
+}
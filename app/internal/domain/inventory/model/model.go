@@ -0,0 +1,132 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/inventory/dao"
+)
+
+type Warehouse struct {
+	ID        string
+	Name      string
+	Code      string
+	CreatedAt time.Time
+}
+
+type Stock struct {
+	ProductID   string
+	WarehouseID string
+	OnHand      uint64
+	Reserved    uint64
+}
+
+type Reservation struct {
+	ID          string
+	ProductID   string
+	WarehouseID string
+	Quantity    uint64
+	Status      string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+func NewWarehouse(name, code string) *Warehouse {
+	return &Warehouse{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Code:      code,
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewReservation(productID, warehouseID string, quantity uint64, ttl time.Duration) *Reservation {
+	now := time.Now()
+	return &Reservation{
+		ID:          uuid.New().String(),
+		ProductID:   productID,
+		WarehouseID: warehouseID,
+		Quantity:    quantity,
+		Status:      dao.ReservationStatusActive,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+func (s *Stock) Available() uint64 {
+	if s.Reserved > s.OnHand {
+		return 0
+	}
+	return s.OnHand - s.Reserved
+}
+
+func (w *Warehouse) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         w.ID,
+		"name":       w.Name,
+		"code":       w.Code,
+		"created_at": w.CreatedAt,
+	}
+}
+
+func (r *Reservation) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           r.ID,
+		"product_id":   r.ProductID,
+		"warehouse_id": r.WarehouseID,
+		"quantity":     r.Quantity,
+		"status":       r.Status,
+		"expires_at":   r.ExpiresAt,
+		"created_at":   r.CreatedAt,
+	}
+}
+
+func (w *Warehouse) ToProto() *pbProducts.Warehouse {
+	return &pbProducts.Warehouse{
+		Id:        w.ID,
+		Name:      w.Name,
+		Code:      w.Code,
+		CreatedAt: w.CreatedAt.UnixMilli(),
+	}
+}
+
+func (s *Stock) ToProto() *pbProducts.StockLevel {
+	return &pbProducts.StockLevel{
+		ProductId:   s.ProductID,
+		WarehouseId: s.WarehouseID,
+		OnHand:      s.OnHand,
+		Reserved:    s.Reserved,
+		Available:   s.Available(),
+	}
+}
+
+func (r *Reservation) ToProto() *pbProducts.Reservation {
+	return &pbProducts.Reservation{
+		Id:          r.ID,
+		ProductId:   r.ProductID,
+		WarehouseId: r.WarehouseID,
+		Quantity:    r.Quantity,
+		Status:      r.Status,
+		ExpiresAt:   r.ExpiresAt.UnixMilli(),
+		CreatedAt:   r.CreatedAt.UnixMilli(),
+	}
+}
+
+func NewWarehouseFromDAO(w *dao.Warehouse) *Warehouse {
+	return &Warehouse{
+		ID:        w.ID,
+		Name:      w.Name,
+		Code:      w.Code,
+		CreatedAt: w.CreatedAt.Time,
+	}
+}
+
+func NewStockFromDAO(s *dao.Stock) *Stock {
+	return &Stock{
+		ProductID:   s.ProductID,
+		WarehouseID: s.WarehouseID,
+		OnHand:      s.OnHand,
+		Reserved:    s.Reserved,
+	}
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/inventory/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/inventory/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type InventoryPolicy struct {
+	inventoryService *service.InventoryService
+	defaultTTL       time.Duration
+	maxTTL           time.Duration
+}
+
+func NewInventoryPolicy(inventoryService *service.InventoryService, defaultTTL, maxTTL time.Duration) *InventoryPolicy {
+	return &InventoryPolicy{
+		inventoryService: inventoryService,
+		defaultTTL:       defaultTTL,
+		maxTTL:           maxTTL,
+	}
+}
+
+func (p *InventoryPolicy) AllWarehouses(ctx context.Context) ([]*model.Warehouse, error) {
+	warehouses, err := p.inventoryService.AllWarehouses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryService.AllWarehouses")
+	}
+
+	return warehouses, nil
+}
+
+func (p *InventoryPolicy) CreateWarehouse(ctx context.Context, warehouse *model.Warehouse) (*model.Warehouse, error) {
+	var violations errors.FieldViolations
+	if warehouse.Name == "" {
+		violations.Add("name", "must not be empty")
+	}
+	if warehouse.Code == "" {
+		violations.Add("code", "must not be empty")
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.inventoryService.CreateWarehouse(ctx, warehouse)
+}
+
+func (p *InventoryPolicy) Stock(ctx context.Context, productID string) ([]*model.Stock, error) {
+	return p.inventoryService.Stock(ctx, productID)
+}
+
+func (p *InventoryPolicy) SetStock(ctx context.Context, productID, warehouseID string, onHand uint64) error {
+	return p.inventoryService.SetStock(ctx, productID, warehouseID, onHand)
+}
+
+// Reserve holds quantity for ttl. A zero ttl falls back to the default one.
+func (p *InventoryPolicy) Reserve(
+	ctx context.Context,
+	productID, warehouseID string,
+	quantity uint64,
+	ttl time.Duration,
+) (*model.Reservation, error) {
+	var violations errors.FieldViolations
+	if quantity == 0 {
+		violations.Add("quantity", "must be greater than 0")
+	}
+	if ttl > p.maxTTL {
+		violations.Add("ttl_seconds", "must not exceed "+p.maxTTL.String())
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if ttl == 0 {
+		ttl = p.defaultTTL
+	}
+
+	return p.inventoryService.Reserve(ctx, model.NewReservation(productID, warehouseID, quantity, ttl))
+}
+
+func (p *InventoryPolicy) Release(ctx context.Context, id string) error {
+	return p.inventoryService.Release(ctx, id)
+}
+
+func (p *InventoryPolicy) Commit(ctx context.Context, id string) error {
+	return p.inventoryService.Commit(ctx, id)
+}
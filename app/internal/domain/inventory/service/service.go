@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/inventory/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/inventory/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+)
+
+type InventoryService struct {
+	repository dao.InventoryDAO
+}
+
+func NewInventoryService(repository dao.InventoryDAO) *InventoryService {
+	return &InventoryService{repository: repository}
+}
+
+func (s *InventoryService) AllWarehouses(ctx context.Context) ([]*model.Warehouse, error) {
+	dbWarehouses, err := s.repository.AllWarehouses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.AllWarehouses")
+	}
+
+	warehouses := make([]*model.Warehouse, 0, len(dbWarehouses))
+	for _, dbW := range dbWarehouses {
+		warehouses = append(warehouses, model.NewWarehouseFromDAO(dbW))
+	}
+
+	return warehouses, nil
+}
+
+func (s *InventoryService) CreateWarehouse(ctx context.Context, warehouse *model.Warehouse) (*model.Warehouse, error) {
+	if err := s.repository.CreateWarehouse(ctx, warehouse.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (s *InventoryService) Stock(ctx context.Context, productID string) ([]*model.Stock, error) {
+	dbStock, err := s.repository.Stock(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Stock")
+	}
+
+	stock := make([]*model.Stock, 0, len(dbStock))
+	for _, dbS := range dbStock {
+		stock = append(stock, model.NewStockFromDAO(dbS))
+	}
+
+	return stock, nil
+}
+
+func (s *InventoryService) SetStock(ctx context.Context, productID, warehouseID string, onHand uint64) error {
+	return s.repository.SetStock(ctx, productID, warehouseID, onHand)
+}
+
+func (s *InventoryService) Reserve(ctx context.Context, reservation *model.Reservation) (*model.Reservation, error) {
+	if err := s.repository.Reserve(ctx, reservation.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (s *InventoryService) Release(ctx context.Context, id string) error {
+	return s.repository.Release(ctx, id)
+}
+
+func (s *InventoryService) Commit(ctx context.Context, id string) error {
+	return s.repository.Commit(ctx, id)
+}
+
+// RunExpiry releases overdue reservations every interval until ctx is done
+func (s *InventoryService) RunExpiry(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			expired, err := s.repository.ExpireReservations(ctx)
+			if err != nil {
+				logging.WithError(ctx, err).Error("failed to expire reservations")
+				continue
+			}
+			if expired > 0 {
+				logging.WithField(ctx, "stock_rows", expired).Info("expired reservations released")
+			}
+		}
+	}
+}
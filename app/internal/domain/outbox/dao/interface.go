@@ -0,0 +1,32 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgreSQLClient interface {
+	Acquire(ctx context.Context) (*pgxpool.Conn, error)
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+// Querier runs the queries of the transaction a product is changed in
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type OutboxDAO interface {
+	Relay(ctx context.Context, limit uint64, skip []string, deliver func([]*Event) []int64) (int, error)
+	Prune(ctx context.Context, before time.Time) (int64, error)
+	Events(ctx context.Context, after int64, categoryIDs []uint32, limit uint64) ([]*Event, error)
+	Bounds(ctx context.Context) (pruned, last int64, err error)
+	Listen(ctx context.Context, notify func(tenantID string)) error
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+// Event is a change of a product waiting in the outbox, Payload is the product row
+// as json after the change, before it for deleted products
+type Event struct {
+	Seq         int64
+	TenantID    string
+	ProductID   string
+	Type        string
+	Payload     []byte
+	CreatedAt   sql.NullTime
+	PublishedAt sql.NullTime
+}
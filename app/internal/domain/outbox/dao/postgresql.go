@@ -0,0 +1,433 @@
+package dao
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+)
+
+type outboxDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewOutboxDAOPostgres(client PostgreSQLClient) OutboxDAO {
+	return &outboxDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme      = "public"
+	table       = "product_outbox"
+	tableScheme = scheme + "." + table
+
+	// stateTableScheme records the highest seq pruned so far of every tenant, the positions
+	// of the tenant before it may have missed events and are no longer valid to go on from
+	stateTableScheme = scheme + ".product_outbox_state"
+
+	tenantColumn = "tenant_id"
+
+	// relayLockKey is the session advisory lock the relays of all instances take turns with,
+	// a single relay at a time keeps the events of a product in order
+	relayLockKey = 0x6f7574626f78
+
+	// writeLockClass is the class of the advisory locks a transaction holds from writing
+	// the events of a tenant until it commits, one lock per tenant. The events of a tenant
+	// are thus committed in the order of seq and a reader of the tenant done with a seq
+	// never sees a lower one appear later, which makes seq a position to resume from.
+	//
+	// The cost is that product changes of a tenant commit one at a time from the moment
+	// their events are written, which is the last statement of the transactions recording
+	// them. Tenants do not wait for each other, unless their names hash alike.
+	writeLockClass = 0x6f757462
+
+	// notifyChannel is notified with the tenant of the events on every commit
+	notifyChannel = "product_outbox"
+)
+
+// Types of the product events
+const (
+	ProductCreated = "product.created"
+	ProductUpdated = "product.updated"
+	ProductDeleted = "product.deleted"
+)
+
+// Returning is the clause a product mutation recorded by Record ends with,
+// table is the name or the alias the product table goes by in the mutation
+func Returning(table string) string {
+	return ReturningMoved(table, table+".category_id")
+}
+
+// ReturningMoved is Returning for mutations which may move products to another category,
+// previousCategory is the expression of the category a product was in before the mutation
+func ReturningMoved(table, previousCategory string) string {
+	return "RETURNING " + table + ".id, " + table + "." + tenantColumn + ", to_jsonb(" + table + "), " + previousCategory
+}
+
+// Record runs the product mutation in the transaction and writes an event of the type
+// for every product changed, so the events are kept exactly when the change is.
+// The number of products changed is returned, errors are left to the caller to log.
+func Record(ctx context.Context, tx Querier, eventType string, sql string, args ...interface{}) (int64, error) {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, db.ErrDoQuery(err)
+	}
+
+	insert := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert(tableScheme).
+		Columns(tenantColumn, "product_id", "type", "payload", "previous_category_id", "created_at")
+
+	var changed int64
+	tenants := make(map[string]bool)
+	for rows.Next() {
+		var productID, tenantID string
+		var payload []byte
+		var previousCategoryID int64
+		if err = rows.Scan(&productID, &tenantID, &payload, &previousCategoryID); err != nil {
+			rows.Close()
+			return 0, db.ErrScan(err)
+		}
+
+		insert = insert.Values(tenantID, productID, eventType, string(payload), previousCategoryID, sq.Expr("NOW()"))
+		tenants[tenantID] = true
+		changed++
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, db.ErrDoQuery(err)
+	}
+
+	if changed == 0 {
+		return 0, nil
+	}
+
+	insertSQL, insertArgs, err := insert.ToSql()
+	if err != nil {
+		return 0, db.ErrCreateQuery(err)
+	}
+
+	// tenants are locked in the same order by every transaction, so none waits for another in turn
+	locked := make([]string, 0, len(tenants))
+	for tenantID := range tenants {
+		locked = append(locked, tenantID)
+	}
+	sort.Strings(locked)
+	for _, tenantID := range locked {
+		if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1, hashtext($2))", writeLockClass, tenantID); err != nil {
+			return 0, db.ErrDoQuery(err)
+		}
+	}
+
+	if _, err = tx.Exec(ctx, insertSQL, insertArgs...); err != nil {
+		return 0, db.ErrDoQuery(err)
+	}
+
+	// notifications are delivered on commit, the ones of a rolled back transaction never
+	for _, tenantID := range locked {
+		if _, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", notifyChannel, tenantID); err != nil {
+			return 0, db.ErrDoQuery(err)
+		}
+	}
+
+	return changed, nil
+}
+
+// Relay hands the oldest pending events of the products not to skip to deliver and marks
+// the ones it returns as published. Nothing is relayed while the relay of another instance
+// is at work.
+//
+// The relay claims the pending events with a session advisory lock rather than a transaction:
+// the events are read, delivered and marked published by separate statements, so no
+// transaction and no row lock is held while deliver waits on the network. The lock goes
+// with the connection if the instance dies, the events it delivered but did not mark are
+// delivered again by the next relay, which a publisher has to allow for anyway.
+func (s *outboxDAOPostgres) Relay(ctx context.Context, limit uint64, skip []string, deliver func([]*Event) []int64) (int, error) {
+	sql, args, buildErr := s.queryBuilder.
+		Select("seq").
+		Columns(
+			tenantColumn,
+			"product_id",
+			"type",
+			"payload",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{"published_at": nil}).
+		Where(sq.NotEq{"product_id": skip}).
+		OrderBy("seq").
+		Limit(limit).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return 0, buildErr
+	}
+
+	conn, err := s.client.Acquire(ctx)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return 0, err
+	}
+
+	var locked bool
+	if err = conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", relayLockKey).Scan(&locked); err != nil {
+		conn.Release()
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return 0, err
+	}
+	if !locked {
+		conn.Release()
+		return 0, nil
+	}
+
+	defer func() {
+		// ctx may be done by now, the lock is released all the same
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", relayLockKey); err != nil {
+			logger.WithError(err).Error("failed to release relay lock")
+			// a connection holding the lock must not go back to the pool, closing it releases the lock
+			_ = conn.Hijack().Close(context.Background())
+			return
+		}
+		conn.Release()
+	}()
+
+	delivered, err := s.relay(ctx, conn, sql, args, deliver)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return delivered, nil
+}
+
+// relay reads the pending events, delivers them and marks the delivered ones published
+func (s *outboxDAOPostgres) relay(
+	ctx context.Context,
+	conn Querier,
+	sql string,
+	args []interface{},
+	deliver func([]*Event) []int64,
+) (int, error) {
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, db.ErrDoQuery(err)
+	}
+
+	list := make([]*Event, 0)
+	for rows.Next() {
+		e := Event{}
+		if err = rows.Scan(&e.Seq, &e.TenantID, &e.ProductID, &e.Type, &e.Payload, &e.CreatedAt); err != nil {
+			rows.Close()
+			return 0, db.ErrScan(err)
+		}
+		list = append(list, &e)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, db.ErrDoQuery(err)
+	}
+
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	seqs := deliver(list)
+	if len(seqs) == 0 {
+		return 0, nil
+	}
+
+	updateSQL, updateArgs, err := s.queryBuilder.
+		Update(tableScheme).
+		Set("published_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"seq": seqs}).
+		ToSql()
+	if err != nil {
+		return 0, db.ErrCreateQuery(err)
+	}
+
+	if _, err = conn.Exec(ctx, updateSQL, updateArgs...); err != nil {
+		return 0, db.ErrDoQuery(err)
+	}
+
+	return len(seqs), nil
+}
+
+// Prune removes the events published before the moment and records the highest seq
+// removed of every tenant, so positions of the tenant before it are known to have missed events
+func (s *outboxDAOPostgres) Prune(ctx context.Context, before time.Time) (int64, error) {
+	const sql = `
+WITH pruned AS (
+	DELETE FROM ` + tableScheme + ` WHERE published_at < $1 RETURNING ` + tenantColumn + `, seq
+), marked AS (
+	INSERT INTO ` + stateTableScheme + ` (` + tenantColumn + `, pruned_seq)
+	SELECT ` + tenantColumn + `, MAX(seq) FROM pruned GROUP BY ` + tenantColumn + `
+	ON CONFLICT (` + tenantColumn + `) DO UPDATE SET pruned_seq = GREATEST(` + stateTableScheme + `.pruned_seq, EXCLUDED.pruned_seq)
+)
+SELECT COUNT(*) FROM pruned`
+
+	var pruned int64
+	if err := s.client.QueryRow(ctx, sql, before).Scan(&pruned); err != nil {
+		err = db.ErrDoQuery(err)
+		logging.WithError(ctx, err).WithField("table", tableScheme).Error("failed to prune events")
+		return 0, err
+	}
+
+	return pruned, nil
+}
+
+// Events returns the events of the tenant after the position in order, only the ones
+// of products of the categories or moved out of them when categories are given
+func (s *outboxDAOPostgres) Events(ctx context.Context, after int64, categoryIDs []uint32, limit uint64) ([]*Event, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.queryBuilder.
+		Select("seq").
+		Columns(
+			tenantColumn,
+			"product_id",
+			"type",
+			"payload",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID}).
+		Where(sq.Gt{"seq": after}).
+		OrderBy("seq").
+		Limit(limit)
+	if len(categoryIDs) != 0 {
+		query = query.Where(sq.Or{
+			sq.Eq{"(payload->>'category_id')::bigint": categoryIDs},
+			sq.Eq{"previous_category_id": categoryIDs},
+		})
+	}
+
+	sql, args, buildErr := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return nil, buildErr
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Event, 0)
+	for rows.Next() {
+		e := Event{}
+		if err = rows.Scan(&e.Seq, &e.TenantID, &e.ProductID, &e.Type, &e.Payload, &e.CreatedAt); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Bounds returns the highest seq pruned and the last seq written of the tenant, zeros
+// while there were none. Events after the pruned seq are all kept.
+func (s *outboxDAOPostgres) Bounds(ctx context.Context) (pruned, last int64, err error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// seq is only in order within a tenant, the write lock is taken per tenant
+	sql, args, buildErr := s.queryBuilder.
+		Select().
+		Column(sq.Expr("COALESCE((SELECT pruned_seq FROM "+stateTableScheme+" WHERE "+tenantColumn+" = ?), 0)", tenantID)).
+		Column(sq.Expr("COALESCE((SELECT MAX(seq) FROM "+tableScheme+" WHERE "+tenantColumn+" = ?), 0)", tenantID)).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return 0, 0, buildErr
+	}
+
+	if err = s.client.QueryRow(ctx, sql, args...).Scan(&pruned, &last); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return 0, 0, err
+	}
+
+	// every event may have been pruned, the sequence went as far as the pruning did
+	if last < pruned {
+		last = pruned
+	}
+
+	return pruned, last, nil
+}
+
+// Listen calls notify with the tenant of every commit of events until ctx is done or the
+// connection fails. notify is called with an empty tenant once listening has started, events
+// committed while nobody listened may have been missed by then.
+func (s *outboxDAOPostgres) Listen(ctx context.Context, notify func(tenantID string)) error {
+	pooled, err := s.client.Acquire(ctx)
+	if err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	// the connection listens for good, so it does not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	notify("")
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return db.ErrDoQuery(err)
+		}
+		notify(n.Payload)
+	}
+}
@@ -0,0 +1,173 @@
+package dao
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// statement is a query the relay sent to the database
+type statement struct {
+	sql  string
+	args []interface{}
+}
+
+// eventConn returns the events for any query and records the statements
+type eventConn struct {
+	events     []*Event
+	statements []statement
+}
+
+func (c *eventConn) Query(_ context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	c.statements = append(c.statements, statement{sql: sql, args: args})
+	return &eventRows{events: c.events, next: -1}, nil
+}
+
+func (c *eventConn) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	c.statements = append(c.statements, statement{sql: sql, args: args})
+	return pgconn.CommandTag("UPDATE 0"), nil
+}
+
+type eventRows struct {
+	pgx.Rows
+	events []*Event
+	next   int
+}
+
+func (r *eventRows) Next() bool {
+	r.next++
+	return r.next < len(r.events)
+}
+
+func (r *eventRows) Scan(dest ...interface{}) error {
+	e := r.events[r.next]
+	*dest[0].(*int64) = e.Seq
+	*dest[1].(*string) = e.TenantID
+	*dest[2].(*string) = e.ProductID
+	*dest[3].(*string) = e.Type
+	*dest[4].(*[]byte) = e.Payload
+	return nil
+}
+
+func (r *eventRows) Err() error { return nil }
+func (r *eventRows) Close()     {}
+
+func TestRelayMarksDeliveredEventsOnly(t *testing.T) {
+	conn := &eventConn{events: []*Event{
+		{Seq: 1, TenantID: "t", ProductID: "p1", Type: ProductCreated},
+		{Seq: 2, TenantID: "t", ProductID: "p2", Type: ProductCreated},
+		{Seq: 3, TenantID: "t", ProductID: "p1", Type: ProductUpdated},
+	}}
+	s := NewOutboxDAOPostgres(nil).(*outboxDAOPostgres)
+
+	var handed []int64
+	delivered, err := s.relay(context.Background(), conn, "SELECT", nil, func(list []*Event) []int64 {
+		for _, e := range list {
+			handed = append(handed, e.Seq)
+		}
+		// the events of p1 failed
+		return []int64{2}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(handed, []int64{1, 2, 3}) {
+		t.Errorf("handed %v to deliver, want the events in order", handed)
+	}
+	if delivered != 1 {
+		t.Errorf("got %d delivered, want 1", delivered)
+	}
+
+	update := conn.statements[len(conn.statements)-1]
+	if !strings.HasPrefix(update.sql, "UPDATE "+tableScheme+" SET published_at") {
+		t.Fatalf("last statement does not mark events published: %s", update.sql)
+	}
+	if !reflect.DeepEqual(update.args, []interface{}{int64(2)}) {
+		t.Errorf("marked %v published, want [2]", update.args)
+	}
+}
+
+func TestRelayMarksNothingWhenNothingWasDelivered(t *testing.T) {
+	conn := &eventConn{events: []*Event{{Seq: 1, TenantID: "t", ProductID: "p1", Type: ProductCreated}}}
+	s := NewOutboxDAOPostgres(nil).(*outboxDAOPostgres)
+
+	delivered, err := s.relay(context.Background(), conn, "SELECT", nil, func([]*Event) []int64 { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if delivered != 0 || len(conn.statements) != 1 {
+		t.Errorf("got %d delivered after statements %v, want none marked", delivered, conn.statements)
+	}
+}
+
+// changedRows are the rows a product mutation returns, product and tenant IDs
+type changedRows struct {
+	pgx.Rows
+	rows [][2]string
+	next int
+}
+
+func (r *changedRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *changedRows) Scan(dest ...interface{}) error {
+	row := r.rows[r.next-1]
+	*dest[0].(*string) = row[0]
+	*dest[1].(*string) = row[1]
+	*dest[2].(*[]byte) = []byte(`{}`)
+	return nil
+}
+
+func (r *changedRows) Err() error { return nil }
+func (r *changedRows) Close()     {}
+
+type mutationConn struct {
+	eventConn
+	changed [][2]string
+}
+
+func (c *mutationConn) Query(_ context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	c.statements = append(c.statements, statement{sql: sql, args: args})
+	return &changedRows{rows: c.changed}, nil
+}
+
+func TestRecordLocksTenantsBeforeWritingEvents(t *testing.T) {
+	conn := &mutationConn{changed: [][2]string{{"p1", "tenant-b"}, {"p2", "tenant-a"}, {"p3", "tenant-b"}}}
+
+	changed, err := Record(context.Background(), conn, ProductUpdated, "UPDATE public.product")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed != 3 {
+		t.Errorf("got %d changed, want 3", changed)
+	}
+
+	var locked []interface{}
+	inserted := false
+	for _, s := range conn.statements[1:] {
+		switch {
+		case strings.Contains(s.sql, "pg_advisory_xact_lock"):
+			if inserted {
+				t.Error("tenant was locked after its events were written")
+			}
+			locked = append(locked, s.args[1])
+		case strings.HasPrefix(s.sql, "INSERT INTO "+tableScheme):
+			inserted = true
+		}
+	}
+
+	if !inserted {
+		t.Error("no events were written")
+	}
+	if !reflect.DeepEqual(locked, []interface{}{"tenant-a", "tenant-b"}) {
+		t.Errorf("locked %v, want every tenant once in order", locked)
+	}
+}
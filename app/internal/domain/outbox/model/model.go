@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/events"
+)
+
+// Event is a change of a product, Product is the product as stored after the change
+// or, for a deleted product, before it. Seq orders the events of all products.
+type Event struct {
+	Seq       int64
+	TenantID  string
+	ProductID string
+	Type      string
+	Product   json.RawMessage
+	CreatedAt time.Time
+}
+
+func NewEventFromDAO(e *dao.Event) *Event {
+	return &Event{
+		Seq:       e.Seq,
+		TenantID:  e.TenantID,
+		ProductID: e.ProductID,
+		Type:      e.Type,
+		Product:   e.Payload,
+		CreatedAt: e.CreatedAt.Time,
+	}
+}
+
+// ToCloudEvent wraps the event for consumers, the sequence doubles as the ID
+// so a redelivered event keeps its ID
+func (e *Event) ToCloudEvent(source string) *events.Event {
+	return &events.Event{
+		SpecVersion:     events.SpecVersion,
+		ID:              strconv.FormatInt(e.Seq, 10),
+		Source:          source,
+		Type:            e.Type,
+		Subject:         e.ProductID,
+		Time:            e.CreatedAt,
+		DataContentType: "application/json",
+		TenantID:        e.TenantID,
+		Sequence:        e.Seq,
+		Data:            e.Product,
+	}
+}
+
+func (e *Event) ToProto() *pbProducts.ProductEvent {
+	return &pbProducts.ProductEvent{
+		Position:   uint64(e.Seq),
+		Type:       e.Type,
+		ProductId:  e.ProductID,
+		OccurredAt: e.CreatedAt.UnixMilli(),
+	}
+}
+
+// syncTokenPrefix versions the sync tokens, so their content may change later
+const syncTokenPrefix = "v1:"
+
+var ErrBadSyncToken = errors.Validation("sync token is malformed")
+
+// Changes are the events after a position read at once
+type Changes struct {
+	Events []*Event
+	// Position is where the next read goes on from
+	Position int64
+	// More is set when events after Position are waiting already
+	More bool
+	// Expired is set when events after the position asked for are no longer kept
+	Expired bool
+}
+
+// Latest returns the last event of every product in order, the earlier ones are overtaken
+func (c *Changes) Latest() []*Event {
+	last := make(map[string]int, len(c.Events))
+	for i, e := range c.Events {
+		last[e.ProductID] = i
+	}
+
+	latest := make([]*Event, 0, len(last))
+	for i, e := range c.Events {
+		if last[e.ProductID] == i {
+			latest = append(latest, e)
+		}
+	}
+
+	return latest
+}
+
+// EncodeSyncToken returns the opaque token a client syncs on from the position with
+func EncodeSyncToken(position int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(syncTokenPrefix + strconv.FormatInt(position, 10)))
+}
+
+func DecodeSyncToken(token string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrBadSyncToken
+	}
+
+	value := string(b)
+	if !strings.HasPrefix(value, syncTokenPrefix) {
+		return 0, ErrBadSyncToken
+	}
+
+	position, err := strconv.ParseInt(strings.TrimPrefix(value, syncTokenPrefix), 10, 64)
+	if err != nil || position < 0 {
+		return 0, ErrBadSyncToken
+	}
+
+	return position, nil
+}
@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+const (
+	DefaultSyncPageSize = 100
+	MaxSyncPageSize     = 1000
+)
+
+type OutboxPolicy struct {
+	outboxService *service.OutboxService
+}
+
+func NewOutboxPolicy(outboxService *service.OutboxService) *OutboxPolicy {
+	return &OutboxPolicy{outboxService: outboxService}
+}
+
+// Watch streams the product events after the position to send, see OutboxService.Watch
+func (p *OutboxPolicy) Watch(
+	ctx context.Context,
+	after uint64,
+	categoryIDs []uint32,
+	send func([]*model.Event) error,
+) error {
+	if after > math.MaxInt64 {
+		var violations errors.FieldViolations
+		violations.Add("after", "is not a position of an event")
+		return violations.Err()
+	}
+
+	return p.outboxService.Watch(ctx, int64(after), categoryIDs, send)
+}
+
+// Sync returns the product changes after the sync token, an empty token asks for a full sync
+func (p *OutboxPolicy) Sync(ctx context.Context, token string, pageSize uint32) (*model.Changes, error) {
+	var violations errors.FieldViolations
+	if pageSize > MaxSyncPageSize {
+		violations.Add("page_size", fmt.Sprintf("must be at most %d", MaxSyncPageSize))
+	}
+
+	var after int64
+	if token != "" {
+		position, err := model.DecodeSyncToken(token)
+		if err != nil {
+			violations.Add("token", "is not a sync token")
+		}
+		after = position
+	}
+
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultSyncPageSize
+	}
+
+	return p.outboxService.Changes(ctx, after, uint64(pageSize))
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/events"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+)
+
+// watchBatchSize is the number of events a watcher reads at once
+const watchBatchSize = 100
+
+type OutboxService struct {
+	repository dao.OutboxDAO
+	publisher  events.Publisher
+	source     string
+
+	mu sync.Mutex
+	// watchers are woken up through their channel when events of their tenant are committed
+	watchers map[chan struct{}]string
+}
+
+func NewOutboxService(repository dao.OutboxDAO, publisher events.Publisher, source string) *OutboxService {
+	return &OutboxService{
+		repository: repository,
+		publisher:  publisher,
+		source:     source,
+		watchers:   make(map[chan struct{}]string),
+	}
+}
+
+// RunRelay delivers the pending events every interval until ctx is done, batch by batch
+// until none is left. Products an event failed for are left out of the later batches of
+// the run, so their events do not fill the batches ahead of the others.
+// Published events are kept for the retention, forever without one.
+func (s *OutboxService) RunRelay(ctx context.Context, interval time.Duration, batchSize uint64, retention time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	defer func() {
+		if err := s.publisher.Close(); err != nil {
+			logging.WithError(ctx, err).Error("failed to close event publisher")
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.relay(ctx, batchSize)
+
+			if retention > 0 {
+				if _, err := s.repository.Prune(ctx, time.Now().Add(-retention)); err != nil {
+					logging.WithError(ctx, err).Error("failed to prune published events")
+				}
+			}
+		}
+	}
+}
+
+// relay delivers the pending events batch by batch until none is left but the ones of
+// products held back in this run
+func (s *OutboxService) relay(ctx context.Context, batchSize uint64) {
+	held := make(map[string]bool)
+	for {
+		var read int
+		deliver := s.deliver(ctx, held)
+		delivered, err := s.repository.Relay(ctx, batchSize, heldProducts(held), func(list []*dao.Event) []int64 {
+			read = len(list)
+			return deliver(list)
+		})
+		if err != nil {
+			logging.WithError(ctx, err).Error("failed to relay events")
+			return
+		}
+		if delivered > 0 {
+			logging.WithField(ctx, "events", delivered).Debug("events published")
+		}
+		if uint64(read) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver publishes the events in order and returns the ones published. Once an event
+// of a product fails the product is held, its later events wait, so they are not published
+// before it when it is retried.
+func (s *OutboxService) deliver(ctx context.Context, held map[string]bool) func([]*dao.Event) []int64 {
+	return func(list []*dao.Event) []int64 {
+		published := make([]int64, 0, len(list))
+		for _, e := range list {
+			if held[e.ProductID] {
+				continue
+			}
+
+			if err := s.publisher.Publish(ctx, model.NewEventFromDAO(e).ToCloudEvent(s.source)); err != nil {
+				logging.WithError(ctx, err).WithFields(map[string]interface{}{
+					"seq":        e.Seq,
+					"product_id": e.ProductID,
+					"type":       e.Type,
+				}).Error("failed to publish event")
+				held[e.ProductID] = true
+				continue
+			}
+
+			published = append(published, e.Seq)
+		}
+
+		return published
+	}
+}
+
+func heldProducts(held map[string]bool) []string {
+	ids := make([]string, 0, len(held))
+	for id := range held {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// RunListener wakes the watchers whenever events of their tenant are committed until ctx
+// is done. A lost connection is restored after the retry delay.
+func (s *OutboxService) RunListener(ctx context.Context, retry time.Duration) error {
+	for {
+		err := s.repository.Listen(ctx, s.wake)
+		if ctx.Err() != nil {
+			return nil
+		}
+		logging.WithError(ctx, err).Error("stopped listening to events")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retry):
+		}
+	}
+}
+
+// Watch hands the events of the tenant after the position to send as they are committed,
+// until ctx is done or send fails. Position 0 starts with the events committed from now on.
+// Positions before the highest seq pruned of the tenant are refused, events after them are gone.
+func (s *OutboxService) Watch(
+	ctx context.Context,
+	after int64,
+	categoryIDs []uint32,
+	send func([]*model.Event) error,
+) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// watching starts before reading, so no commit falls in between
+	wake := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.watchers[wake] = tenantID
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, wake)
+		s.mu.Unlock()
+	}()
+
+	pruned, last, err := s.repository.Bounds(ctx)
+	if err != nil {
+		return errors.Wrap(err, "repository.Bounds")
+	}
+	switch {
+	case after == 0:
+		after = last
+	case after < pruned:
+		return errors.OutOfRange("position is no longer kept, reload the products and watch from now")
+	case after > last:
+		return errors.OutOfRange("position is ahead of the events")
+	}
+
+	for {
+		for {
+			dbEvents, err := s.repository.Events(ctx, after, categoryIDs, watchBatchSize)
+			if err != nil {
+				return errors.Wrap(err, "repository.Events")
+			}
+			if len(dbEvents) == 0 {
+				break
+			}
+
+			list := make([]*model.Event, len(dbEvents))
+			for i, e := range dbEvents {
+				list[i] = model.NewEventFromDAO(e)
+			}
+			if err = send(list); err != nil {
+				return err
+			}
+
+			after = list[len(list)-1].Seq
+			if len(dbEvents) < watchBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		}
+	}
+}
+
+// wake wakes the watchers of the tenant, all of them without a tenant
+func (s *OutboxService) wake(tenantID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch, watched := range s.watchers {
+		if tenantID != "" && watched != tenantID {
+			continue
+		}
+		select {
+		case ch <- struct{}{}:
+		default:
+			// the watcher is already due to read
+		}
+	}
+}
+
+// Changes returns up to limit events of the tenant after the position. Position 0 asks
+// for none but where the events are now, as does a position events after it were pruned of.
+func (s *OutboxService) Changes(ctx context.Context, after int64, limit uint64) (*model.Changes, error) {
+	pruned, last, err := s.repository.Bounds(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Bounds")
+	}
+	switch {
+	case after == 0 || after < pruned:
+		return &model.Changes{Position: last, Expired: after != 0}, nil
+	case after > last:
+		return nil, errors.OutOfRange("position is ahead of the events")
+	}
+
+	// one more event tells whether more are waiting
+	dbEvents, err := s.repository.Events(ctx, after, nil, limit+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Events")
+	}
+
+	changes := &model.Changes{Position: after}
+	if uint64(len(dbEvents)) > limit {
+		dbEvents = dbEvents[:limit]
+		changes.More = true
+	}
+
+	changes.Events = make([]*model.Event, len(dbEvents))
+	for i, e := range dbEvents {
+		changes.Events[i] = model.NewEventFromDAO(e)
+	}
+	if len(changes.Events) != 0 {
+		changes.Position = changes.Events[len(changes.Events)-1].Seq
+	}
+
+	return changes, nil
+}
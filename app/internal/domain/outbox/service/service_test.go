@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/outbox/model"
+	appErrors "github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/events"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+)
+
+// failingPublisher fails the events of the products and records the others
+type failingPublisher struct {
+	failing   map[string]bool
+	published []string
+}
+
+func (p *failingPublisher) Publish(_ context.Context, e *events.Event) error {
+	if p.failing[e.Subject] {
+		return errors.New("unavailable")
+	}
+	p.published = append(p.published, e.ID)
+	return nil
+}
+
+func (p *failingPublisher) Close() error { return nil }
+
+func TestDeliverHoldsBackEventsAfterFailure(t *testing.T) {
+	publisher := &failingPublisher{failing: map[string]bool{"p1": true}}
+	s := NewOutboxService(nil, publisher, "test")
+
+	list := []*dao.Event{
+		{Seq: 1, TenantID: "t", ProductID: "p1", Type: dao.ProductCreated, Payload: []byte(`{}`)},
+		{Seq: 2, TenantID: "t", ProductID: "p2", Type: dao.ProductCreated, Payload: []byte(`{}`)},
+		{Seq: 3, TenantID: "t", ProductID: "p1", Type: dao.ProductUpdated, Payload: []byte(`{}`)},
+		{Seq: 4, TenantID: "t", ProductID: "p2", Type: dao.ProductUpdated, Payload: []byte(`{}`)},
+	}
+
+	held := make(map[string]bool)
+	published := s.deliver(context.Background(), held)(list)
+	if !reflect.DeepEqual(published, []int64{2, 4}) {
+		t.Errorf("got %v published, want [2 4]", published)
+	}
+	if len(publisher.published) != 2 {
+		t.Errorf("published %v, the later event of p1 went out before the failed one", publisher.published)
+	}
+	if !reflect.DeepEqual(held, map[string]bool{"p1": true}) {
+		t.Errorf("got %v held, want p1 only", held)
+	}
+}
+
+// pendingEvents is an outbox of pending events, Relay hands them out in order like the DAO
+type pendingEvents struct {
+	dao.OutboxDAO
+	events    []*dao.Event
+	published map[int64]bool
+}
+
+func (o *pendingEvents) Relay(_ context.Context, limit uint64, skip []string, deliver func([]*dao.Event) []int64) (int, error) {
+	skipped := make(map[string]bool, len(skip))
+	for _, id := range skip {
+		skipped[id] = true
+	}
+
+	var list []*dao.Event
+	for _, e := range o.events {
+		if !o.published[e.Seq] && !skipped[e.ProductID] && uint64(len(list)) < limit {
+			list = append(list, e)
+		}
+	}
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	seqs := deliver(list)
+	for _, seq := range seqs {
+		o.published[seq] = true
+	}
+	return len(seqs), nil
+}
+
+func TestRelaySkipsHeldProductsInLaterBatches(t *testing.T) {
+	// the failing product has a full batch of events ahead of the others
+	outbox := &pendingEvents{published: make(map[int64]bool), events: []*dao.Event{
+		{Seq: 1, TenantID: "t", ProductID: "p1", Type: dao.ProductCreated, Payload: []byte(`{}`)},
+		{Seq: 2, TenantID: "t", ProductID: "p1", Type: dao.ProductUpdated, Payload: []byte(`{}`)},
+		{Seq: 3, TenantID: "t", ProductID: "p1", Type: dao.ProductUpdated, Payload: []byte(`{}`)},
+		{Seq: 4, TenantID: "t", ProductID: "p2", Type: dao.ProductCreated, Payload: []byte(`{}`)},
+		{Seq: 5, TenantID: "t", ProductID: "p3", Type: dao.ProductCreated, Payload: []byte(`{}`)},
+	}}
+	publisher := &failingPublisher{failing: map[string]bool{"p1": true}}
+	s := NewOutboxService(outbox, publisher, "test")
+
+	s.relay(context.Background(), 2)
+
+	if !reflect.DeepEqual(outbox.published, map[int64]bool{4: true, 5: true}) {
+		t.Errorf("got %v published, want the events of p2 and p3", outbox.published)
+	}
+}
+
+// keptEvents is an outbox pruned up to a seq per tenant, the events kept of the tenant are returned in order
+type keptEvents struct {
+	dao.OutboxDAO
+	pruned map[string]int64
+	events []*dao.Event
+}
+
+func (o *keptEvents) Bounds(ctx context.Context) (int64, int64, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	pruned := o.pruned[tenantID]
+	last := pruned
+	for _, e := range o.events {
+		if e.TenantID == tenantID && e.Seq > last {
+			last = e.Seq
+		}
+	}
+	return pruned, last, nil
+}
+
+func (o *keptEvents) Events(ctx context.Context, after int64, _ []uint32, limit uint64) ([]*dao.Event, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*dao.Event
+	for _, e := range o.events {
+		if e.TenantID == tenantID && e.Seq > after && uint64(len(list)) < limit {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+func TestChangesExpirePositionsBeforeThePrunedSeq(t *testing.T) {
+	// event 2 is not published yet and kept, the later events 3 and 4 were pruned
+	outbox := &keptEvents{pruned: map[string]int64{"t": 4}, events: []*dao.Event{
+		{Seq: 2, TenantID: "t", ProductID: "p1", Type: dao.ProductCreated},
+		{Seq: 5, TenantID: "t", ProductID: "p2", Type: dao.ProductCreated},
+	}}
+	s := NewOutboxService(outbox, nil, "test")
+	ctx := tenant.ContextWithTenant(context.Background(), "t")
+
+	tests := []struct {
+		after    int64
+		expired  bool
+		position int64
+	}{
+		{after: 1, expired: true, position: 5},
+		{after: 3, expired: true, position: 5},
+		{after: 4, position: 5},
+		{after: 5, position: 5},
+	}
+
+	for _, tt := range tests {
+		changes, err := s.Changes(ctx, tt.after, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changes.Expired != tt.expired || changes.Position != tt.position {
+			t.Errorf("after %d: got expired %v at %d, want %v at %d",
+				tt.after, changes.Expired, changes.Position, tt.expired, tt.position)
+		}
+	}
+}
+
+func TestChangesStartAtTheLastEventOfTheTenant(t *testing.T) {
+	outbox := &keptEvents{events: []*dao.Event{
+		{Seq: 1, TenantID: "t", ProductID: "p1", Type: dao.ProductCreated},
+		{Seq: 2, TenantID: "other", ProductID: "p2", Type: dao.ProductCreated},
+	}}
+	s := NewOutboxService(outbox, nil, "test")
+	ctx := tenant.ContextWithTenant(context.Background(), "t")
+
+	changes, err := s.Changes(ctx, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes.Position != 1 {
+		t.Errorf("got position %d, want 1 the last event of the tenant", changes.Position)
+	}
+
+	// a later event of another tenant is no position of the tenant
+	_, err = s.Changes(ctx, 2, 10)
+	if appErrors.KindOf(err) != appErrors.KindOutOfRange {
+		t.Errorf("got error %v, want out of range", err)
+	}
+}
+
+func TestChangesKeepPositionsPrunedByAnotherTenant(t *testing.T) {
+	outbox := &keptEvents{pruned: map[string]int64{"other": 4}, events: []*dao.Event{
+		{Seq: 2, TenantID: "t", ProductID: "p1", Type: dao.ProductCreated},
+		{Seq: 5, TenantID: "t", ProductID: "p2", Type: dao.ProductCreated},
+	}}
+	s := NewOutboxService(outbox, nil, "test")
+	ctx := tenant.ContextWithTenant(context.Background(), "t")
+
+	changes, err := s.Changes(ctx, 2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes.Expired || changes.Position != 5 {
+		t.Errorf("got expired %v at %d, want the events up to 5", changes.Expired, changes.Position)
+	}
+}
+
+func TestChangesRefusePositionsAheadOfTheEvents(t *testing.T) {
+	s := NewOutboxService(&keptEvents{pruned: map[string]int64{"t": 4}}, nil, "test")
+	ctx := tenant.ContextWithTenant(context.Background(), "t")
+
+	if changes, err := s.Changes(ctx, 4, 10); err != nil || changes.Expired {
+		t.Errorf("got %+v, %v at the pruned seq with every event pruned", changes, err)
+	}
+
+	_, err := s.Changes(ctx, 5, 10)
+	if appErrors.KindOf(err) != appErrors.KindOutOfRange {
+		t.Errorf("got error %v, want out of range", err)
+	}
+}
+
+func TestWatchRefusesPositionsBeforeThePrunedSeq(t *testing.T) {
+	s := NewOutboxService(&keptEvents{pruned: map[string]int64{"t": 4}}, nil, "test")
+	ctx, cancel := context.WithTimeout(tenant.ContextWithTenant(context.Background(), "t"), time.Second)
+	defer cancel()
+
+	err := s.Watch(ctx, 3, nil, func([]*model.Event) error { return nil })
+	if appErrors.KindOf(err) != appErrors.KindOutOfRange {
+		t.Errorf("got error %v, want out of range", err)
+	}
+}
@@ -0,0 +1,24 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type PriceDAO interface {
+	History(context.Context, string) ([]*Price, error)
+	At(context.Context, string, time.Time) (*Price, error)
+	Create(context.Context, map[string]interface{}) error
+	CancelScheduled(context.Context, string) error
+	ApplyDue(context.Context) (int64, error)
+}
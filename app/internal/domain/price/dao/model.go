@@ -0,0 +1,14 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Price struct {
+	ID            string
+	ProductID     string
+	Price         uint64
+	EffectiveFrom sql.NullTime
+	EffectiveTo   sql.NullTime
+	CreatedAt     sql.NullTime
+}
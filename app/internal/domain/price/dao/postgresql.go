@@ -0,0 +1,277 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	outboxDao "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type priceDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewPriceDAOPostgres(client PostgreSQLClient) PriceDAO {
+	return &priceDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme             = "public"
+	table              = "product_price"
+	tableScheme        = scheme + "." + table
+	productTableScheme = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+var errProductNotFound = errors.NotFound("product not found")
+
+func (s *priceDAOPostgres) selectQuery(tenantID string) sq.SelectBuilder {
+	return s.queryBuilder.
+		Select("id").
+		Columns(
+			"product_id",
+			"price",
+			"effective_from",
+			"effective_to",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID})
+}
+
+func scanPrice(row interface{ Scan(...interface{}) error }, p *Price) error {
+	return row.Scan(
+		&p.ID,
+		&p.ProductID,
+		&p.Price,
+		&p.EffectiveFrom,
+		&p.EffectiveTo,
+		&p.CreatedAt,
+	)
+}
+
+func (s *priceDAOPostgres) History(ctx context.Context, productID string) ([]*Price, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.selectQuery(tenantID).
+		Where(sq.Eq{"product_id": productID}).
+		OrderBy("effective_from DESC", "created_at DESC").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Price, 0)
+
+	for rows.Next() {
+		p := Price{}
+		if err = scanPrice(rows, &p); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &p)
+	}
+
+	return list, nil
+}
+
+// At returns the entry in effect at the moment. A bounded sale overrides the open-ended
+// base prices while it lasts, even one set after it started. The latest started entry
+// wins otherwise.
+func (s *priceDAOPostgres) At(ctx context.Context, productID string, at time.Time) (*Price, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.selectQuery(tenantID).
+		Where(sq.Eq{"product_id": productID}).
+		Where(sq.LtOrEq{"effective_from": at}).
+		Where(sq.Or{sq.Eq{"effective_to": nil}, sq.Gt{"effective_to": at}}).
+		OrderBy("effective_to IS NULL", "effective_from DESC", "created_at DESC").
+		Limit(1).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	var p Price
+	if err = scanPrice(s.client.QueryRow(ctx, sql, args...), &p); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Create adds an entry to the timeline of a product of the tenant
+func (s *priceDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(tableScheme).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := s.lockProduct(ctx, tx, tenantID, m["product_id"]); err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if _, execErr := tx.Exec(ctx, sql, args...); execErr != nil {
+			execErr = db.ErrDoQuery(execErr)
+			logger.Error(execErr)
+			return execErr
+		}
+
+		return nil
+	})
+}
+
+// lockProduct makes sure the product belongs to the tenant and is not deleted until the transaction ends
+func (s *priceDAOPostgres) lockProduct(ctx context.Context, tx pgx.Tx, tenantID string, productID interface{}) error {
+	sql, args, buildErr := s.queryBuilder.
+		Select("id").
+		From(productTableScheme).
+		Where(sq.Eq{"id": productID, tenantColumn: tenantID}).
+		Suffix("FOR SHARE").
+		ToSql()
+	if buildErr != nil {
+		return db.ErrCreateQuery(buildErr)
+	}
+
+	var id string
+	if err := tx.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errProductNotFound
+		}
+		return db.ErrDoQuery(err)
+	}
+
+	return nil
+}
+
+// CancelScheduled deletes an entry which has not started yet. Started entries are history.
+func (s *priceDAOPostgres) CancelScheduled(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Delete(tableScheme).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		Where(sq.Expr("effective_from > NOW()")).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("scheduled price not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// ApplyDue copies the price in effect now into product.price for every product
+// whose timeline moved on since the previous run, for all tenants at once. The entries
+// are matched by tenant as well, so no timeline prices a product of another tenant.
+// The price changes go to the outbox as updates of the products.
+func (s *priceDAOPostgres) ApplyDue(ctx context.Context) (int64, error) {
+	sql := `
+UPDATE ` + productTableScheme + ` AS p
+SET price = current.price, updated_at = NOW()
+FROM (
+	SELECT DISTINCT ON (tenant_id, product_id) tenant_id, product_id, price
+	FROM ` + tableScheme + `
+	WHERE effective_from <= NOW() AND (effective_to IS NULL OR effective_to > NOW())
+	ORDER BY tenant_id, product_id, effective_to IS NULL, effective_from DESC, created_at DESC
+) AS current
+WHERE p.tenant_id = current.tenant_id AND p.id = current.product_id AND p.price <> current.price
+` + outboxDao.Returning("p")
+
+	var applied int64
+	err := s.client.BeginFunc(ctx, func(tx pgx.Tx) (err error) {
+		applied, err = outboxDao.Record(ctx, tx, outboxDao.ProductUpdated, sql)
+		return err
+	})
+	if err != nil {
+		logging.WithError(ctx, err).WithField("table", tableScheme).Error("failed to apply due prices")
+		return 0, err
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/price/dao"
+)
+
+// Price is an entry of the product price timeline. An entry without
+// EffectiveTo stays in effect until a later entry starts.
+type Price struct {
+	ID            string
+	ProductID     string
+	Price         uint64
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	CreatedAt     time.Time
+}
+
+func NewPrice(productID string, price uint64, from time.Time, to *time.Time) *Price {
+	return &Price{
+		ID:            uuid.New().String(),
+		ProductID:     productID,
+		Price:         price,
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func NewPriceFromPB(req *pbProducts.SchedulePriceRequest) *Price {
+	var to *time.Time
+	if req.EffectiveTo != nil {
+		t := time.UnixMilli(req.GetEffectiveTo())
+		to = &t
+	}
+
+	return NewPrice(req.GetProductId(), req.GetPrice(), time.UnixMilli(req.GetEffectiveFrom()), to)
+}
+
+func (p *Price) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":             p.ID,
+		"product_id":     p.ProductID,
+		"price":          p.Price,
+		"effective_from": p.EffectiveFrom,
+		"effective_to":   p.EffectiveTo,
+		"created_at":     p.CreatedAt,
+	}
+}
+
+func (p *Price) ToProto() *pbProducts.PriceEntry {
+	var to *int64
+	if p.EffectiveTo != nil {
+		ms := p.EffectiveTo.UnixMilli()
+		to = &ms
+	}
+
+	return &pbProducts.PriceEntry{
+		Id:            p.ID,
+		ProductId:     p.ProductID,
+		Price:         p.Price,
+		EffectiveFrom: p.EffectiveFrom.UnixMilli(),
+		EffectiveTo:   to,
+		CreatedAt:     p.CreatedAt.UnixMilli(),
+	}
+}
+
+func NewPriceFromDAO(p *dao.Price) *Price {
+	var to *time.Time
+	if p.EffectiveTo.Valid {
+		to = &p.EffectiveTo.Time
+	}
+
+	return &Price{
+		ID:            p.ID,
+		ProductID:     p.ProductID,
+		Price:         p.Price,
+		EffectiveFrom: p.EffectiveFrom.Time,
+		EffectiveTo:   to,
+		CreatedAt:     p.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/price/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/price/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type PricePolicy struct {
+	priceService *service.PriceService
+}
+
+func NewPricePolicy(priceService *service.PriceService) *PricePolicy {
+	return &PricePolicy{priceService: priceService}
+}
+
+func (p *PricePolicy) History(ctx context.Context, productID string) ([]*model.Price, error) {
+	prices, err := p.priceService.History(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "priceService.History")
+	}
+
+	return prices, nil
+}
+
+func (p *PricePolicy) At(ctx context.Context, productID string, at time.Time) (*model.Price, error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	price, err := p.priceService.At(ctx, productID, at)
+	if err != nil {
+		return nil, errors.Wrap(err, "priceService.At")
+	}
+
+	return price, nil
+}
+
+func (p *PricePolicy) Schedule(ctx context.Context, price *model.Price) (*model.Price, error) {
+	var violations errors.FieldViolations
+	if price.ProductID == "" {
+		violations.Add("product_id", "must not be empty")
+	}
+	if price.Price == 0 {
+		violations.Add("price", "must be greater than 0")
+	}
+	if price.EffectiveFrom.Before(time.Now()) {
+		violations.Add("effective_from", "must be in the future")
+	}
+	if price.EffectiveTo != nil && !price.EffectiveTo.After(price.EffectiveFrom) {
+		violations.Add("effective_to", "must be after effective_from")
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.priceService.Schedule(ctx, price)
+}
+
+func (p *PricePolicy) CancelScheduled(ctx context.Context, id string) error {
+	return p.priceService.CancelScheduled(ctx, id)
+}
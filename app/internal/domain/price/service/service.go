@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/price/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/price/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+)
+
+type PriceService struct {
+	repository dao.PriceDAO
+}
+
+func NewPriceService(repository dao.PriceDAO) *PriceService {
+	return &PriceService{repository: repository}
+}
+
+func (s *PriceService) History(ctx context.Context, productID string) ([]*model.Price, error) {
+	dbPrices, err := s.repository.History(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.History")
+	}
+
+	prices := make([]*model.Price, 0, len(dbPrices))
+	for _, dbP := range dbPrices {
+		prices = append(prices, model.NewPriceFromDAO(dbP))
+	}
+
+	return prices, nil
+}
+
+func (s *PriceService) At(ctx context.Context, productID string, at time.Time) (*model.Price, error) {
+	dbPrice, err := s.repository.At(ctx, productID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPriceFromDAO(dbPrice), nil
+}
+
+func (s *PriceService) Schedule(ctx context.Context, price *model.Price) (*model.Price, error) {
+	if err := s.repository.Create(ctx, price.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+func (s *PriceService) CancelScheduled(ctx context.Context, id string) error {
+	return s.repository.CancelScheduled(ctx, id)
+}
+
+// RunScheduler applies due price changes every interval until ctx is done
+func (s *PriceService) RunScheduler(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			applied, err := s.repository.ApplyDue(ctx)
+			if err != nil {
+				logging.WithError(ctx, err).Error("failed to apply due prices")
+				continue
+			}
+			if applied > 0 {
+				logging.WithField(ctx, "products", applied).Info("scheduled prices applied")
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type PriceListDAO interface {
+	All(context.Context) ([]*PriceList, error)
+	Create(context.Context, map[string]interface{}) error
+	Delete(context.Context, string) error
+	SetTiers(ctx context.Context, priceListID, productID string, tiers []*Tier) error
+	Tiers(ctx context.Context, customerGroup, channel string, productIDs []string) ([]*ProductTier, error)
+}
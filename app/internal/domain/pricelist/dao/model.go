@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type PriceList struct {
+	ID            string
+	Name          string
+	CurrencyID    uint32
+	CustomerGroup sql.NullString
+	Channel       sql.NullString
+	Priority      int32
+	CreatedAt     sql.NullTime
+}
+
+type Tier struct {
+	MinQuantity uint64
+	Price       uint64
+}
+
+// ProductTier is a tier of the product in one of the price lists matching the caller
+type ProductTier struct {
+	ProductID   string
+	PriceListID string
+	CurrencyID  uint32
+	// CurrencyCode and CurrencyExponent are read only, they come from the currency of the list
+	CurrencyCode     string
+	CurrencyExponent uint8
+	Tier
+}
@@ -0,0 +1,353 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type priceListDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewPriceListDAOPostgres(client PostgreSQLClient) PriceListDAO {
+	return &priceListDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme           = "public"
+	table            = "price_list"
+	tableScheme      = scheme + "." + table
+	entryTableScheme = scheme + ".price_list_entry"
+
+	currencyTableScheme = scheme + ".currency"
+	productTableScheme  = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+var errProductNotFound = errors.NotFound("product not found")
+
+func (s *priceListDAOPostgres) All(ctx context.Context) ([]*PriceList, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("id").
+		Columns(
+			"name",
+			"currency_id",
+			"customer_group",
+			"channel",
+			"priority",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID}).
+		OrderBy("priority DESC", "name").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*PriceList, 0)
+
+	for rows.Next() {
+		pl := PriceList{}
+		if err = rows.Scan(
+			&pl.ID,
+			&pl.Name,
+			&pl.CurrencyID,
+			&pl.CustomerGroup,
+			&pl.Channel,
+			&pl.Priority,
+			&pl.CreatedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &pl)
+	}
+
+	return list, nil
+}
+
+func (s *priceListDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(tableScheme).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// Delete removes the price list, its entries go with it by the foreign key
+func (s *priceListDAOPostgres) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Delete(tableScheme).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("price list not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// SetTiers replaces the quantity breaks of the product in the price list in a single transaction
+func (s *priceListDAOPostgres) SetTiers(ctx context.Context, priceListID, productID string, tiers []*Tier) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":         entryTableScheme,
+		"price_list_id": priceListID,
+		"product_id":    productID,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Select("id").
+			From(tableScheme).
+			Where(sq.Eq{"id": priceListID, tenantColumn: tenantID}).
+			Suffix("FOR UPDATE").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var id string
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		// the product has to belong to the tenant and stay until the tiers are written
+		sql, args, buildErr = s.queryBuilder.
+			Select("id").
+			From(productTableScheme).
+			Where(sq.Eq{"id": productID, tenantColumn: tenantID}).
+			Suffix("FOR SHARE").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				err = errProductNotFound
+			} else {
+				err = db.ErrDoQuery(err)
+			}
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Delete(entryTableScheme).
+			Where(sq.Eq{"price_list_id": priceListID, "product_id": productID}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		if len(tiers) == 0 {
+			return nil
+		}
+
+		insert := s.queryBuilder.
+			Insert(entryTableScheme).
+			Columns("price_list_id", "product_id", "min_quantity", "price")
+		for _, tier := range tiers {
+			insert = insert.Values(priceListID, productID, tier.MinQuantity, tier.Price)
+		}
+
+		sql, args, buildErr = insert.ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Tiers returns the tiers of the products in every price list matching the caller.
+// Lists assigned to both the group and the channel come first, then the ones
+// assigned to one of them, then the default lists; ties are broken by priority.
+func (s *priceListDAOPostgres) Tiers(
+	ctx context.Context,
+	customerGroup, channel string,
+	productIDs []string,
+) ([]*ProductTier, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("e.product_id").
+		Columns(
+			"pl.id",
+			"pl.currency_id",
+			"c.code",
+			"c.exponent",
+			"e.min_quantity",
+			"e.price",
+		).
+		From(entryTableScheme+" e").
+		Join(tableScheme+" pl ON pl.id = e.price_list_id").
+		Join(currencyTableScheme+" c ON c.id = pl.currency_id").
+		Where(sq.Eq{"pl." + tenantColumn: tenantID, "e.product_id": productIDs}).
+		Where(sq.Or{sq.Eq{"pl.customer_group": nil}, sq.Eq{"pl.customer_group": customerGroup}}).
+		Where(sq.Or{sq.Eq{"pl.channel": nil}, sq.Eq{"pl.channel": channel}}).
+		OrderBy(
+			"(pl.customer_group IS NOT NULL)::int * 2 + (pl.channel IS NOT NULL)::int DESC",
+			"pl.priority DESC",
+			"pl.created_at",
+			"pl.id",
+			"e.min_quantity",
+		).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": entryTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*ProductTier, 0)
+
+	for rows.Next() {
+		t := ProductTier{}
+		if err = rows.Scan(
+			&t.ProductID,
+			&t.PriceListID,
+			&t.CurrencyID,
+			&t.CurrencyCode,
+			&t.CurrencyExponent,
+			&t.MinQuantity,
+			&t.Price,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &t)
+	}
+
+	return list, nil
+}
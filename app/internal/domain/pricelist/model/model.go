@@ -0,0 +1,145 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+)
+
+// PriceList holds prices for the customers of a group buying through a channel.
+// A list without group or channel applies to every group or channel.
+type PriceList struct {
+	ID            string
+	Name          string
+	CurrencyID    uint32
+	CustomerGroup *string
+	Channel       *string
+	Priority      int32
+	CreatedAt     time.Time
+}
+
+// Tier is a quantity break, Price applies from MinQuantity units on
+type Tier struct {
+	MinQuantity uint64
+	Price       uint64
+}
+
+// Resolved is the price list picked for a product and the caller
+type Resolved struct {
+	PriceListID string
+	CurrencyID  uint32
+	Currency    money.Currency
+	Tiers       []*Tier
+}
+
+func NewPriceListFromPB(req *pbProducts.CreatePriceListRequest) *PriceList {
+	return &PriceList{
+		ID:            uuid.New().String(),
+		Name:          req.GetName(),
+		CurrencyID:    req.GetCurrencyId(),
+		CustomerGroup: req.CustomerGroup,
+		Channel:       req.Channel,
+		Priority:      req.GetPriority(),
+		CreatedAt:     time.Now(),
+	}
+}
+
+func NewTiersFromPB(tiersPB []*pbProducts.PriceTier) []*Tier {
+	tiers := make([]*Tier, len(tiersPB))
+	for i, t := range tiersPB {
+		tiers[i] = &Tier{
+			MinQuantity: t.GetMinQuantity(),
+			Price:       t.GetPrice(),
+		}
+	}
+	return tiers
+}
+
+// PriceFor returns the price of the largest quantity break the quantity reaches
+func (r *Resolved) PriceFor(quantity uint64) (uint64, bool) {
+	var best *Tier
+	for _, t := range r.Tiers {
+		if t.MinQuantity <= quantity && (best == nil || t.MinQuantity > best.MinQuantity) {
+			best = t
+		}
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.Price, true
+}
+
+func (pl *PriceList) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":             pl.ID,
+		"name":           pl.Name,
+		"currency_id":    pl.CurrencyID,
+		"customer_group": pl.CustomerGroup,
+		"channel":        pl.Channel,
+		"priority":       pl.Priority,
+		"created_at":     pl.CreatedAt,
+	}
+}
+
+func (pl *PriceList) ToProto() *pbProducts.PriceList {
+	return &pbProducts.PriceList{
+		Id:            pl.ID,
+		Name:          pl.Name,
+		CurrencyId:    pl.CurrencyID,
+		CustomerGroup: pl.CustomerGroup,
+		Channel:       pl.Channel,
+		Priority:      pl.Priority,
+		CreatedAt:     pl.CreatedAt.UnixMilli(),
+	}
+}
+
+func (t *Tier) ToProto() *pbProducts.PriceTier {
+	return &pbProducts.PriceTier{
+		MinQuantity: t.MinQuantity,
+		Price:       t.Price,
+	}
+}
+
+func NewPriceListFromDAO(pl *dao.PriceList) *PriceList {
+	var group, channel *string
+	if pl.CustomerGroup.Valid {
+		group = &pl.CustomerGroup.String
+	}
+	if pl.Channel.Valid {
+		channel = &pl.Channel.String
+	}
+
+	return &PriceList{
+		ID:            pl.ID,
+		Name:          pl.Name,
+		CurrencyID:    pl.CurrencyID,
+		CustomerGroup: group,
+		Channel:       channel,
+		Priority:      pl.Priority,
+		CreatedAt:     pl.CreatedAt.Time,
+	}
+}
+
+// NewResolvedFromDAO keeps for every product only the tiers of its first, i.e. best matching, list
+func NewResolvedFromDAO(tiers []*dao.ProductTier) map[string]*Resolved {
+	resolved := make(map[string]*Resolved)
+	for _, t := range tiers {
+		r, ok := resolved[t.ProductID]
+		if !ok {
+			r = &Resolved{
+				PriceListID: t.PriceListID,
+				CurrencyID:  t.CurrencyID,
+				Currency:    money.Currency{Code: t.CurrencyCode, Exponent: t.CurrencyExponent},
+			}
+			resolved[t.ProductID] = r
+		}
+		if r.PriceListID != t.PriceListID {
+			continue
+		}
+		r.Tiers = append(r.Tiers, &Tier{MinQuantity: t.MinQuantity, Price: t.Price})
+	}
+	return resolved
+}
@@ -0,0 +1,104 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	apiKeyModel "github.com/ilkinabd/goods-manager/app/internal/domain/apikey/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type PriceListPolicy struct {
+	priceListService *service.PriceListService
+	adminRoleID      uint64
+}
+
+func NewPriceListPolicy(priceListService *service.PriceListService, adminRoleID uint64) *PriceListPolicy {
+	return &PriceListPolicy{
+		priceListService: priceListService,
+		adminRoleID:      adminRoleID,
+	}
+}
+
+// CustomerGroup returns the customer group to resolve prices for. The group of the token
+// owner wins, the group asked for is honoured for admins and integrations only, e.g. a shop
+// backend pricing for its B2B customers. Everyone else gets the prices without a group.
+func (p *PriceListPolicy) CustomerGroup(ctx context.Context, requested string) string {
+	if group, ok := jwt.GetCustomerGroup(ctx); ok {
+		return group
+	}
+	if jwt.HasRole(ctx, p.adminRoleID) ||
+		jwt.HasScope(ctx, apiKeyModel.ScopeProductsRead) ||
+		jwt.HasScope(ctx, apiKeyModel.ScopeProductsWrite) {
+		return requested
+	}
+	return ""
+}
+
+func (p *PriceListPolicy) All(ctx context.Context) ([]*model.PriceList, error) {
+	lists, err := p.priceListService.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "priceListService.All")
+	}
+
+	return lists, nil
+}
+
+func (p *PriceListPolicy) Create(ctx context.Context, priceList *model.PriceList) (*model.PriceList, error) {
+	var violations errors.FieldViolations
+	if priceList.Name == "" {
+		violations.Add("name", "must not be empty")
+	}
+	if priceList.CurrencyID == 0 {
+		violations.Add("currency_id", "must be set")
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.priceListService.Create(ctx, priceList)
+}
+
+func (p *PriceListPolicy) Delete(ctx context.Context, id string) error {
+	return p.priceListService.Delete(ctx, id)
+}
+
+// SetTiers requires a break for a single unit, so a listed product always has a price in the list currency
+func (p *PriceListPolicy) SetTiers(ctx context.Context, priceListID, productID string, tiers []*model.Tier) error {
+	var violations errors.FieldViolations
+	seen := make(map[uint64]bool, len(tiers))
+	hasSingleUnit := false
+	for i, t := range tiers {
+		field := fmt.Sprintf("tiers[%d]", i)
+		if t.MinQuantity == 0 {
+			violations.Add(field+".min_quantity", "must be greater than 0")
+		}
+		if seen[t.MinQuantity] {
+			violations.Add(field+".min_quantity", "must be unique")
+		}
+		if t.Price == 0 {
+			violations.Add(field+".price", "must be greater than 0")
+		}
+		seen[t.MinQuantity] = true
+		hasSingleUnit = hasSingleUnit || t.MinQuantity == 1
+	}
+	if len(tiers) != 0 && !hasSingleUnit {
+		violations.Add("tiers", "must contain a break with min_quantity 1")
+	}
+	if err := violations.Err(); err != nil {
+		return err
+	}
+
+	return p.priceListService.SetTiers(ctx, priceListID, productID, tiers)
+}
+
+func (p *PriceListPolicy) Resolve(
+	ctx context.Context,
+	customerGroup, channel string,
+	productIDs []string,
+) (map[string]*model.Resolved, error) {
+	return p.priceListService.Resolve(ctx, customerGroup, channel, productIDs)
+}
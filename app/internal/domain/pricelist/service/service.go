@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/pricelist/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type PriceListService struct {
+	repository dao.PriceListDAO
+}
+
+func NewPriceListService(repository dao.PriceListDAO) *PriceListService {
+	return &PriceListService{repository: repository}
+}
+
+func (s *PriceListService) All(ctx context.Context) ([]*model.PriceList, error) {
+	dbLists, err := s.repository.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.All")
+	}
+
+	lists := make([]*model.PriceList, 0, len(dbLists))
+	for _, dbL := range dbLists {
+		lists = append(lists, model.NewPriceListFromDAO(dbL))
+	}
+
+	return lists, nil
+}
+
+func (s *PriceListService) Create(ctx context.Context, priceList *model.PriceList) (*model.PriceList, error) {
+	if err := s.repository.Create(ctx, priceList.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+func (s *PriceListService) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *PriceListService) SetTiers(ctx context.Context, priceListID, productID string, tiers []*model.Tier) error {
+	tiersDAO := make([]*dao.Tier, len(tiers))
+	for i, t := range tiers {
+		tiersDAO[i] = &dao.Tier{MinQuantity: t.MinQuantity, Price: t.Price}
+	}
+
+	return s.repository.SetTiers(ctx, priceListID, productID, tiersDAO)
+}
+
+// Resolve picks a price list for every product which has one for the caller
+func (s *PriceListService) Resolve(
+	ctx context.Context,
+	customerGroup, channel string,
+	productIDs []string,
+) (map[string]*model.Resolved, error) {
+	if len(productIDs) == 0 {
+		return map[string]*model.Resolved{}, nil
+	}
+
+	tiers, err := s.repository.Tiers(ctx, customerGroup, channel, productIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Tiers")
+	}
+
+	return model.NewResolvedFromDAO(tiers), nil
+}
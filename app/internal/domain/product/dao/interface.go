@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/filter"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
@@ -17,10 +18,20 @@ type PostgreSQLClient interface {
 	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 }
 
+// Within is work done in the transaction of a product write once the product row is
+// written, an error of it rolls the write back
+type Within func(ctx context.Context, tx pgx.Tx) error
+
 type ProductDAO interface {
 	All(context.Context, []filter.Criteria, filter.Sortable) ([]*Product, error)
 	One(context.Context, string) (*Product, error)
-	Create(context.Context, map[string]interface{}) error
-	Update(context.Context, string, map[string]interface{}) error
+	Create(context.Context, map[string]interface{}, Within) error
+	Update(context.Context, string, map[string]interface{}, Within) error
 	Delete(context.Context, string) error
+	OptionAxes(context.Context, string) ([]*OptionAxis, error)
+	SetOptionAxes(context.Context, string, []*OptionAxis) error
+	Transition(ctx context.Context, id, from, to string) error
+	SchedulePublication(ctx context.Context, id string, publishAt, unpublishAt *time.Time) error
+	ApplyScheduledStatus(context.Context) (int64, error)
+	BrandFacets(context.Context, []filter.Criteria) ([]*BrandFacet, error)
 }
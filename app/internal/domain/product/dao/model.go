@@ -5,15 +5,58 @@ import (
 )
 
 type Product struct {
-	ID            string
-	Name          string
-	Description   string
-	ImageID       sql.NullString
-	Price         uint64
-	CurrencyID    uint32
-	Rating        uint32
+	ID          string
+	Name        string
+	Description string
+	ImageID     sql.NullString
+	// Price is in minor units of the currency, e.g. cents for EUR and yen for JPY
+	Price int64
+	// BasePrice is read only, the open-ended price of the timeline without the running sale
+	BasePrice  int64
+	CurrencyID uint32
+	// CurrencyCode and CurrencyExponent are read only, they come from the currency
+	CurrencyCode     sql.NullString
+	CurrencyExponent sql.NullInt16
+	// Rating is the average score of the approved reviews, read only like ReviewCount
+	Rating        float64
 	CategoryID    uint32
+	BrandID       sql.NullString
 	Specification map[string]interface{}
-	CreatedAt     sql.NullString
-	UpdatedAt     sql.NullString
+	ParentID      sql.NullString
+	SKU           sql.NullString
+	Options       map[string]string
+	// measures are kept in grams and centimeters along with the units they were given in
+	WeightGrams   sql.NullFloat64
+	WeightUnit    sql.NullString
+	LengthCm      sql.NullFloat64
+	WidthCm       sql.NullFloat64
+	HeightCm      sql.NullFloat64
+	DimensionUnit sql.NullString
+	ShippingClass sql.NullString
+	// EffectiveShippingClass is read only, the class of the product or else of its category
+	EffectiveShippingClass sql.NullString
+	TaxClassID             sql.NullString
+	// EffectiveTaxClassID is read only, the tax class of the product or else of its category
+	EffectiveTaxClassID sql.NullString
+	Status              string
+	PublishAt           sql.NullTime
+	UnpublishAt         sql.NullTime
+	// AvailableQuantity is read only, summed up over all warehouses
+	AvailableQuantity uint64
+	ReviewCount       uint64
+	CreatedAt         sql.NullString
+	UpdatedAt         sql.NullString
+}
+
+// BrandFacet is a brand with the number of products matching a filter
+type BrandFacet struct {
+	BrandID      string
+	Name         string
+	ProductCount uint64
+}
+
+type OptionAxis struct {
+	Name     string
+	Values   []string
+	Position int
 }
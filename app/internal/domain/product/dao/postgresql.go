@@ -3,11 +3,16 @@ package dao
 import (
 	"context"
 	filter2 "github.com/ilkinabd/goods-manager/app/internal/domain/product/filter"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	outboxDao "github.com/ilkinabd/goods-manager/app/internal/domain/outbox/dao"
 	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
 	"github.com/ilkinabd/goods-manager/app/pkg/errors"
 	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
 )
 
 type productDAOPostgres struct {
@@ -26,24 +31,152 @@ const (
 	scheme      = "public"
 	table       = "product"
 	tableScheme = scheme + "." + table
+
+	optionTableScheme = scheme + ".product_option"
+	brandTableScheme  = scheme + ".brand"
+	priceTableScheme  = scheme + ".product_price"
+
+	tenantColumn = "tenant_id"
+
+	availableQuantityColumn = "COALESCE((SELECT SUM(s.on_hand - s.reserved) FROM " + scheme + ".inventory_stock s " +
+		"WHERE s.product_id = " + table + ".id), 0)::bigint AS available_quantity"
+
+	// the average of the approved reviews, sorting by rating sorts by this output column
+	ratingColumn = "COALESCE((SELECT rs.score_sum::float8 / NULLIF(rs.review_count, 0) FROM " + scheme +
+		".product_review_summary rs WHERE rs.product_id = " + table + ".id), 0) AS rating"
+
+	reviewCountColumn = "COALESCE((SELECT rs.review_count FROM " + scheme + ".product_review_summary rs " +
+		"WHERE rs.product_id = " + table + ".id), 0)::bigint AS review_count"
+
+	effectiveShippingClassColumn = "COALESCE(" + table + ".shipping_class, (SELECT c.shipping_class FROM " + scheme +
+		".category c WHERE c.id = " + table + ".category_id)) AS effective_shipping_class"
+
+	// categories are shared, their tax classes are set per tenant
+	effectiveTaxClassColumn = "COALESCE(" + table + ".tax_class_id, (SELECT ct.tax_class_id FROM " + scheme +
+		".category_tax_class ct WHERE ct.tenant_id = " + table + ".tenant_id " +
+		"AND ct.category_id = " + table + ".category_id)) AS effective_tax_class_id"
+
+	currencyCodeColumn = "(SELECT cur.code FROM " + scheme + ".currency cur " +
+		"WHERE cur.id = " + table + ".currency_id) AS currency_code"
+	currencyExponentColumn = "(SELECT cur.exponent FROM " + scheme + ".currency cur " +
+		"WHERE cur.id = " + table + ".currency_id) AS currency_exponent"
+
+	// the price in effect right now, product.price is only refreshed by the price scheduler.
+	// A running sale wins over the open-ended entries, so a new base price does not end it.
+	effectivePriceColumn = "COALESCE((SELECT pp.price FROM " + priceTableScheme + " pp " +
+		"WHERE pp.tenant_id = " + table + ".tenant_id AND pp.product_id = " + table + ".id " +
+		"AND pp.effective_from <= NOW() AND (pp.effective_to IS NULL OR pp.effective_to > NOW()) " +
+		"ORDER BY pp.effective_to IS NULL, pp.effective_from DESC, pp.created_at DESC LIMIT 1), " +
+		table + ".price) AS price"
+
+	// the open-ended entry in effect, the price an update changes
+	basePriceColumn = "COALESCE((SELECT pp.price FROM " + priceTableScheme + " pp " +
+		"WHERE pp.tenant_id = " + table + ".tenant_id AND pp.product_id = " + table + ".id " +
+		"AND pp.effective_from <= NOW() AND pp.effective_to IS NULL " +
+		"ORDER BY pp.effective_from DESC, pp.created_at DESC LIMIT 1), " +
+		table + ".price) AS base_price"
 )
 
-func (s *productDAOPostgres) All(ctx context.Context, filtering []filter2.Criteria, sorting filter2.Sortable) ([]*Product, error) {
-	query := s.queryBuilder.
+// tenantScope returns the where-clause every query has to be restricted by.
+// Queries without a tenant in context are refused.
+func tenantScope(ctx context.Context) (sq.Eq, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sq.Eq{tenantColumn: tenantID}, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (s *productDAOPostgres) selectQuery() sq.SelectBuilder {
+	return s.queryBuilder.
 		Select("id").
 		Columns(
 			"name",
 			"description",
 			"image_id",
-			"price",
+			effectivePriceColumn,
+			basePriceColumn,
 			"currency_id",
-			"rating",
+			currencyCodeColumn,
+			currencyExponentColumn,
+			ratingColumn,
 			"category_id",
+			"brand_id",
 			"specification",
+			"parent_id",
+			"sku",
+			"options",
+			"weight_grams",
+			"weight_unit",
+			"length_cm",
+			"width_cm",
+			"height_cm",
+			"dimension_unit",
+			"shipping_class",
+			effectiveShippingClassColumn,
+			"tax_class_id",
+			effectiveTaxClassColumn,
+			"status",
+			"publish_at",
+			"unpublish_at",
+			availableQuantityColumn,
+			reviewCountColumn,
 			"created_at",
 			"updated_at",
 		).
 		From(tableScheme)
+}
+
+func scanProduct(row scanner, ps *Product) error {
+	return row.Scan(
+		&ps.ID,
+		&ps.Name,
+		&ps.Description,
+		&ps.ImageID,
+		&ps.Price,
+		&ps.BasePrice,
+		&ps.CurrencyID,
+		&ps.CurrencyCode,
+		&ps.CurrencyExponent,
+		&ps.Rating,
+		&ps.CategoryID,
+		&ps.BrandID,
+		&ps.Specification,
+		&ps.ParentID,
+		&ps.SKU,
+		&ps.Options,
+		&ps.WeightGrams,
+		&ps.WeightUnit,
+		&ps.LengthCm,
+		&ps.WidthCm,
+		&ps.HeightCm,
+		&ps.DimensionUnit,
+		&ps.ShippingClass,
+		&ps.EffectiveShippingClass,
+		&ps.TaxClassID,
+		&ps.EffectiveTaxClassID,
+		&ps.Status,
+		&ps.PublishAt,
+		&ps.UnpublishAt,
+		&ps.AvailableQuantity,
+		&ps.ReviewCount,
+		&ps.CreatedAt,
+		&ps.UpdatedAt,
+	)
+}
+
+func (s *productDAOPostgres) All(ctx context.Context, filtering []filter2.Criteria, sorting filter2.Sortable) ([]*Product, error) {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.selectQuery().
+		Where(scope)
 
 	for _, filter := range filtering {
 		query = filter.MeetCriteria(query)
@@ -76,19 +209,7 @@ func (s *productDAOPostgres) All(ctx context.Context, filtering []filter2.Criter
 
 	for rows.Next() {
 		ps := Product{}
-		if err = rows.Scan(
-			&ps.ID,
-			&ps.Name,
-			&ps.Description,
-			&ps.ImageID,
-			&ps.Price,
-			&ps.CurrencyID,
-			&ps.Rating,
-			&ps.CategoryID,
-			&ps.Specification,
-			&ps.CreatedAt,
-			&ps.UpdatedAt,
-		); err != nil {
+		if err = scanProduct(rows, &ps); err != nil {
 			err = db.ErrScan(err)
 			logger.Error(err)
 			return nil, err
@@ -100,10 +221,17 @@ func (s *productDAOPostgres) All(ctx context.Context, filtering []filter2.Criter
 	return list, nil
 }
 
-func (s *productDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+func (s *productDAOPostgres) Create(ctx context.Context, m map[string]interface{}, within Within) error {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = scope[tenantColumn]
+
 	sql, args, buildErr := s.queryBuilder.
 		Insert(tableScheme).
 		SetMap(m).
+		Suffix(outboxDao.Returning(table)).
 		PlaceholderFormat(sq.Dollar).ToSql()
 
 	logger := logging.WithFields(ctx, map[string]interface{}{
@@ -117,11 +245,10 @@ func (s *productDAOPostgres) Create(ctx context.Context, m map[string]interface{
 		return buildErr
 	}
 
-	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
-		execErr = db.ErrDoQuery(execErr)
+	if created, execErr := s.recordPriced(ctx, outboxDao.ProductCreated, m["id"], m["price"], within, sql, args...); execErr != nil {
 		logger.Error(execErr)
 		return execErr
-	} else if exec.RowsAffected() == 0 || !exec.Insert() {
+	} else if created == 0 {
 		execErr = db.ErrDoQuery(errors.New("product was not created. 0 rows were affected"))
 		logger.Error(execErr)
 		return execErr
@@ -130,23 +257,88 @@ func (s *productDAOPostgres) Create(ctx context.Context, m map[string]interface{
 	return nil
 }
 
+// record runs the mutation in a transaction with writing its events to the outbox,
+// the number of products changed is returned
+func (s *productDAOPostgres) record(ctx context.Context, eventType, sql string, args ...interface{}) (int64, error) {
+	var changed int64
+	err := s.client.BeginFunc(ctx, func(tx pgx.Tx) (err error) {
+		changed, err = outboxDao.Record(ctx, tx, eventType, sql, args...)
+		return err
+	})
+	return changed, err
+}
+
+// recordPriced is record for mutations which may set the base price of a product. A base price
+// set starts an open-ended entry of the price timeline in the same transaction, the mutation
+// is scoped to the tenant, so the entry is written only for a product of the tenant.
+// The work within runs last in the transaction, for a product the mutation changed only.
+func (s *productDAOPostgres) recordPriced(
+	ctx context.Context,
+	eventType string,
+	id, price interface{},
+	within Within,
+	sql string,
+	args ...interface{},
+) (int64, error) {
+	var changed int64
+	err := s.client.BeginFunc(ctx, func(tx pgx.Tx) (err error) {
+		changed, err = outboxDao.Record(ctx, tx, eventType, sql, args...)
+		if err != nil || changed == 0 {
+			return err
+		}
+
+		if price != nil {
+			if err = s.recordBasePrice(ctx, tx, id, price); err != nil {
+				return err
+			}
+		}
+
+		if within == nil {
+			return nil
+		}
+		return within(ctx, tx)
+	})
+	return changed, err
+}
+
+func (s *productDAOPostgres) recordBasePrice(ctx context.Context, tx pgx.Tx, id, price interface{}) error {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Insert(priceTableScheme).
+		SetMap(map[string]interface{}{
+			"id":             uuid.New().String(),
+			tenantColumn:     scope[tenantColumn],
+			"product_id":     id,
+			"price":          price,
+			"effective_from": sq.Expr("NOW()"),
+			"created_at":     sq.Expr("NOW()"),
+		}).
+		ToSql()
+	if err != nil {
+		return db.ErrCreateQuery(err)
+	}
+
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	return nil
+}
+
 func (s *productDAOPostgres) One(ctx context.Context, id string) (*Product, error) {
-	sql, args, buildErr := s.queryBuilder.
-		Select("id").
-		Columns(
-			"name",
-			"description",
-			"image_id",
-			"price",
-			"currency_id",
-			"rating",
-			"category_id",
-			"specification",
-			"created_at",
-			"updated_at",
-		).
-		From(tableScheme).
-		Where(sq.Eq{"id": id}).ToSql()
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, buildErr := s.selectQuery().
+		Where(sq.Eq{"id": id}).
+		Where(scope).
+		ToSql()
 
 	logger := logging.WithFields(ctx, map[string]interface{}{
 		"sql":   sql,
@@ -161,19 +353,7 @@ func (s *productDAOPostgres) One(ctx context.Context, id string) (*Product, erro
 
 	var ps Product
 
-	err := s.client.QueryRow(ctx, sql, args...).Scan(
-		&ps.ID,
-		&ps.Name,
-		&ps.Description,
-		&ps.ImageID,
-		&ps.Price,
-		&ps.CurrencyID,
-		&ps.Rating,
-		&ps.CategoryID,
-		&ps.Specification,
-		&ps.CreatedAt,
-		&ps.UpdatedAt,
-	)
+	err = scanProduct(s.client.QueryRow(ctx, sql, args...), &ps)
 	if err != nil {
 		err = db.ErrDoQuery(err)
 		logger.Error(err)
@@ -183,11 +363,28 @@ func (s *productDAOPostgres) One(ctx context.Context, id string) (*Product, erro
 	return &ps, nil
 }
 
-func (s *productDAOPostgres) Update(ctx context.Context, id string, m map[string]interface{}) error {
+func (s *productDAOPostgres) Update(ctx context.Context, id string, m map[string]interface{}, within Within) error {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	// a product can not be moved to another tenant
+	delete(m, tenantColumn)
+
+	// the product is locked before the update reads it, so the category it leaves is the one
+	// committed last and watchers of that category learn the product moved out
+	previous := sq.Select("id", "category_id").
+		From(tableScheme).
+		Where(sq.Eq{"id": id}).
+		Where(scope).
+		Suffix("FOR UPDATE")
+
 	sql, args, buildErr := s.queryBuilder.
 		Update(tableScheme).
+		PrefixExpr(sq.Expr("WITH previous AS (?)", previous)).
 		SetMap(m).
-		Where(sq.Eq{"id": id}).
+		Where("id IN (SELECT id FROM previous)").
+		Suffix(outboxDao.ReturningMoved(table, "(SELECT category_id FROM previous)")).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
@@ -202,12 +399,11 @@ func (s *productDAOPostgres) Update(ctx context.Context, id string, m map[string
 		return buildErr
 	}
 
-	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
-		execErr = db.ErrDoQuery(execErr)
+	if updated, execErr := s.recordPriced(ctx, outboxDao.ProductUpdated, id, m["price"], within, sql, args...); execErr != nil {
 		logger.Error(execErr)
 		return execErr
-	} else if exec.RowsAffected() == 0 || !exec.Update() {
-		execErr = db.ErrDoQuery(errors.New("product was not updated. 0 rows were affected"))
+	} else if updated == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("product not found"))
 		logger.Error(execErr)
 		return execErr
 	}
@@ -216,9 +412,230 @@ func (s *productDAOPostgres) Update(ctx context.Context, id string, m map[string
 }
 
 func (s *productDAOPostgres) Delete(ctx context.Context, id string) error {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
 	sql, args, buildErr := s.queryBuilder.
 		Delete(tableScheme).
+		// variants go together with their parent
+		Where(sq.Or{sq.Eq{"id": id}, sq.Eq{"parent_id": id}}).
+		Where(scope).
+		Suffix(outboxDao.Returning(table)).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if deleted, execErr := s.record(ctx, outboxDao.ProductDeleted, sql, args...); execErr != nil {
+		logger.Error(execErr)
+		return execErr
+	} else if deleted == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("product not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *productDAOPostgres) OptionAxes(ctx context.Context, productID string) ([]*OptionAxis, error) {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// nested queries keep the question placeholders, the outer query numbers them
+	tenantProducts := sq.Select("id").From(tableScheme).Where(scope)
+
+	sql, args, err := s.queryBuilder.
+		Select("name").
+		Columns(
+			"option_values",
+			"position",
+		).
+		From(optionTableScheme).
+		Where(sq.Eq{"product_id": productID}).
+		Where(sq.Expr("product_id IN (?)", tenantProducts)).
+		OrderBy("position").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": optionTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	axes := make([]*OptionAxis, 0)
+
+	for rows.Next() {
+		axis := OptionAxis{}
+		if err = rows.Scan(&axis.Name, &axis.Values, &axis.Position); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		axes = append(axes, &axis)
+	}
+
+	return axes, nil
+}
+
+// SetOptionAxes replaces option axes of the product in a single transaction
+func (s *productDAOPostgres) SetOptionAxes(ctx context.Context, productID string, axes []*OptionAxis) error {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":      optionTableScheme,
+		"product_id": productID,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Select("id").
+			From(tableScheme).
+			Where(sq.Eq{"id": productID}).
+			Where(scope).
+			Suffix("FOR UPDATE").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var id string
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Delete(optionTableScheme).
+			Where(sq.Eq{"product_id": productID}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		if len(axes) == 0 {
+			return nil
+		}
+
+		insert := s.queryBuilder.
+			Insert(optionTableScheme).
+			Columns("product_id", "name", "option_values", "position")
+		for _, axis := range axes {
+			insert = insert.Values(productID, axis.Name, axis.Values, axis.Position)
+		}
+
+		sql, args, buildErr = insert.ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Transition moves the product from one status to another. Nothing is changed when
+// the product is no longer in the from status, e.g. the scheduler got there first.
+func (s *productDAOPostgres) Transition(ctx context.Context, id, from, to string) error {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Update(tableScheme).
+		Set("status", to).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id, "status": from}).
+		Where(scope).
+		Suffix(outboxDao.Returning(table)).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if changed, execErr := s.record(ctx, outboxDao.ProductUpdated, sql, args...); execErr != nil {
+		logger.Error(execErr)
+		return execErr
+	} else if changed == 0 {
+		execErr = db.ErrDoQuery(errors.Conflict("product status has changed"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// SchedulePublication sets or, with nil, clears the moments the product is to be published and unpublished
+func (s *productDAOPostgres) SchedulePublication(ctx context.Context, id string, publishAt, unpublishAt *time.Time) error {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Update(tableScheme).
+		Set("publish_at", publishAt).
+		Set("unpublish_at", unpublishAt).
 		Where(sq.Eq{"id": id}).
+		Where(scope).
+		Suffix(outboxDao.Returning(table)).
 		ToSql()
 
 	logger := logging.WithFields(ctx, map[string]interface{}{
@@ -232,15 +649,110 @@ func (s *productDAOPostgres) Delete(ctx context.Context, id string) error {
 		return buildErr
 	}
 
-	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
-		execErr = db.ErrDoQuery(execErr)
+	if scheduled, execErr := s.record(ctx, outboxDao.ProductUpdated, sql, args...); execErr != nil {
 		logger.Error(execErr)
 		return execErr
-	} else if exec.RowsAffected() == 0 || !exec.Delete() {
-		execErr = db.ErrDoQuery(errors.New("product was not deleted. 0 rows were affected"))
+	} else if scheduled == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("product not found"))
 		logger.Error(execErr)
 		return execErr
 	}
 
 	return nil
 }
+
+// ApplyScheduledStatus publishes reviewed products and archives published ones whose time has come
+func (s *productDAOPostgres) ApplyScheduledStatus(ctx context.Context) (int64, error) {
+	var (
+		publishSQL = `UPDATE ` + tableScheme + `
+SET status = 'published', publish_at = NULL, updated_at = NOW()
+WHERE publish_at <= NOW() AND status = 'in_review'
+` + outboxDao.Returning(table)
+		unpublishSQL = `UPDATE ` + tableScheme + `
+SET status = 'archived', unpublish_at = NULL, updated_at = NOW()
+WHERE unpublish_at <= NOW() AND status = 'published'
+` + outboxDao.Returning(table)
+	)
+
+	var changed int64
+	err := s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// publishing goes first, so a product with both moments overdue ends up archived
+		for _, sql := range []string{publishSQL, unpublishSQL} {
+			n, err := outboxDao.Record(ctx, tx, outboxDao.ProductUpdated, sql)
+			if err != nil {
+				return err
+			}
+			changed += n
+		}
+		return nil
+	})
+	if err != nil {
+		logging.WithError(ctx, err).WithField("table", tableScheme).Error("failed to apply scheduled statuses")
+		return 0, err
+	}
+
+	return changed, nil
+}
+
+// BrandFacets counts the products meeting the criteria per brand, most frequent brands first.
+// Products without a brand are not counted.
+func (s *productDAOPostgres) BrandFacets(ctx context.Context, filtering []filter2.Criteria) ([]*BrandFacet, error) {
+	scope, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products := s.queryBuilder.
+		Select("brand_id").
+		From(tableScheme).
+		Where(scope).
+		Where(sq.NotEq{"brand_id": nil})
+
+	for _, filter := range filtering {
+		products = filter.MeetCriteria(products)
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("b.id").
+		Columns("b.name", "COUNT(*)").
+		FromSelect(products, "p").
+		Join(brandTableScheme+" b ON b.id = p.brand_id").
+		GroupBy("b.id", "b.name").
+		OrderBy("COUNT(*) DESC", "b.name").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*BrandFacet, 0)
+
+	for rows.Next() {
+		f := BrandFacet{}
+		if err = rows.Scan(&f.BrandID, &f.Name, &f.ProductCount); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &f)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,230 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/filter"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ownTenant     = "tenant-a"
+	foreignTenant = "tenant-b"
+)
+
+// statement is a query the DAO sent to the database
+type statement struct {
+	sql  string
+	args []interface{}
+}
+
+// recordingClient records the statements instead of running them. No row is ever found,
+// so every lookup a change depends on fails like it does for a product of another tenant.
+type recordingClient struct {
+	statements []statement
+}
+
+func (c *recordingClient) record(sql string, args []interface{}) {
+	c.statements = append(c.statements, statement{sql: sql, args: args})
+}
+
+func (c *recordingClient) Begin(context.Context) (pgx.Tx, error) {
+	return &recordingTx{client: c}, nil
+}
+
+func (c *recordingClient) BeginFunc(_ context.Context, f func(pgx.Tx) error) error {
+	return f(&recordingTx{client: c})
+}
+
+func (c *recordingClient) BeginTxFunc(_ context.Context, _ pgx.TxOptions, f func(pgx.Tx) error) error {
+	return f(&recordingTx{client: c})
+}
+
+func (c *recordingClient) Query(_ context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	c.record(sql, args)
+	return &emptyRows{}, nil
+}
+
+func (c *recordingClient) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	c.record(sql, args)
+	return noRow{}
+}
+
+func (c *recordingClient) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	c.record(sql, args)
+	return pgconn.CommandTag("UPDATE 0"), nil
+}
+
+type recordingTx struct {
+	pgx.Tx
+	client *recordingClient
+}
+
+func (t *recordingTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return t.client.Query(ctx, sql, args...)
+}
+
+func (t *recordingTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return t.client.QueryRow(ctx, sql, args...)
+}
+
+func (t *recordingTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return t.client.Exec(ctx, sql, args...)
+}
+
+type emptyRows struct {
+	pgx.Rows
+}
+
+func (r *emptyRows) Next() bool { return false }
+func (r *emptyRows) Err() error { return nil }
+func (r *emptyRows) Close()     {}
+
+type noRow struct{}
+
+func (noRow) Scan(...interface{}) error { return pgx.ErrNoRows }
+
+// tenantCalls are the calls of every tenant scoped DAO method. ApplyScheduledStatus is
+// left out, the lifecycle scheduler applies the schedules of all tenants at once.
+var tenantCalls = map[string]func(context.Context, ProductDAO) error{
+	"All": func(ctx context.Context, d ProductDAO) error {
+		_, err := d.All(ctx, []filter.Criteria{filter.NewIDCriteria([]string{"p1"})}, filter.NewSort("", ""))
+		return err
+	},
+	"One": func(ctx context.Context, d ProductDAO) error {
+		_, err := d.One(ctx, "p1")
+		return err
+	},
+	"Create": func(ctx context.Context, d ProductDAO) error {
+		return d.Create(ctx, map[string]interface{}{"name": "p", tenantColumn: foreignTenant}, nil)
+	},
+	"Update": func(ctx context.Context, d ProductDAO) error {
+		return d.Update(ctx, "p1", map[string]interface{}{"name": "p", tenantColumn: foreignTenant}, nil)
+	},
+	"Delete": func(ctx context.Context, d ProductDAO) error {
+		return d.Delete(ctx, "p1")
+	},
+	"OptionAxes": func(ctx context.Context, d ProductDAO) error {
+		_, err := d.OptionAxes(ctx, "p1")
+		return err
+	},
+	"SetOptionAxes": func(ctx context.Context, d ProductDAO) error {
+		return d.SetOptionAxes(ctx, "p1", []*OptionAxis{{Name: "size", Values: []string{"S"}}})
+	},
+	"Transition": func(ctx context.Context, d ProductDAO) error {
+		return d.Transition(ctx, "p1", "draft", "in_review")
+	},
+	"SchedulePublication": func(ctx context.Context, d ProductDAO) error {
+		at := time.Now()
+		return d.SchedulePublication(ctx, "p1", &at, nil)
+	},
+	"BrandFacets": func(ctx context.Context, d ProductDAO) error {
+		_, err := d.BrandFacets(ctx, nil)
+		return err
+	},
+}
+
+var (
+	scopePlaceholder = regexp.MustCompile(`(?:^|[\s(])tenant_id = \$(\d+)`)
+	insertColumns    = regexp.MustCompile(`^INSERT INTO ` + regexp.QuoteMeta(tableScheme) + ` \(([^)]*)\)`)
+)
+
+// tenantOf returns the tenant the statement is restricted to or stamps its row with
+func tenantOf(s statement) (string, bool) {
+	if m := insertColumns.FindStringSubmatch(s.sql); m != nil {
+		for i, column := range strings.Split(m[1], ",") {
+			if strings.TrimSpace(column) == tenantColumn {
+				tenantID, ok := s.args[i].(string)
+				return tenantID, ok
+			}
+		}
+		return "", false
+	}
+
+	m := scopePlaceholder.FindStringSubmatch(s.sql)
+	if m == nil {
+		return "", false
+	}
+	n, _ := strconv.Atoi(m[1])
+	if n < 1 || n > len(s.args) {
+		return "", false
+	}
+	tenantID, ok := s.args[n-1].(string)
+	return tenantID, ok
+}
+
+func TestProductDAOScopesStatementsByTenant(t *testing.T) {
+	for name, call := range tenantCalls {
+		t.Run(name, func(t *testing.T) {
+			client := &recordingClient{}
+			_ = call(tenant.ContextWithTenant(context.Background(), ownTenant), NewProductDAOPostgres(client))
+
+			if len(client.statements) == 0 {
+				t.Fatal("no statement was run")
+			}
+
+			for _, s := range client.statements {
+				if !strings.Contains(s.sql, tableScheme+" ") && !strings.HasSuffix(s.sql, tableScheme) {
+					t.Errorf("statement does not go through the product table: %s", s.sql)
+					continue
+				}
+
+				tenantID, ok := tenantOf(s)
+				if !ok {
+					t.Errorf("statement is not scoped by tenant: %s", s.sql)
+					continue
+				}
+				if tenantID != ownTenant {
+					t.Errorf("statement is scoped to tenant %q instead of %q: %s", tenantID, ownTenant, s.sql)
+				}
+
+				for _, arg := range s.args {
+					if arg == foreignTenant {
+						t.Errorf("statement carries the tenant of the caller's input: %s %v", s.sql, s.args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestProductDAOLeavesOptionsOfOtherTenantsAlone(t *testing.T) {
+	client := &recordingClient{}
+	d := NewProductDAOPostgres(client)
+
+	// the product is not found in the tenant of the caller
+	err := d.SetOptionAxes(tenant.ContextWithTenant(context.Background(), ownTenant), "p1", []*OptionAxis{{Name: "size"}})
+	if err == nil {
+		t.Fatal("options of a product out of the tenant were replaced")
+	}
+
+	for _, s := range client.statements {
+		if strings.Contains(s.sql, optionTableScheme) {
+			t.Errorf("options were changed without the product being found in the tenant: %s", s.sql)
+		}
+	}
+}
+
+func TestProductDAORefusesCallsWithoutTenant(t *testing.T) {
+	for name, call := range tenantCalls {
+		t.Run(name, func(t *testing.T) {
+			client := &recordingClient{}
+
+			err := call(context.Background(), NewProductDAOPostgres(client))
+			if !errors.Is(err, tenant.ErrNoTenant) {
+				t.Errorf("got error %v, want %v", err, tenant.ErrNoTenant)
+			}
+			if len(client.statements) != 0 {
+				t.Errorf("statements were run without a tenant: %v", client.statements)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	pbProduct "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+const brandFieldName = "brand_id"
+
+type brandCriteria struct {
+	ids []string
+}
+
+// NewBrandCriteriaFromPB keeps products of any of the brands
+func NewBrandCriteriaFromPB(product *pbProduct.AllProductsRequest) Criteria {
+	return brandCriteria{ids: product.GetBrandIds()}
+}
+
+func (c brandCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	if len(c.ids) == 0 {
+		return query
+	}
+	return query.Where(sq.Eq{brandFieldName: c.ids})
+}
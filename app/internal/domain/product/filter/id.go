@@ -0,0 +1,20 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+const idFieldName = "id"
+
+type idCriteria struct {
+	ids []string
+}
+
+// NewIDCriteria selects the products with the given ids
+func NewIDCriteria(ids []string) Criteria {
+	return idCriteria{ids: ids}
+}
+
+func (c idCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(sq.Eq{idFieldName: c.ids})
+}
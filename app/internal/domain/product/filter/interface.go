@@ -32,6 +32,13 @@ func NewSortFromPB(product *pbProduct.AllProductsRequest) Sortable {
 	}
 }
 
+func NewSort(field, order string) Sortable {
+	return sort{
+		field: field,
+		order: order,
+	}
+}
+
 func (s sort) Sort(query sq.SelectBuilder) sq.SelectBuilder {
 	if s.field == "" || s.order == "" {
 		return query
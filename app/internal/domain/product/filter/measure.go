@@ -0,0 +1,96 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	pbProduct "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/measure"
+)
+
+type rangeCriteria struct {
+	column string
+	min    *float64
+	max    *float64
+}
+
+// NewMeasureCriteriaFromPB keeps products with the weight and package dimensions in the ranges.
+// Bounds are converted from the units of the ranges, grams and centimeters by default.
+func NewMeasureCriteriaFromPB(product *pbProduct.AllProductsRequest) ([]Criteria, error) {
+	var violations errors.FieldViolations
+	criteria := make([]Criteria, 0, 4)
+
+	weight, err := newRange("weight_grams", product.GetWeight(), func(v float64, unit string) (float64, error) {
+		if unit == "" {
+			unit = string(measure.Gram)
+		}
+		return measure.ToGrams(v, measure.WeightUnit(unit))
+	})
+	if err != nil {
+		violations.Add("weight.unit", err.Error())
+	}
+	criteria = append(criteria, weight)
+
+	dimensions := []struct {
+		field  string
+		column string
+		bounds *pbProduct.MeasureRange
+	}{
+		{"length", "length_cm", product.GetLength()},
+		{"width", "width_cm", product.GetWidth()},
+		{"height", "height_cm", product.GetHeight()},
+	}
+	for _, d := range dimensions {
+		c, err := newRange(d.column, d.bounds, func(v float64, unit string) (float64, error) {
+			if unit == "" {
+				unit = string(measure.Centimeter)
+			}
+			return measure.ToCentimeters(v, measure.LengthUnit(unit))
+		})
+		if err != nil {
+			violations.Add(d.field+".unit", err.Error())
+		}
+		criteria = append(criteria, c)
+	}
+
+	if err = violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return criteria, nil
+}
+
+func newRange(
+	column string,
+	r *pbProduct.MeasureRange,
+	convert func(v float64, unit string) (float64, error),
+) (rangeCriteria, error) {
+	c := rangeCriteria{column: column}
+	if r == nil {
+		return c, nil
+	}
+	if r.Min != nil {
+		v, err := convert(r.GetMin(), r.GetUnit())
+		if err != nil {
+			return c, err
+		}
+		c.min = &v
+	}
+	if r.Max != nil {
+		v, err := convert(r.GetMax(), r.GetUnit())
+		if err != nil {
+			return c, err
+		}
+		c.max = &v
+	}
+	return c, nil
+}
+
+func (c rangeCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	if c.min != nil {
+		query = query.Where(sq.GtOrEq{c.column: *c.min})
+	}
+	if c.max != nil {
+		query = query.Where(sq.LtOrEq{c.column: *c.max})
+	}
+	return query
+}
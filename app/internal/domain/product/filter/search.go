@@ -0,0 +1,40 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	pbProduct "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+type searchCriteria struct {
+	query      string
+	locale     string
+	textConfig string
+}
+
+// NewSearchCriteriaFromPB matches products by full text search over the base content
+// and the translation to the locale, both analysed with the text search configuration of the locale
+func NewSearchCriteriaFromPB(product *pbProduct.AllProductsRequest, locale, textConfig string) Criteria {
+	return searchCriteria{
+		query:      product.GetSearch(),
+		locale:     locale,
+		textConfig: textConfig,
+	}
+}
+
+func (c searchCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	if c.query == "" {
+		return query
+	}
+
+	return query.Where(sq.Or{
+		sq.Expr(
+			"to_tsvector(?::regconfig, product.name || ' ' || product.description) @@ plainto_tsquery(?::regconfig, ?)",
+			c.textConfig, c.textConfig, c.query,
+		),
+		sq.Expr(
+			"EXISTS (SELECT 1 FROM public.product_translation t WHERE t.product_id = product.id AND t.locale = ? "+
+				"AND to_tsvector(?::regconfig, t.name || ' ' || t.description) @@ plainto_tsquery(?::regconfig, ?))",
+			c.locale, c.textConfig, c.textConfig, c.query,
+		),
+	})
+}
@@ -0,0 +1,28 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	pbProduct "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+const statusFieldName = "status"
+
+type statusCriteria struct {
+	status string
+}
+
+// NewStatusCriteriaFromPB keeps products in the requested lifecycle status
+func NewStatusCriteriaFromPB(product *pbProduct.AllProductsRequest) Criteria {
+	return statusCriteria{status: product.GetStatus()}
+}
+
+func NewStatusCriteria(status string) Criteria {
+	return statusCriteria{status: status}
+}
+
+func (c statusCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	if c.status != "" {
+		query = query.Where(sq.Eq{statusFieldName: c.status})
+	}
+	return query
+}
@@ -0,0 +1,22 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	pbProduct "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+type inStockCriteria struct {
+	enabled bool
+}
+
+// NewInStockCriteriaFromPB keeps products with available quantity in any warehouse
+func NewInStockCriteriaFromPB(product *pbProduct.AllProductsRequest) Criteria {
+	return inStockCriteria{enabled: product.GetInStockOnly()}
+}
+
+func (c inStockCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	if c.enabled {
+		query = query.Where("EXISTS (SELECT 1 FROM public.inventory_stock s WHERE s.product_id = product.id AND s.on_hand > s.reserved)")
+	}
+	return query
+}
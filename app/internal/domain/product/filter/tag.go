@@ -0,0 +1,59 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	pbProduct "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+const (
+	tagTableScheme     = "public.tag"
+	productTagScheme   = "public.product_tag"
+	taggedProductField = "pt.product_id"
+)
+
+type tagCriteria struct {
+	anyOf  []string
+	allOf  []string
+	noneOf []string
+}
+
+// NewTagCriteriaFromPB keeps products labelled with any of, all of and none of the tag slugs
+func NewTagCriteriaFromPB(product *pbProduct.AllProductsRequest) Criteria {
+	return tagCriteria{
+		anyOf:  product.GetTags().GetAnyOf(),
+		allOf:  product.GetTags().GetAllOf(),
+		noneOf: product.GetTags().GetNoneOf(),
+	}
+}
+
+// tagged selects ids of products labelled with one of the slugs
+func tagged(slugs []string) sq.SelectBuilder {
+	return sq.Select(taggedProductField).
+		From(productTagScheme + " pt").
+		Join(tagTableScheme + " t ON t.id = pt.tag_id").
+		Where(sq.Eq{"t.slug": slugs})
+}
+
+func (c tagCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	if len(c.anyOf) != 0 {
+		query = query.Where(sq.Expr("id IN (?)", tagged(c.anyOf)))
+	}
+	if len(c.allOf) != 0 {
+		allOf := tagged(c.allOf).
+			GroupBy(taggedProductField).
+			Having("COUNT(DISTINCT t.slug) = ?", len(unique(c.allOf)))
+		query = query.Where(sq.Expr("id IN (?)", allOf))
+	}
+	if len(c.noneOf) != 0 {
+		query = query.Where(sq.Expr("id NOT IN (?)", tagged(c.noneOf)))
+	}
+	return query
+}
+
+func unique(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,37 @@
+package filter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	pbProduct "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+)
+
+const parentFieldName = "parent_id"
+
+type parentsOnlyCriteria struct {
+	enabled bool
+}
+
+type parentCriteria struct {
+	parentIDs []string
+}
+
+// NewCollapseVariantsCriteriaFromPB hides variants from the list when the request asks to collapse them
+func NewCollapseVariantsCriteriaFromPB(product *pbProduct.AllProductsRequest) Criteria {
+	return parentsOnlyCriteria{enabled: product.GetCollapseVariants()}
+}
+
+// NewParentCriteria selects variants of the given parents
+func NewParentCriteria(parentIDs []string) Criteria {
+	return parentCriteria{parentIDs: parentIDs}
+}
+
+func (c parentsOnlyCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	if c.enabled {
+		query = query.Where(sq.Eq{parentFieldName: nil})
+	}
+	return query
+}
+
+func (c parentCriteria) MeetCriteria(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(sq.Eq{parentFieldName: c.parentIDs})
+}
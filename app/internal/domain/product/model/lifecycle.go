@@ -0,0 +1,35 @@
+package model
+
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// transitions lists the statuses a product can move to from each status.
+// Unpublishing archives a product, an archived product can be reworked as a draft.
+var transitions = map[string][]string{
+	StatusDraft:     {StatusInReview, StatusArchived},
+	StatusInReview:  {StatusDraft, StatusPublished},
+	StatusPublished: {StatusArchived},
+	StatusArchived:  {StatusDraft},
+}
+
+func IsStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+func CanTransition(from, to string) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Product) IsPublished() bool {
+	return p.Status == StatusPublished
+}
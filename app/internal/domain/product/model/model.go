@@ -5,9 +5,14 @@ import (
 	"github.com/google/uuid"
 	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/barcode"
 	"github.com/ilkinabd/goods-manager/app/pkg/errors"
 	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/measure"
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+	"github.com/ilkinabd/goods-manager/app/pkg/shipping"
 	"github.com/mitchellh/mapstructure"
+	"math"
 	"time"
 )
 
@@ -16,13 +21,100 @@ type Product struct {
 	Name          string                 `mapstructure:"name"`
 	Description   string                 `mapstructure:"description"`
 	ImageID       *string                `mapstructure:"image_id"`
-	Price         uint64                 `mapstructure:"price"`
+	Price         money.Money            `mapstructure:"-"`
 	CurrencyID    uint32                 `mapstructure:"currency_id"`
-	Rating        uint32                 `mapstructure:"rating"`
 	CategoryID    uint32                 `mapstructure:"category_id"`
+	BrandID       *string                `mapstructure:"brand_id"`
 	Specification map[string]interface{} `mapstructure:"specification"`
+	ParentID      *string                `mapstructure:"parent_id"`
+	SKU           *string                `mapstructure:"sku"`
+	Options       map[string]string      `mapstructure:"options"`
+	ShippingClass *string                `mapstructure:"shipping_class"`
+	TaxClassID    *string                `mapstructure:"tax_class_id"`
 	CreatedAt     time.Time              `mapstructure:"created_at"`
 	UpdatedAt     *time.Time             `mapstructure:"updated_at"`
+
+	OptionAxes        []*OptionAxis `mapstructure:"-"`
+	Variants          []*Product    `mapstructure:"-"`
+	AvailableQuantity uint64        `mapstructure:"-"`
+	DiscountedPrice   *money.Money  `mapstructure:"-"`
+	PriceListID       *string       `mapstructure:"-"`
+	PriceTiers        []*PriceTier  `mapstructure:"-"`
+	// Locale the content was localized to, empty for the base content
+	Locale              string            `mapstructure:"-"`
+	SpecificationLabels map[string]string `mapstructure:"-"`
+	Slug                string            `mapstructure:"-"`
+	// Rating is the rounded average score of the approved reviews, it changes through reviews only
+	Rating        uint32              `mapstructure:"-"`
+	RatingAverage float64             `mapstructure:"-"`
+	ReviewCount   uint64              `mapstructure:"-"`
+	Tags          []*Tag              `mapstructure:"-"`
+	Weight        *measure.Weight     `mapstructure:"-"`
+	Dimensions    *measure.Dimensions `mapstructure:"-"`
+	// EffectiveShippingClass is the class of the product, of its parent or of its category
+	EffectiveShippingClass string `mapstructure:"-"`
+	// EffectiveTaxClassID is the tax class of the product, of its parent or of its category
+	EffectiveTaxClassID *string `mapstructure:"-"`
+	// Tax is the price breakdown in the region the product was read for
+	Tax *TaxBreakdown `mapstructure:"-"`
+	// Barcodes are kept apart from the product row, they change with the product though
+	Barcodes []*Barcode `mapstructure:"-"`
+	// Status and the publication schedule change through transitions only
+	Status      string     `mapstructure:"-"`
+	PublishAt   *time.Time `mapstructure:"-"`
+	UnpublishAt *time.Time `mapstructure:"-"`
+
+	// basePrice is the open-ended price of the timeline, Price may be the one of a running sale
+	basePrice        int64
+	basePriceChanged bool
+	nameChanged      bool
+	barcodesChanged  bool
+	// inputViolations are found reading the request, they are reported along with validation
+	inputViolations errors.FieldViolations
+}
+
+// TaxBreakdown splits the price the product sells at in a region into net, tax and gross
+type TaxBreakdown struct {
+	Region           string
+	TaxClassID       *string
+	BasisPoints      uint32
+	PricesIncludeTax bool
+	Net              money.Money
+	Tax              money.Money
+	Gross            money.Money
+}
+
+// PriceTier is a quantity break of the price list the product was priced with
+type PriceTier struct {
+	MinQuantity uint64
+	Price       money.Money
+}
+
+// Tag is a label the product is assigned
+type Tag struct {
+	ID   string
+	Name string
+	Slug string
+}
+
+// BrandFacet is a brand with the number of products matching a filter
+type BrandFacet struct {
+	BrandID      string
+	Name         string
+	ProductCount uint64
+}
+
+// Barcode is a code the product is scanned by, Type is empty for an invalid code
+type Barcode struct {
+	Code string
+	Type string
+	GTIN string
+}
+
+// OptionAxis is a dimension variants of a parent product differ in, e.g. size or colour
+type OptionAxis struct {
+	Name   string
+	Values []string
 }
 
 func (p *Product) ToMap() (map[string]interface{}, error) {
@@ -31,12 +123,111 @@ func (p *Product) ToMap() (map[string]interface{}, error) {
 	if err != nil {
 		return updateProductMap, errors.Wrap(err, "mapstructure.Decode(product)")
 	}
+	// mapstructure turns time.Time into an empty map
+	updateProductMap["created_at"] = p.CreatedAt
+	// the price is kept in minor units, its currency is the one of currency_id. It is written only
+	// when a new base price was set, the price read may be the one of a running sale.
+	if p.basePriceChanged {
+		updateProductMap["price"] = p.basePrice
+	}
+
+	// measures are kept in grams and centimeters along with the units they were given in
+	updateProductMap["weight_grams"] = nil
+	updateProductMap["weight_unit"] = nil
+	if p.Weight != nil {
+		updateProductMap["weight_grams"] = p.Weight.Grams
+		updateProductMap["weight_unit"] = string(p.Weight.Unit)
+	}
+	updateProductMap["length_cm"] = nil
+	updateProductMap["width_cm"] = nil
+	updateProductMap["height_cm"] = nil
+	updateProductMap["dimension_unit"] = nil
+	if p.Dimensions != nil {
+		updateProductMap["length_cm"] = p.Dimensions.Length
+		updateProductMap["width_cm"] = p.Dimensions.Width
+		updateProductMap["height_cm"] = p.Dimensions.Height
+		updateProductMap["dimension_unit"] = string(p.Dimensions.Unit)
+	}
 
 	return updateProductMap, nil
 }
 
+// BarcodesChanged reports whether an update replaced the barcodes
+func (p *Product) BarcodesChanged() bool {
+	return p.barcodesChanged
+}
+
+// BarcodeCodes returns the codes of the barcodes in their order
+func (p *Product) BarcodeCodes() []string {
+	codes := make([]string, len(p.Barcodes))
+	for i, b := range p.Barcodes {
+		codes[i] = b.Code
+	}
+	return codes
+}
+
+// NameChanged reports whether an update renamed the product, which gives it a new slug
+func (p *Product) NameChanged() bool {
+	return p.nameChanged
+}
+
+func (p *Product) IsVariant() bool {
+	return p.ParentID != nil
+}
+
+// ApplyParent fills the fields a variant inherits from its parent and
+// merges the parent specification with the variant overrides.
+func (p *Product) ApplyParent(parent *Product) {
+	if p.Name == "" {
+		p.Name = parent.Name
+	}
+	if p.Description == "" {
+		p.Description = parent.Description
+	}
+	if p.ImageID == nil {
+		p.ImageID = parent.ImageID
+	}
+	p.CurrencyID = parent.CurrencyID
+	p.Price.Currency = parent.Price.Currency
+	p.CategoryID = parent.CategoryID
+	p.BrandID = parent.BrandID
+	if p.Weight == nil {
+		p.Weight = parent.Weight
+	}
+	if p.Dimensions == nil {
+		p.Dimensions = parent.Dimensions
+	}
+	if p.ShippingClass == nil {
+		p.EffectiveShippingClass = parent.EffectiveShippingClass
+	}
+	if p.TaxClassID == nil {
+		p.EffectiveTaxClassID = parent.EffectiveTaxClassID
+	}
+	p.Specification = p.EffectiveSpecification(parent)
+}
+
+// EffectiveSpecification returns the parent specification with the variant overrides applied
+func (p *Product) EffectiveSpecification(parent *Product) map[string]interface{} {
+	spec := make(map[string]interface{}, len(parent.Specification)+len(p.Specification))
+	for k, v := range parent.Specification {
+		spec[k] = v
+	}
+	for k, v := range p.Specification {
+		spec[k] = v
+	}
+	return spec
+}
+
+// InputViolations returns the fields of the request the product could not be read from
+func (p *Product) InputViolations() errors.FieldViolations {
+	return append(errors.FieldViolations(nil), p.inputViolations...)
+}
+
+// UpdateFromPB applies the fields set in the request. Fields which can not be read are
+// left as they are and reported by InputViolations.
 func (p *Product) UpdateFromPB(productPB *pbProducts.UpdateProductRequest) {
 	if productPB.Name != nil {
+		p.nameChanged = p.Name != productPB.GetName()
 		p.Name = productPB.GetName()
 	}
 	if productPB.Description != nil {
@@ -46,18 +237,67 @@ func (p *Product) UpdateFromPB(productPB *pbProducts.UpdateProductRequest) {
 		p.ImageID = productPB.ImageId
 	}
 	if productPB.Price != nil {
-		p.Price = productPB.GetPrice()
-	}
-	if productPB.CurrencyId != nil {
-		p.CurrencyID = productPB.GetCurrencyId()
+		if price, ok := newPriceFromPB(productPB.GetPrice(), p.Price.Currency, &p.inputViolations); ok {
+			p.basePriceChanged = p.basePrice != price.Amount
+			p.basePrice = price.Amount
+			p.Price = price
+		}
 	}
-	if productPB.Rating != nil {
-		p.Rating = productPB.GetRating()
+	if productPB.CurrencyId != nil && productPB.GetCurrencyId() != p.CurrencyID {
+		// the price is kept in minor units of the currency, it would mean another
+		// amount in a currency of another exponent
+		if productPB.Price == nil {
+			p.inputViolations.Add("currency_id", "can only be changed together with the price")
+		} else {
+			p.CurrencyID = productPB.GetCurrencyId()
+		}
 	}
 	if productPB.CategoryId != nil {
 		p.CategoryID = productPB.GetCategoryId()
 	}
-
+	if productPB.BrandId != nil {
+		p.BrandID = productPB.BrandId
+		if productPB.GetBrandId() == "" {
+			p.BrandID = nil
+		}
+	}
+	if productPB.Sku != nil {
+		p.SKU = productPB.Sku
+	}
+	if len(productPB.GetOptions()) != 0 {
+		p.Options = productPB.GetOptions()
+	}
+	if productPB.Weight != nil {
+		if weight, ok := newWeightFromPB(productPB.GetWeight(), &p.inputViolations); ok {
+			p.Weight = weight
+		}
+	}
+	if productPB.Dimensions != nil {
+		if dimensions, ok := newDimensionsFromPB(productPB.GetDimensions(), &p.inputViolations); ok {
+			p.Dimensions = dimensions
+		}
+	}
+	if productPB.ShippingClass != nil {
+		p.ShippingClass = productPB.ShippingClass
+		if productPB.GetShippingClass() == "" {
+			p.ShippingClass = nil
+		}
+	}
+	if productPB.TaxClassId != nil {
+		p.TaxClassID = productPB.TaxClassId
+		if productPB.GetTaxClassId() == "" {
+			p.TaxClassID = nil
+		}
+	}
+	if len(productPB.GetBarcodes()) != 0 {
+		p.barcodesChanged = true
+		p.Barcodes = NewBarcodesFromPB(productPB.GetBarcodes())
+	}
+	if productPB.Specification != nil {
+		if spec, ok := parseSpecificationFromPB(productPB.GetSpecification(), &p.inputViolations); ok {
+			p.Specification = spec
+		}
+	}
 }
 
 func (p *Product) ToProto() *pbProducts.Product {
@@ -72,51 +312,271 @@ func (p *Product) ToProto() *pbProducts.Product {
 		logging.GetLogger().Trace(p.Specification)
 	}
 
+	variants := make([]*pbProducts.Product, len(p.Variants))
+	for i, v := range p.Variants {
+		variants[i] = v.ToProto()
+	}
+
+	optionAxes := make([]*pbProducts.OptionAxis, len(p.OptionAxes))
+	for i, axis := range p.OptionAxes {
+		optionAxes[i] = &pbProducts.OptionAxis{
+			Name:   axis.Name,
+			Values: axis.Values,
+		}
+	}
+
+	priceTiers := make([]*pbProducts.PriceTier, len(p.PriceTiers))
+	for i, t := range p.PriceTiers {
+		priceTiers[i] = &pbProducts.PriceTier{
+			MinQuantity: t.MinQuantity,
+			Price:       t.Price.Minor(),
+		}
+	}
+
+	tags := make([]*pbProducts.Tag, len(p.Tags))
+	for i, t := range p.Tags {
+		tags[i] = &pbProducts.Tag{
+			Id:   t.ID,
+			Name: t.Name,
+			Slug: t.Slug,
+		}
+	}
+
+	barcodes := make([]*pbProducts.Barcode, len(p.Barcodes))
+	for i, b := range p.Barcodes {
+		barcodes[i] = &pbProducts.Barcode{
+			Code: b.Code,
+			Type: b.Type,
+			Gtin: b.GTIN,
+		}
+	}
+
+	var weight, volumetricWeight *pbProducts.Weight
+	if p.Weight != nil {
+		weight = &pbProducts.Weight{
+			Value: p.Weight.Value(),
+			Unit:  string(p.Weight.Unit),
+		}
+	}
+	var dimensions *pbProducts.Dimensions
+	if p.Dimensions != nil {
+		length, width, height := p.Dimensions.In(p.Dimensions.Unit)
+		dimensions = &pbProducts.Dimensions{
+			Length: length,
+			Width:  width,
+			Height: height,
+			Unit:   string(p.Dimensions.Unit),
+		}
+
+		unit := measure.Kilogram
+		if p.Weight != nil {
+			unit = p.Weight.Unit
+		}
+		volumetric := shipping.VolumetricWeight(*p.Dimensions, unit)
+		volumetricWeight = &pbProducts.Weight{
+			Value: volumetric.Value(),
+			Unit:  string(volumetric.Unit),
+		}
+	}
+
+	var tax *pbProducts.ProductTax
+	if p.Tax != nil {
+		tax = &pbProducts.ProductTax{
+			Region:           p.Tax.Region,
+			TaxClassId:       p.Tax.TaxClassID,
+			BasisPoints:      p.Tax.BasisPoints,
+			PricesIncludeTax: p.Tax.PricesIncludeTax,
+			Net:              newMoneyProto(p.Tax.Net, p.Locale),
+			Tax:              newMoneyProto(p.Tax.Tax, p.Locale),
+			Gross:            newMoneyProto(p.Tax.Gross, p.Locale),
+		}
+	}
+
+	var discountedPrice *uint64
+	var discountedPriceMoney *pbProducts.Money
+	if p.DiscountedPrice != nil {
+		minor := p.DiscountedPrice.Minor()
+		discountedPrice = &minor
+		discountedPriceMoney = newMoneyProto(*p.DiscountedPrice, p.Locale)
+	}
+
+	var publishAt, unpublishAt *int64
+	if p.PublishAt != nil {
+		ms := p.PublishAt.UnixMilli()
+		publishAt = &ms
+	}
+	if p.UnpublishAt != nil {
+		ms := p.UnpublishAt.UnixMilli()
+		unpublishAt = &ms
+	}
+
 	return &pbProducts.Product{
-		Id:            p.ID,
-		Name:          p.Name,
-		Description:   p.Description,
-		ImageId:       p.ImageID,
-		Price:         p.Price,
-		CurrencyId:    p.CurrencyID,
-		Rating:        p.Rating,
-		CategoryId:    p.CategoryID,
-		Specification: string(specBytes),
-		UpdatedAt:     updatedAt,
-		CreatedAt:     p.CreatedAt.UnixMilli(),
-	}
-}
-
-func NewProductFromPB(productPB *pbProducts.CreateProductRequest) (*Product, error) {
-	spec, err := parseSpecificationFromPB(productPB.Specification)
-	if err != nil {
-		return nil, err
+		Id:               p.ID,
+		Name:             p.Name,
+		Description:      p.Description,
+		ImageId:          p.ImageID,
+		Price:            p.Price.Minor(),
+		PriceMoney:       newMoneyProto(p.Price, p.Locale),
+		CurrencyId:       p.CurrencyID,
+		Rating:           p.Rating,
+		RatingAverage:    p.RatingAverage,
+		ReviewCount:      p.ReviewCount,
+		Tags:             tags,
+		Barcodes:         barcodes,
+		CategoryId:       p.CategoryID,
+		BrandId:          p.BrandID,
+		Specification:    string(specBytes),
+		ParentId:         p.ParentID,
+		Sku:              p.SKU,
+		Options:          p.Options,
+		Weight:           weight,
+		Dimensions:       dimensions,
+		VolumetricWeight: volumetricWeight,
+		ShippingClass:    p.EffectiveShippingClass,
+		// a product without a class of its own ships in the class of its parent or category
+		ShippingClassInherited: p.ShippingClass == nil,
+		TaxClassId:             p.EffectiveTaxClassID,
+		TaxClassInherited:      p.TaxClassID == nil,
+		Tax:                    tax,
+		OptionAxes:             optionAxes,
+		Variants:               variants,
+		AvailableQuantity:      p.AvailableQuantity,
+		DiscountedPrice:        discountedPrice,
+		DiscountedPriceMoney:   discountedPriceMoney,
+		PriceListId:            p.PriceListID,
+		PriceTiers:             priceTiers,
+		Locale:                 p.Locale,
+		SpecificationLabels:    p.SpecificationLabels,
+		Slug:                   p.Slug,
+		Status:                 p.Status,
+		PublishAt:              publishAt,
+		UnpublishAt:            unpublishAt,
+		UpdatedAt:              updatedAt,
+		CreatedAt:              p.CreatedAt.UnixMilli(),
 	}
+}
+
+// NewProductFromPB reads the product of the request. Fields which can not be read
+// are left empty and reported by InputViolations.
+func NewProductFromPB(productPB *pbProducts.CreateProductRequest) *Product {
+	var violations errors.FieldViolations
+
+	spec, _ := parseSpecificationFromPB(productPB.Specification, &violations)
+	weight, _ := newWeightFromPB(productPB.GetWeight(), &violations)
+	dimensions, _ := newDimensionsFromPB(productPB.GetDimensions(), &violations)
+	// the currency is known by its id only, it is resolved when the product is stored
+	price, _ := newPriceFromPB(productPB.GetPrice(), money.Currency{}, &violations)
 
 	return &Product{
 		ID:            uuid.New().String(),
 		Name:          productPB.GetName(),
 		Description:   productPB.GetDescription(),
 		ImageID:       productPB.ImageId,
-		Price:         productPB.GetPrice(),
+		Price:         price,
 		CurrencyID:    productPB.GetCurrencyId(),
-		Rating:        productPB.GetRating(),
 		CategoryID:    productPB.GetCategoryId(),
+		BrandID:       productPB.BrandId,
 		Specification: spec,
+		ParentID:      productPB.ParentId,
+		SKU:           productPB.Sku,
+		Options:       productPB.GetOptions(),
+		Barcodes:      NewBarcodesFromPB(productPB.GetBarcodes()),
+		Weight:        weight,
+		Dimensions:    dimensions,
+		ShippingClass: productPB.ShippingClass,
+		TaxClassID:    productPB.TaxClassId,
+		Status:        StatusDraft,
 		CreatedAt:     time.Now(),
-	}, nil
+
+		basePrice:        price.Amount,
+		basePriceChanged: true,
+		inputViolations:  violations,
+	}
+}
+
+// NewBarcodesFromPB detects the type of every code, invalid codes are left for validation
+func NewBarcodesFromPB(codes []string) []*Barcode {
+	barcodes := make([]*Barcode, len(codes))
+	for i, code := range codes {
+		barcodes[i] = &Barcode{Code: code}
+		if b, err := barcode.Parse(code); err == nil {
+			barcodes[i].Code = b.Code
+			barcodes[i].Type = string(b.Type)
+			barcodes[i].GTIN = b.GTIN
+		}
+	}
+	return barcodes
+}
+
+// newPriceFromPB takes the price in minor units of the currency, e.g. cents for EUR
+func newPriceFromPB(amount uint64, currency money.Currency, violations *errors.FieldViolations) (money.Money, bool) {
+	price, err := money.FromMinor(amount, currency)
+	if err != nil {
+		violations.Add("price", err.Error())
+		return money.Money{Currency: currency}, false
+	}
+
+	return price, true
+}
+
+// newMoneyProto writes the amount along with its currency and formats it for the locale
+func newMoneyProto(m money.Money, locale string) *pbProducts.Money {
+	return &pbProducts.Money{
+		Amount:       m.Amount,
+		CurrencyCode: m.Currency.Code,
+		Exponent:     uint32(m.Currency.Exponent),
+		Formatted:    m.Format(locale),
+	}
+}
+
+// newWeightFromPB converts the weight to grams, no weight or a zero one means the weight is unknown
+func newWeightFromPB(weightPB *pbProducts.Weight, violations *errors.FieldViolations) (*measure.Weight, bool) {
+	if weightPB.GetValue() == 0 {
+		return nil, true
+	}
+
+	weight, err := measure.NewWeight(weightPB.GetValue(), measure.WeightUnit(weightPB.GetUnit()))
+	if err != nil {
+		violations.Add("weight.unit", err.Error())
+		return nil, false
+	}
+
+	return &weight, true
 }
 
-func parseSpecificationFromPB(specFromPB string) (spec map[string]interface{}, unmarshalErr error) {
-	if specFromPB != "" {
-		return nil, errors.New("specification is empty")
+// newDimensionsFromPB converts the dimensions to centimeters, all zero dimensions mean they are unknown
+func newDimensionsFromPB(dimensionsPB *pbProducts.Dimensions, violations *errors.FieldViolations) (*measure.Dimensions, bool) {
+	if dimensionsPB.GetLength() == 0 && dimensionsPB.GetWidth() == 0 && dimensionsPB.GetHeight() == 0 {
+		return nil, true
 	}
 
-	if unmarshalErr = json.Unmarshal([]byte(specFromPB), &spec); unmarshalErr == nil {
-		return spec, nil
+	dimensions, err := measure.NewDimensions(
+		dimensionsPB.GetLength(),
+		dimensionsPB.GetWidth(),
+		dimensionsPB.GetHeight(),
+		measure.LengthUnit(dimensionsPB.GetUnit()),
+	)
+	if err != nil {
+		violations.Add("dimensions.unit", err.Error())
+		return nil, false
 	}
 
-	return nil, errors.Wrap(unmarshalErr, "failed to parse specification")
+	return &dimensions, true
+}
+
+// parseSpecificationFromPB reads the specification, an empty one is read as no attributes
+func parseSpecificationFromPB(specFromPB string, violations *errors.FieldViolations) (map[string]interface{}, bool) {
+	spec := make(map[string]interface{})
+	if specFromPB == "" {
+		return spec, true
+	}
+
+	if err := json.Unmarshal([]byte(specFromPB), &spec); err != nil {
+		violations.Add("specification", "must be a JSON object")
+		return make(map[string]interface{}), false
+	}
+
+	return spec, true
 }
 
 func NewProductFromDAO(sp *dao.Product) *Product {
@@ -125,6 +585,17 @@ func NewProductFromDAO(sp *dao.Product) *Product {
 		imageID = &sp.ImageID.String
 	}
 
+	var parentID, sku, brandID *string
+	if sp.BrandID.Valid {
+		brandID = &sp.BrandID.String
+	}
+	if sp.ParentID.Valid {
+		parentID = &sp.ParentID.String
+	}
+	if sp.SKU.Valid {
+		sku = &sp.SKU.String
+	}
+
 	createdAt, err := time.Parse(time.RFC3339, sp.CreatedAt.String)
 	if err != nil {
 		logging.GetLogger().WithError(err).Error("time.Parse(sp.CreatedAt)")
@@ -138,17 +609,125 @@ func NewProductFromDAO(sp *dao.Product) *Product {
 		}
 	}
 
+	var weight *measure.Weight
+	if sp.WeightGrams.Valid {
+		weight = &measure.Weight{
+			Grams: sp.WeightGrams.Float64,
+			Unit:  measure.WeightUnit(sp.WeightUnit.String),
+		}
+	}
+
+	var dimensions *measure.Dimensions
+	if sp.LengthCm.Valid && sp.WidthCm.Valid && sp.HeightCm.Valid {
+		dimensions = &measure.Dimensions{
+			Length: sp.LengthCm.Float64,
+			Width:  sp.WidthCm.Float64,
+			Height: sp.HeightCm.Float64,
+			Unit:   measure.LengthUnit(sp.DimensionUnit.String),
+		}
+	}
+
+	var shippingClass *string
+	if sp.ShippingClass.Valid {
+		shippingClass = &sp.ShippingClass.String
+	}
+	effectiveShippingClass := string(shipping.Standard)
+	if sp.EffectiveShippingClass.Valid {
+		effectiveShippingClass = sp.EffectiveShippingClass.String
+	}
+
+	var taxClassID, effectiveTaxClassID *string
+	if sp.TaxClassID.Valid {
+		taxClassID = &sp.TaxClassID.String
+	}
+	if sp.EffectiveTaxClassID.Valid {
+		effectiveTaxClassID = &sp.EffectiveTaxClassID.String
+	}
+
+	// currencies without an exponent were never migrated, their prices are in hundredths
+	currency := money.Currency{Code: sp.CurrencyCode.String, Exponent: money.DefaultExponent}
+	if sp.CurrencyExponent.Valid {
+		currency.Exponent = uint8(sp.CurrencyExponent.Int16)
+	}
+
+	var publishAt, unpublishAt *time.Time
+	if sp.PublishAt.Valid {
+		publishAt = &sp.PublishAt.Time
+	}
+	if sp.UnpublishAt.Valid {
+		unpublishAt = &sp.UnpublishAt.Time
+	}
+
 	return &Product{
-		ID:            sp.ID,
-		Name:          sp.Name,
-		Description:   sp.Description,
-		ImageID:       imageID,
-		Price:         sp.Price,
-		CurrencyID:    sp.CurrencyID,
-		Rating:        sp.Rating,
-		CategoryID:    sp.CategoryID,
-		Specification: sp.Specification,
-		CreatedAt:     createdAt,
-		UpdatedAt:     &updatedAt,
+		ID:                     sp.ID,
+		Name:                   sp.Name,
+		Description:            sp.Description,
+		ImageID:                imageID,
+		Price:                  money.New(sp.Price, currency),
+		basePrice:              sp.BasePrice,
+		CurrencyID:             sp.CurrencyID,
+		Rating:                 uint32(math.Round(sp.Rating)),
+		RatingAverage:          sp.Rating,
+		ReviewCount:            sp.ReviewCount,
+		CategoryID:             sp.CategoryID,
+		BrandID:                brandID,
+		Specification:          sp.Specification,
+		ParentID:               parentID,
+		SKU:                    sku,
+		Options:                sp.Options,
+		Weight:                 weight,
+		Dimensions:             dimensions,
+		ShippingClass:          shippingClass,
+		EffectiveShippingClass: effectiveShippingClass,
+		TaxClassID:             taxClassID,
+		EffectiveTaxClassID:    effectiveTaxClassID,
+		Status:                 sp.Status,
+		PublishAt:              publishAt,
+		UnpublishAt:            unpublishAt,
+		CreatedAt:              createdAt,
+		AvailableQuantity:      sp.AvailableQuantity,
+		UpdatedAt:              &updatedAt,
+	}
+}
+
+func NewOptionAxesFromPB(axesPB []*pbProducts.OptionAxis) []*OptionAxis {
+	axes := make([]*OptionAxis, len(axesPB))
+	for i, axis := range axesPB {
+		axes[i] = &OptionAxis{
+			Name:   axis.GetName(),
+			Values: axis.GetValues(),
+		}
+	}
+	return axes
+}
+
+func NewOptionAxesFromDAO(axesDAO []*dao.OptionAxis) []*OptionAxis {
+	axes := make([]*OptionAxis, len(axesDAO))
+	for i, axis := range axesDAO {
+		axes[i] = &OptionAxis{
+			Name:   axis.Name,
+			Values: axis.Values,
+		}
+	}
+	return axes
+}
+
+func (f *BrandFacet) ToProto() *pbProducts.BrandFacet {
+	return &pbProducts.BrandFacet{
+		BrandId:      f.BrandID,
+		Name:         f.Name,
+		ProductCount: f.ProductCount,
+	}
+}
+
+func NewBrandFacetsFromDAO(facetsDAO []*dao.BrandFacet) []*BrandFacet {
+	facets := make([]*BrandFacet, len(facetsDAO))
+	for i, f := range facetsDAO {
+		facets[i] = &BrandFacet{
+			BrandID:      f.BrandID,
+			Name:         f.Name,
+			ProductCount: f.ProductCount,
+		}
 	}
+	return facets
 }
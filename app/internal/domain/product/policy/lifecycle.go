@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/filter"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+var errProductNotFound = errors.NotFound("product not found")
+
+// publishedOnly reports whether the caller may see published products only.
+// Admins and API key integrations see products in every status.
+func (p *ProductPolicy) publishedOnly(ctx context.Context) bool {
+	if _, ok := jwt.GetAPIKeyID(ctx); ok {
+		return false
+	}
+	return !jwt.HasRole(ctx, p.adminRoleID)
+}
+
+// VisibleStatuses returns the statuses of products the caller may see, nil for any status
+func (p *ProductPolicy) VisibleStatuses(ctx context.Context) []string {
+	if p.publishedOnly(ctx) {
+		return []string{model.StatusPublished}
+	}
+	return nil
+}
+
+// visibility returns the criteria hiding unpublished products from the caller, if needed
+func (p *ProductPolicy) visibility(ctx context.Context) []filter.Criteria {
+	if p.publishedOnly(ctx) {
+		return []filter.Criteria{filter.NewStatusCriteria(model.StatusPublished)}
+	}
+	return nil
+}
+
+func (p *ProductPolicy) Transition(ctx context.Context, id, to string) (*model.Product, error) {
+	if !model.IsStatus(to) {
+		var violations errors.FieldViolations
+		violations.Add("status", "must be one of draft, in_review, published, archived")
+		return nil, violations.Err()
+	}
+
+	product, err := p.productService.One(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !model.CanTransition(product.Status, to) {
+		return nil, errors.Conflict("product can not move from " + product.Status + " to " + to)
+	}
+
+	if err = p.productService.Transition(ctx, product, to); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// SchedulePublication plans publishing of a product in review and unpublishing of a
+// product which is or will be published. A nil moment clears the schedule.
+func (p *ProductPolicy) SchedulePublication(ctx context.Context, id string, publishAt, unpublishAt *time.Time) error {
+	product, err := p.productService.One(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var violations errors.FieldViolations
+	if publishAt != nil {
+		if !publishAt.After(now) {
+			violations.Add("publish_at", "must be in the future")
+		}
+		if product.Status != model.StatusInReview {
+			violations.Add("publish_at", "only products in review can be scheduled for publishing")
+		}
+	}
+	if unpublishAt != nil {
+		if !unpublishAt.After(now) {
+			violations.Add("unpublish_at", "must be in the future")
+		}
+		if publishAt != nil && !unpublishAt.After(*publishAt) {
+			violations.Add("unpublish_at", "must be after publish_at")
+		}
+		if publishAt == nil && product.Status != model.StatusPublished {
+			violations.Add("unpublish_at", "only published products can be scheduled for unpublishing")
+		}
+	}
+	if err = violations.Err(); err != nil {
+		return err
+	}
+
+	return p.productService.SchedulePublication(ctx, id, publishAt, unpublishAt)
+}
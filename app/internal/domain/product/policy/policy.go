@@ -6,19 +6,26 @@ import (
 
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/service"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/validator"
 	"github.com/ilkinabd/goods-manager/app/pkg/errors"
 )
 
 type ProductPolicy struct {
 	productService *service.ProductService
+	validator      *validator.ProductValidator
+	adminRoleID    uint64
 }
 
-func NewProductPolicy(productService *service.ProductService) *ProductPolicy {
-	return &ProductPolicy{productService: productService}
+func NewProductPolicy(productService *service.ProductService, validator *validator.ProductValidator, adminRoleID uint64) *ProductPolicy {
+	return &ProductPolicy{
+		productService: productService,
+		validator:      validator,
+		adminRoleID:    adminRoleID,
+	}
 }
 
 func (p *ProductPolicy) All(ctx context.Context, filtering []filter2.Criteria, sorting filter2.Sortable) ([]*model.Product, error) {
-	products, err := p.productService.All(ctx, filtering, sorting)
+	products, err := p.productService.All(ctx, append(filtering, p.visibility(ctx)...), sorting)
 	if err != nil {
 		return nil, errors.Wrap(err, "productService.All")
 	}
@@ -26,7 +33,21 @@ func (p *ProductPolicy) All(ctx context.Context, filtering []filter2.Criteria, s
 	return products, nil
 }
 
+// BrandFacets counts the products the caller can see meeting the criteria per brand
+func (p *ProductPolicy) BrandFacets(ctx context.Context, filtering []filter2.Criteria) ([]*model.BrandFacet, error) {
+	facets, err := p.productService.BrandFacets(ctx, append(filtering, p.visibility(ctx)...))
+	if err != nil {
+		return nil, errors.Wrap(err, "productService.BrandFacets")
+	}
+
+	return facets, nil
+}
+
 func (p *ProductPolicy) CreateProduct(ctx context.Context, product *model.Product) (*model.Product, error) {
+	if err := p.validate(ctx, product); err != nil {
+		return nil, err
+	}
+
 	return p.productService.Create(ctx, product)
 }
 
@@ -34,10 +55,55 @@ func (p *ProductPolicy) One(ctx context.Context, id string) (*model.Product, err
 	return p.productService.One(ctx, id)
 }
 
+func (p *ProductPolicy) OneDetailed(ctx context.Context, id string) (*model.Product, error) {
+	product, err := p.productService.OneDetailed(ctx, id, p.visibility(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	if p.publishedOnly(ctx) && !product.IsPublished() {
+		return nil, errProductNotFound
+	}
+
+	return product, nil
+}
+
+func (p *ProductPolicy) NestVariants(ctx context.Context, parents []*model.Product) error {
+	return p.productService.NestVariants(ctx, parents, p.visibility(ctx)...)
+}
+
 func (p *ProductPolicy) Delete(ctx context.Context, id string) error {
 	return p.productService.Delete(ctx, id)
 }
 
 func (p *ProductPolicy) Update(ctx context.Context, product *model.Product) error {
+	if err := p.validate(ctx, product); err != nil {
+		return err
+	}
+
 	return p.productService.Update(ctx, product)
 }
+
+// validate reports the fields the request could not be read from together with
+// the violations of the product, so all of them come back at once
+func (p *ProductPolicy) validate(ctx context.Context, product *model.Product) error {
+	violations := product.InputViolations()
+
+	var err error
+	if product.IsVariant() {
+		err = p.validateVariant(ctx, product)
+	} else {
+		if len(product.Options) != 0 {
+			violations.Add("options", "are allowed for variants only")
+		}
+		err = p.validator.Validate(ctx, product)
+	}
+
+	if err != nil {
+		if errors.KindOf(err) != errors.KindValidation {
+			return err
+		}
+		violations = append(violations, errors.ViolationsOf(err)...)
+	}
+
+	return violations.Err()
+}
@@ -0,0 +1,126 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+func (p *ProductPolicy) SetOptionAxes(ctx context.Context, productID string, axes []*model.OptionAxis) error {
+	product, err := p.productService.One(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if product.IsVariant() {
+		return errors.Validation("option axes can be set on a parent product only")
+	}
+
+	var violations errors.FieldViolations
+	names := make(map[string]struct{}, len(axes))
+	for i, axis := range axes {
+		field := fmt.Sprintf("option_axes[%d]", i)
+		if axis.Name == "" {
+			violations.Add(field+".name", "must not be empty")
+		}
+		if _, ok := names[axis.Name]; ok {
+			violations.Add(field+".name", "must be unique")
+		}
+		names[axis.Name] = struct{}{}
+		if len(axis.Values) == 0 {
+			violations.Add(field+".values", "must not be empty")
+		}
+	}
+	if err = violations.Err(); err != nil {
+		return err
+	}
+
+	return p.productService.SetOptionAxes(ctx, productID, axes)
+}
+
+// validateVariant checks the variant against its parent: the parent has to be a top level
+// product, options have to pick one value of every parent axis and be unique among siblings.
+// The variant is validated with the inherited fields of the parent applied.
+func (p *ProductPolicy) validateVariant(ctx context.Context, variant *model.Product) error {
+	var violations errors.FieldViolations
+
+	parent, err := p.productService.OneDetailed(ctx, *variant.ParentID)
+	if err != nil {
+		if errors.KindOf(err) != errors.KindNotFound {
+			return err
+		}
+		violations.Add("parent_id", "parent product does not exist")
+		return violations.Err()
+	}
+	if parent.IsVariant() {
+		violations.Add("parent_id", "variant can not be a parent")
+		return violations.Err()
+	}
+
+	if variant.SKU == nil || *variant.SKU == "" {
+		violations.Add("sku", "is required for a variant")
+	}
+
+	axes := make(map[string]*model.OptionAxis, len(parent.OptionAxes))
+	for _, axis := range parent.OptionAxes {
+		axes[axis.Name] = axis
+		value, ok := variant.Options[axis.Name]
+		if !ok {
+			violations.Add("options."+axis.Name, "is required")
+			continue
+		}
+		if !contains(axis.Values, value) {
+			violations.Add("options."+axis.Name, fmt.Sprintf("must be one of %v", axis.Values))
+		}
+	}
+	for name := range variant.Options {
+		if _, ok := axes[name]; !ok {
+			violations.Add("options."+name, "is not an option axis of the parent")
+		}
+	}
+
+	for _, sibling := range parent.Variants {
+		if sibling.ID != variant.ID && sameOptions(sibling.Options, variant.Options) {
+			violations.Add("options", fmt.Sprintf("variant %s already has these options", sibling.ID))
+			break
+		}
+	}
+
+	// variants always share currency, category and brand with their parent
+	variant.CurrencyID = parent.CurrencyID
+	variant.CategoryID = parent.CategoryID
+	variant.BrandID = parent.BrandID
+
+	effective := *variant
+	effective.ApplyParent(parent)
+	if err = p.validator.Validate(ctx, &effective); err != nil {
+		if errors.KindOf(err) != errors.KindValidation {
+			return err
+		}
+		violations = append(violations, errors.ViolationsOf(err)...)
+	}
+
+	return violations.Err()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sameOptions(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
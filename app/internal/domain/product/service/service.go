@@ -2,19 +2,53 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/filter"
 
+	currencyModel "github.com/ilkinabd/goods-manager/app/internal/domain/currency/model"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/dao"
 	"github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
 	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/jackc/pgx/v4"
 )
 
+// CurrencyFinder tells the ISO 4217 code and exponent of the currency prices are given in
+type CurrencyFinder interface {
+	One(ctx context.Context, id uint32) (*currencyModel.Currency, error)
+}
+
+// SlugGenerator gives a product a slug made from its name in the transaction writing the product
+type SlugGenerator interface {
+	Generate(ctx context.Context, tx pgx.Tx, productID, name string) error
+}
+
+// BarcodeRegistry keeps the barcodes a product is scanned by, they are set in the
+// transaction writing the product
+type BarcodeRegistry interface {
+	Set(ctx context.Context, tx pgx.Tx, productID string, codes []string) error
+}
+
 type ProductService struct {
 	repository dao.ProductDAO
+	currencies CurrencyFinder
+	slugs      SlugGenerator
+	barcodes   BarcodeRegistry
 }
 
-func NewProductService(repository dao.ProductDAO) *ProductService {
-	return &ProductService{repository: repository}
+func NewProductService(
+	repository dao.ProductDAO,
+	currencies CurrencyFinder,
+	slugs SlugGenerator,
+	barcodes BarcodeRegistry,
+) *ProductService {
+	return &ProductService{
+		repository: repository,
+		currencies: currencies,
+		slugs:      slugs,
+		barcodes:   barcodes,
+	}
 }
 
 func (s *ProductService) All(ctx context.Context, filtering []filter.Criteria, sorting filter.Sortable) ([]*model.Product, error) {
@@ -31,13 +65,37 @@ func (s *ProductService) All(ctx context.Context, filtering []filter.Criteria, s
 	return products, nil
 }
 
+func (s *ProductService) BrandFacets(ctx context.Context, filtering []filter.Criteria) ([]*model.BrandFacet, error) {
+	dbFacets, err := s.repository.BrandFacets(ctx, filtering)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.BrandFacets")
+	}
+
+	return model.NewBrandFacetsFromDAO(dbFacets), nil
+}
+
 func (s *ProductService) Create(ctx context.Context, product *model.Product) (*model.Product, error) {
+	if err := s.resolveCurrency(ctx, product); err != nil {
+		return nil, err
+	}
+
 	productStorageMap, err := product.ToMap()
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.repository.Create(ctx, productStorageMap)
+	productStorageMap["status"] = product.Status
+
+	err = s.repository.Create(ctx, productStorageMap, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.slugs.Generate(ctx, tx, product.ID, product.Name); err != nil {
+			return errors.Wrap(err, "slugs.Generate")
+		}
+
+		if len(product.Barcodes) == 0 {
+			return nil
+		}
+		return errors.Wrap(s.barcodes.Set(ctx, tx, product.ID, product.BarcodeCodes()), "barcodes.Set")
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -54,15 +112,161 @@ func (s *ProductService) One(ctx context.Context, id string) (*model.Product, er
 	return model.NewProductFromDAO(one), nil
 }
 
+// Delete removes the product with its variants. Their relations, bundles, tags and
+// barcodes go with them by the foreign keys, in the same statement.
 func (s *ProductService) Delete(ctx context.Context, id string) error {
 	return s.repository.Delete(ctx, id)
 }
 
 func (s *ProductService) Update(ctx context.Context, product *model.Product) error {
+	if err := s.resolveCurrency(ctx, product); err != nil {
+		return err
+	}
+
 	productStorageMap, err := product.ToMap()
 	if err != nil {
 		return err
 	}
 
-	return s.repository.Update(ctx, product.ID, productStorageMap)
+	var within dao.Within
+	if product.NameChanged() || product.BarcodesChanged() {
+		within = func(ctx context.Context, tx pgx.Tx) error {
+			if product.NameChanged() {
+				if err := s.slugs.Generate(ctx, tx, product.ID, product.Name); err != nil {
+					return errors.Wrap(err, "slugs.Generate")
+				}
+			}
+
+			if product.BarcodesChanged() {
+				if err := s.barcodes.Set(ctx, tx, product.ID, product.BarcodeCodes()); err != nil {
+					return errors.Wrap(err, "barcodes.Set")
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return s.repository.Update(ctx, product.ID, productStorageMap, within)
+}
+
+// OneDetailed returns a parent product with its option axes and variants,
+// or a variant with the inherited fields of its parent applied.
+func (s *ProductService) OneDetailed(ctx context.Context, id string, variantCriteria ...filter.Criteria) (*model.Product, error) {
+	product, err := s.One(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.IsVariant() {
+		parent, err := s.One(ctx, *product.ParentID)
+		if err != nil {
+			return nil, errors.Wrap(err, "parent of variant")
+		}
+		product.ApplyParent(parent)
+		return product, nil
+	}
+
+	if err = s.NestVariants(ctx, []*model.Product{product}, variantCriteria...); err != nil {
+		return nil, err
+	}
+
+	axes, err := s.repository.OptionAxes(ctx, product.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.OptionAxes")
+	}
+	product.OptionAxes = model.NewOptionAxesFromDAO(axes)
+
+	return product, nil
+}
+
+// NestVariants loads variants of the parents meeting the criteria with a single query and attaches them
+func (s *ProductService) NestVariants(ctx context.Context, parents []*model.Product, criteria ...filter.Criteria) error {
+	if len(parents) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*model.Product, len(parents))
+	ids := make([]string, 0, len(parents))
+	for _, p := range parents {
+		byID[p.ID] = p
+		ids = append(ids, p.ID)
+	}
+
+	dbVariants, err := s.repository.All(
+		ctx,
+		append([]filter.Criteria{filter.NewParentCriteria(ids)}, criteria...),
+		filter.NewSort("sku", "ASC"),
+	)
+	if err != nil {
+		return errors.Wrap(err, "repository.All(variants)")
+	}
+
+	for _, dbV := range dbVariants {
+		variant := model.NewProductFromDAO(dbV)
+		parent := byID[*variant.ParentID]
+		variant.ApplyParent(parent)
+		parent.Variants = append(parent.Variants, variant)
+	}
+
+	return nil
+}
+
+func (s *ProductService) SetOptionAxes(ctx context.Context, productID string, axes []*model.OptionAxis) error {
+	axesDAO := make([]*dao.OptionAxis, len(axes))
+	for i, axis := range axes {
+		axesDAO[i] = &dao.OptionAxis{
+			Name:     axis.Name,
+			Values:   axis.Values,
+			Position: i,
+		}
+	}
+
+	return s.repository.SetOptionAxes(ctx, productID, axesDAO)
+}
+
+func (s *ProductService) Transition(ctx context.Context, product *model.Product, to string) error {
+	if err := s.repository.Transition(ctx, product.ID, product.Status, to); err != nil {
+		return err
+	}
+
+	product.Status = to
+	return nil
+}
+
+func (s *ProductService) SchedulePublication(ctx context.Context, id string, publishAt, unpublishAt *time.Time) error {
+	return s.repository.SchedulePublication(ctx, id, publishAt, unpublishAt)
+}
+
+// RunLifecycle carries out scheduled publishing and unpublishing every interval until ctx is done
+func (s *ProductService) RunLifecycle(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed, err := s.repository.ApplyScheduledStatus(ctx)
+			if err != nil {
+				logging.WithError(ctx, err).Error("failed to apply scheduled statuses")
+				continue
+			}
+			if changed > 0 {
+				logging.WithField(ctx, "products", changed).Info("scheduled statuses applied")
+			}
+		}
+	}
+}
+
+// resolveCurrency gives the price the currency of the product, which may have changed
+func (s *ProductService) resolveCurrency(ctx context.Context, product *model.Product) error {
+	currency, err := s.currencies.One(ctx, product.CurrencyID)
+	if err != nil {
+		return errors.Wrap(err, "currencies.One")
+	}
+
+	product.Price.Currency = currency.Money()
+	return nil
 }
@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	barcodeDao "github.com/ilkinabd/goods-manager/app/internal/domain/barcode/dao"
+	brandDao "github.com/ilkinabd/goods-manager/app/internal/domain/brand/dao"
+	categoryStorage "github.com/ilkinabd/goods-manager/app/internal/domain/category/storage"
+	currencyStorage "github.com/ilkinabd/goods-manager/app/internal/domain/currency/storage"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/product/model"
+	taxDao "github.com/ilkinabd/goods-manager/app/internal/domain/tax/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/barcode"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/shipping"
+)
+
+const (
+	NameMinLength        = 1
+	NameMaxLength        = 255
+	DescriptionMaxLength = 5000
+
+	SpecificationMaxKeys      = 100
+	SpecificationKeyMaxLength = 64
+)
+
+type ProductValidator struct {
+	categories categoryStorage.CategoryStorage
+	currencies currencyStorage.CurrencyStorage
+	brands     brandDao.BrandDAO
+	barcodes   barcodeDao.BarcodeDAO
+	taxes      taxDao.TaxDAO
+}
+
+func NewProductValidator(
+	categories categoryStorage.CategoryStorage,
+	currencies currencyStorage.CurrencyStorage,
+	brands brandDao.BrandDAO,
+	barcodes barcodeDao.BarcodeDAO,
+	taxes taxDao.TaxDAO,
+) *ProductValidator {
+	return &ProductValidator{
+		categories: categories,
+		currencies: currencies,
+		brands:     brands,
+		barcodes:   barcodes,
+		taxes:      taxes,
+	}
+}
+
+// Validate checks the product before it is created or updated and reports all
+// violations at once. Storage failures are returned as they are.
+func (v *ProductValidator) Validate(ctx context.Context, p *model.Product) error {
+	var violations errors.FieldViolations
+
+	nameLength := utf8.RuneCountInString(p.Name)
+	if nameLength < NameMinLength || nameLength > NameMaxLength {
+		violations.Add("name", fmt.Sprintf("must be from %d to %d characters long", NameMinLength, NameMaxLength))
+	}
+
+	if utf8.RuneCountInString(p.Description) > DescriptionMaxLength {
+		violations.Add("description", fmt.Sprintf("must be at most %d characters long", DescriptionMaxLength))
+	}
+
+	if !p.Price.IsPositive() {
+		violations.Add("price", "must be greater than 0")
+	}
+
+	if p.Weight != nil && p.Weight.Grams <= 0 {
+		violations.Add("weight.value", "must be greater than 0")
+	}
+
+	if d := p.Dimensions; d != nil && (d.Length <= 0 || d.Width <= 0 || d.Height <= 0) {
+		violations.Add("dimensions", "length, width and height must be greater than 0")
+	}
+
+	if p.ShippingClass != nil && !shipping.IsClass(*p.ShippingClass) {
+		violations.Add("shipping_class", "must be one of standard, bulky, fragile")
+	}
+
+	exists, err := v.currencies.Exists(ctx, p.CurrencyID)
+	if err != nil {
+		return errors.Wrap(err, "currencies.Exists")
+	}
+	if !exists {
+		violations.Add("currency_id", "currency does not exist")
+	}
+
+	exists, err = v.categories.Exists(ctx, p.CategoryID)
+	if err != nil {
+		return errors.Wrap(err, "categories.Exists")
+	}
+	if !exists {
+		violations.Add("category_id", "category does not exist")
+	}
+
+	if p.BrandID != nil {
+		brandExists, err := v.brands.Exists(ctx, *p.BrandID)
+		if err != nil {
+			return errors.Wrap(err, "brands.Exists")
+		}
+		if !brandExists {
+			violations.Add("brand_id", "brand does not exist")
+		}
+	}
+
+	if p.TaxClassID != nil {
+		taxClassExists, err := v.taxes.ClassExists(ctx, *p.TaxClassID)
+		if err != nil {
+			return errors.Wrap(err, "taxes.ClassExists")
+		}
+		if !taxClassExists {
+			violations.Add("tax_class_id", "tax class does not exist")
+		}
+	}
+
+	if err = v.validateBarcodes(ctx, p, &violations); err != nil {
+		return err
+	}
+
+	validateSpecification(p.Specification, &violations)
+
+	if exists {
+		schema, err := v.categories.Schema(ctx, p.CategoryID)
+		if err != nil {
+			return errors.Wrap(err, "categories.Schema")
+		}
+		schema.Validate(p.Specification, &violations)
+	}
+
+	return violations.Err()
+}
+
+// validateBarcodes checks the check digits and that no other product is scanned by the same GTIN
+func (v *ProductValidator) validateBarcodes(ctx context.Context, p *model.Product, violations *errors.FieldViolations) error {
+	positions := make(map[string]int, len(p.Barcodes))
+	gtins := make([]string, 0, len(p.Barcodes))
+	for i, b := range p.Barcodes {
+		field := fmt.Sprintf("barcodes[%d]", i)
+		parsed, err := barcode.Parse(b.Code)
+		if err != nil {
+			violations.Add(field, err.Error())
+			continue
+		}
+		if _, ok := positions[parsed.GTIN]; ok {
+			violations.Add(field, "is listed twice")
+			continue
+		}
+		positions[parsed.GTIN] = i
+		gtins = append(gtins, parsed.GTIN)
+	}
+
+	if len(gtins) == 0 {
+		return nil
+	}
+
+	taken, err := v.barcodes.ByGTIN(ctx, gtins)
+	if err != nil {
+		return errors.Wrap(err, "barcodes.ByGTIN")
+	}
+	for _, t := range taken {
+		if t.ProductID != p.ID {
+			violations.Add(fmt.Sprintf("barcodes[%d]", positions[t.GTIN]), "is used by product "+t.ProductID)
+		}
+	}
+
+	return nil
+}
+
+// validateSpecification allows a flat object of scalars and lists of scalars
+func validateSpecification(spec map[string]interface{}, violations *errors.FieldViolations) {
+	if len(spec) > SpecificationMaxKeys {
+		violations.Add("specification", fmt.Sprintf("must have at most %d attributes", SpecificationMaxKeys))
+	}
+
+	keys := make([]string, 0, len(spec))
+	for key := range spec {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := spec[key]
+		field := "specification." + key
+		if key == "" || utf8.RuneCountInString(key) > SpecificationKeyMaxLength {
+			violations.Add(field, fmt.Sprintf("attribute name must be from 1 to %d characters long", SpecificationKeyMaxLength))
+		}
+
+		switch val := value.(type) {
+		case []interface{}:
+			for _, item := range val {
+				if !isScalar(item) {
+					violations.Add(field, "list items must be strings, numbers or booleans")
+					break
+				}
+			}
+		default:
+			if !isScalar(val) {
+				violations.Add(field, "must be a string, number, boolean or list")
+			}
+		}
+	}
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, float64, bool:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type PromotionDAO interface {
+	All(context.Context) ([]*Promotion, error)
+	Active(context.Context) ([]*Promotion, error)
+	Create(context.Context, map[string]interface{}) error
+	Delete(context.Context, string) error
+}
@@ -0,0 +1,28 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+// Conditions are stored as a single jsonb column, all set conditions have to hold
+type Conditions struct {
+	CategoryIDs   []uint32               `json:"category_ids,omitempty"`
+	ProductIDs    []string               `json:"product_ids,omitempty"`
+	Specification map[string]interface{} `json:"specification,omitempty"`
+	MinQuantity   uint64                 `json:"min_quantity,omitempty"`
+	MinSubtotal   uint64                 `json:"min_subtotal,omitempty"`
+}
+
+type Promotion struct {
+	ID           string
+	Name         string
+	Priority     int32
+	Stackable    bool
+	Conditions   Conditions
+	Action       string
+	Value        uint64
+	FreeQuantity uint64
+	StartsAt     sql.NullTime
+	EndsAt       sql.NullTime
+	CreatedAt    sql.NullTime
+}
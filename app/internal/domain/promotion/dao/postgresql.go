@@ -0,0 +1,190 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+)
+
+type promotionDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewPromotionDAOPostgres(client PostgreSQLClient) PromotionDAO {
+	return &promotionDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme      = "public"
+	table       = "promotion"
+	tableScheme = scheme + "." + table
+
+	tenantColumn = "tenant_id"
+)
+
+func (s *promotionDAOPostgres) selectQuery(tenantID string) sq.SelectBuilder {
+	return s.queryBuilder.
+		Select("id").
+		Columns(
+			"name",
+			"priority",
+			"stackable",
+			"conditions",
+			"action",
+			"value",
+			"free_quantity",
+			"starts_at",
+			"ends_at",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID}).
+		OrderBy("priority DESC", "created_at")
+}
+
+func (s *promotionDAOPostgres) All(ctx context.Context) ([]*Promotion, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, s.selectQuery(tenantID))
+}
+
+// Active returns promotions whose date window contains the current moment
+func (s *promotionDAOPostgres) Active(ctx context.Context) ([]*Promotion, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, s.selectQuery(tenantID).
+		Where(sq.Expr("starts_at <= NOW()")).
+		Where(sq.Or{sq.Eq{"ends_at": nil}, sq.Expr("ends_at > NOW()")}))
+}
+
+func (s *promotionDAOPostgres) list(ctx context.Context, query sq.SelectBuilder) ([]*Promotion, error) {
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Promotion, 0)
+
+	for rows.Next() {
+		p := Promotion{}
+		if err = rows.Scan(
+			&p.ID,
+			&p.Name,
+			&p.Priority,
+			&p.Stackable,
+			&p.Conditions,
+			&p.Action,
+			&p.Value,
+			&p.FreeQuantity,
+			&p.StartsAt,
+			&p.EndsAt,
+			&p.CreatedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &p)
+	}
+
+	return list, nil
+}
+
+func (s *promotionDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(tableScheme).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *promotionDAOPostgres) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Delete(tableScheme).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("promotion not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
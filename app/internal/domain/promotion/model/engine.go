@@ -0,0 +1,206 @@
+package model
+
+import (
+	"math"
+	"math/bits"
+	"reflect"
+	"sort"
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+var errSubtotalOutOfRange = errors.Validation("the subtotal of the lines is out of range")
+
+// Line is a product with quantity to be priced
+type Line struct {
+	ProductID     string
+	CategoryID    uint32
+	Specification map[string]interface{}
+	UnitPrice     uint64
+	Quantity      uint64
+}
+
+type LineQuote struct {
+	Line
+	Subtotal   uint64
+	Discount   uint64
+	Total      uint64
+	Promotions []string
+}
+
+func (q *LineQuote) ToProto() *pbProducts.QuoteLine {
+	return &pbProducts.QuoteLine{
+		ProductId:    q.ProductID,
+		Quantity:     q.Quantity,
+		UnitPrice:    q.UnitPrice,
+		Subtotal:     q.Subtotal,
+		Discount:     q.Discount,
+		Total:        q.Total,
+		PromotionIds: q.Promotions,
+	}
+}
+
+// Matches reports whether every condition of the promotion holds for the line at the moment.
+// The minimum subtotal is a threshold of the whole cart, it is compared with the cart subtotal.
+func (p *Promotion) Matches(line Line, cartSubtotal uint64, at time.Time) bool {
+	if at.Before(p.StartsAt) || (p.EndsAt != nil && !at.Before(*p.EndsAt)) {
+		return false
+	}
+
+	c := p.Conditions
+	if len(c.CategoryIDs) != 0 && !containsCategory(c.CategoryIDs, line.CategoryID) {
+		return false
+	}
+	if len(c.ProductIDs) != 0 && !containsProduct(c.ProductIDs, line.ProductID) {
+		return false
+	}
+	for attr, want := range c.Specification {
+		if !specMatches(line.Specification[attr], want) {
+			return false
+		}
+	}
+	if line.Quantity < c.MinQuantity {
+		return false
+	}
+	if cartSubtotal < c.MinSubtotal {
+		return false
+	}
+
+	return true
+}
+
+// discount returns how much the promotion takes off the remaining line total
+func (p *Promotion) discount(line Line, remaining uint64) uint64 {
+	var off uint64
+	switch p.Action {
+	case ActionPercentage:
+		off = mulDiv(remaining, p.Value, 100)
+	case ActionFixedAmount:
+		off = p.Value
+	case ActionFixedPrice:
+		if target := mulDiv(p.Value, line.Quantity, 1); remaining > target {
+			off = remaining - target
+		}
+	case ActionBuyXGetY:
+		if group := p.Value + p.FreeQuantity; group >= p.Value && group != 0 && line.Quantity != 0 {
+			free := mulDiv(line.Quantity/group, p.FreeQuantity, 1)
+			off = mulDiv(remaining, free, line.Quantity)
+		}
+	}
+
+	if off > remaining {
+		return remaining
+	}
+	return off
+}
+
+// Quote prices the lines with the promotions. Promotions are tried by priority.
+// A non-stackable promotion applies only if nothing was applied before it and
+// stops the chain; stackable ones compound on the already discounted total.
+// Lines whose subtotals do not add up within range are refused.
+func Quote(promotions []*Promotion, lines []Line, at time.Time) ([]*LineQuote, error) {
+	ordered := make([]*Promotion, len(promotions))
+	copy(ordered, promotions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority > ordered[j].Priority
+	})
+
+	subtotals := make([]uint64, len(lines))
+	var cartSubtotal uint64
+	for i, line := range lines {
+		hi, subtotal := bits.Mul64(line.UnitPrice, line.Quantity)
+		sum, carry := bits.Add64(cartSubtotal, subtotal, 0)
+		if hi != 0 || carry != 0 {
+			return nil, errSubtotalOutOfRange
+		}
+		subtotals[i] = subtotal
+		cartSubtotal = sum
+	}
+
+	quotes := make([]*LineQuote, len(lines))
+	for i, line := range lines {
+		quote := &LineQuote{Line: line, Subtotal: subtotals[i], Total: subtotals[i]}
+
+		for _, promo := range ordered {
+			if len(quote.Promotions) != 0 && !promo.Stackable {
+				continue
+			}
+			if !promo.Matches(line, cartSubtotal, at) {
+				continue
+			}
+
+			off := promo.discount(line, quote.Total)
+			if off == 0 {
+				continue
+			}
+			quote.Total -= off
+			quote.Promotions = append(quote.Promotions, promo.ID)
+
+			if !promo.Stackable {
+				break
+			}
+		}
+
+		quote.Discount = quote.Subtotal - quote.Total
+		quotes[i] = quote
+	}
+
+	return quotes, nil
+}
+
+// QuoteEach prices every line as a cart of its own, e.g. the products of a listing
+func QuoteEach(promotions []*Promotion, lines []Line, at time.Time) ([]*LineQuote, error) {
+	quotes := make([]*LineQuote, len(lines))
+	for i, line := range lines {
+		quote, err := Quote(promotions, []Line{line}, at)
+		if err != nil {
+			return nil, err
+		}
+		quotes[i] = quote[0]
+	}
+
+	return quotes, nil
+}
+
+// mulDiv computes a * b / c without intermediate overflow, a result out of range is capped
+func mulDiv(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= c {
+		return math.MaxUint64
+	}
+	quo, _ := bits.Div64(hi, lo, c)
+	return quo
+}
+
+func containsCategory(ids []uint32, id uint32) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsProduct(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// specMatches compares JSON decoded values, a list attribute matches if it contains the value
+func specMatches(have, want interface{}) bool {
+	if list, ok := have.([]interface{}); ok {
+		for _, v := range list {
+			if reflect.DeepEqual(v, want) {
+				return true
+			}
+		}
+		return false
+	}
+	return reflect.DeepEqual(have, want)
+}
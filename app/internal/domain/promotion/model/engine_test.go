@@ -0,0 +1,79 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+func TestQuoteComparesMinSubtotalWithCart(t *testing.T) {
+	now := time.Now()
+	promotions := []*Promotion{{
+		ID:         "p1",
+		StartsAt:   now.Add(-time.Hour),
+		Conditions: Conditions{MinSubtotal: 1000},
+		Action:     ActionPercentage,
+		Value:      10,
+	}}
+
+	// no line reaches the threshold on its own, the cart does
+	lines := []Line{
+		{ProductID: "a", UnitPrice: 300, Quantity: 2},
+		{ProductID: "b", UnitPrice: 400, Quantity: 1},
+	}
+
+	quotes, err := Quote(promotions, lines, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range quotes {
+		if q.Discount != q.Subtotal/10 {
+			t.Errorf("line %s: got discount %d of %d, want 10%%", q.ProductID, q.Discount, q.Subtotal)
+		}
+	}
+
+	quotes, err = QuoteEach(promotions, lines, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range quotes {
+		if q.Discount != 0 {
+			t.Errorf("line %s: discounted below the threshold when priced on its own", q.ProductID)
+		}
+	}
+}
+
+func TestQuoteRefusesSubtotalsOutOfRange(t *testing.T) {
+	tests := map[string][]Line{
+		"line": {{ProductID: "a", UnitPrice: math.MaxUint64 / 2, Quantity: 3}},
+		"cart": {
+			{ProductID: "a", UnitPrice: math.MaxUint64 / 2, Quantity: 1},
+			{ProductID: "b", UnitPrice: math.MaxUint64 / 2, Quantity: 1},
+			{ProductID: "c", UnitPrice: 2, Quantity: 1},
+		},
+	}
+
+	for name, lines := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Quote(nil, lines, time.Now())
+			if errors.KindOf(err) != errors.KindValidation {
+				t.Errorf("got error %v, want a validation error", err)
+			}
+		})
+	}
+}
+
+func TestQuoteDiscountsLargeTotalsWithoutOverflow(t *testing.T) {
+	now := time.Now()
+	promotions := []*Promotion{{ID: "p1", StartsAt: now.Add(-time.Hour), Action: ActionPercentage, Value: 50}}
+
+	quotes, err := Quote(promotions, []Line{{ProductID: "a", UnitPrice: math.MaxUint64 - 1, Quantity: 1}}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := uint64(math.MaxUint64 / 2); quotes[0].Total != want {
+		t.Errorf("got total %d, want half of the subtotal", quotes[0].Total)
+	}
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/promotion/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+)
+
+const (
+	// ActionPercentage takes Value percent off
+	ActionPercentage = "percentage"
+	// ActionFixedAmount takes Value off the line once
+	ActionFixedAmount = "fixed_amount"
+	// ActionFixedPrice sells every unit for Value
+	ActionFixedPrice = "fixed_price"
+	// ActionBuyXGetY gives FreeQuantity units for free for every Value units bought
+	ActionBuyXGetY = "buy_x_get_y"
+)
+
+type Conditions struct {
+	CategoryIDs   []uint32
+	ProductIDs    []string
+	Specification map[string]interface{}
+	MinQuantity   uint64
+	MinSubtotal   uint64
+}
+
+type Promotion struct {
+	ID           string
+	Name         string
+	Priority     int32
+	Stackable    bool
+	Conditions   Conditions
+	Action       string
+	Value        uint64
+	FreeQuantity uint64
+	StartsAt     time.Time
+	EndsAt       *time.Time
+	CreatedAt    time.Time
+}
+
+func NewPromotionFromPB(req *pbProducts.CreatePromotionRequest) (*Promotion, error) {
+	spec := make(map[string]interface{})
+	if req.GetSpecification() != "" {
+		if err := json.Unmarshal([]byte(req.GetSpecification()), &spec); err != nil {
+			var violations errors.FieldViolations
+			violations.Add("specification", "must be a JSON object")
+			return nil, violations.Err()
+		}
+	}
+
+	startsAt := time.Now()
+	if req.GetStartsAt() != 0 {
+		startsAt = time.UnixMilli(req.GetStartsAt())
+	}
+
+	var endsAt *time.Time
+	if req.EndsAt != nil {
+		t := time.UnixMilli(req.GetEndsAt())
+		endsAt = &t
+	}
+
+	return &Promotion{
+		ID:        uuid.New().String(),
+		Name:      req.GetName(),
+		Priority:  req.GetPriority(),
+		Stackable: req.GetStackable(),
+		Conditions: Conditions{
+			CategoryIDs:   req.GetCategoryIds(),
+			ProductIDs:    req.GetProductIds(),
+			Specification: spec,
+			MinQuantity:   req.GetMinQuantity(),
+			MinSubtotal:   req.GetMinSubtotal(),
+		},
+		Action:       req.GetAction(),
+		Value:        req.GetValue(),
+		FreeQuantity: req.GetFreeQuantity(),
+		StartsAt:     startsAt,
+		EndsAt:       endsAt,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+func (p *Promotion) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            p.ID,
+		"name":          p.Name,
+		"priority":      p.Priority,
+		"stackable":     p.Stackable,
+		"conditions":    dao.Conditions(p.Conditions),
+		"action":        p.Action,
+		"value":         p.Value,
+		"free_quantity": p.FreeQuantity,
+		"starts_at":     p.StartsAt,
+		"ends_at":       p.EndsAt,
+		"created_at":    p.CreatedAt,
+	}
+}
+
+func (p *Promotion) ToProto() *pbProducts.Promotion {
+	specBytes, err := json.Marshal(p.Conditions.Specification)
+	if err != nil {
+		logging.GetLogger().Warnf("failed to marshal promotion specification %v", err)
+	}
+
+	var endsAt *int64
+	if p.EndsAt != nil {
+		ms := p.EndsAt.UnixMilli()
+		endsAt = &ms
+	}
+
+	return &pbProducts.Promotion{
+		Id:            p.ID,
+		Name:          p.Name,
+		Priority:      p.Priority,
+		Stackable:     p.Stackable,
+		CategoryIds:   p.Conditions.CategoryIDs,
+		ProductIds:    p.Conditions.ProductIDs,
+		Specification: string(specBytes),
+		MinQuantity:   p.Conditions.MinQuantity,
+		MinSubtotal:   p.Conditions.MinSubtotal,
+		Action:        p.Action,
+		Value:         p.Value,
+		FreeQuantity:  p.FreeQuantity,
+		StartsAt:      p.StartsAt.UnixMilli(),
+		EndsAt:        endsAt,
+		CreatedAt:     p.CreatedAt.UnixMilli(),
+	}
+}
+
+func NewPromotionFromDAO(p *dao.Promotion) *Promotion {
+	var endsAt *time.Time
+	if p.EndsAt.Valid {
+		endsAt = &p.EndsAt.Time
+	}
+
+	return &Promotion{
+		ID:           p.ID,
+		Name:         p.Name,
+		Priority:     p.Priority,
+		Stackable:    p.Stackable,
+		Conditions:   Conditions(p.Conditions),
+		Action:       p.Action,
+		Value:        p.Value,
+		FreeQuantity: p.FreeQuantity,
+		StartsAt:     p.StartsAt.Time,
+		EndsAt:       endsAt,
+		CreatedAt:    p.CreatedAt.Time,
+	}
+}
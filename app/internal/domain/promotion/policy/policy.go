@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/promotion/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/promotion/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type PromotionPolicy struct {
+	promotionService *service.PromotionService
+}
+
+func NewPromotionPolicy(promotionService *service.PromotionService) *PromotionPolicy {
+	return &PromotionPolicy{promotionService: promotionService}
+}
+
+func (p *PromotionPolicy) All(ctx context.Context) ([]*model.Promotion, error) {
+	promotions, err := p.promotionService.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "promotionService.All")
+	}
+
+	return promotions, nil
+}
+
+func (p *PromotionPolicy) Create(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error) {
+	var violations errors.FieldViolations
+	if promotion.Name == "" {
+		violations.Add("name", "must not be empty")
+	}
+	switch promotion.Action {
+	case model.ActionPercentage:
+		if promotion.Value == 0 || promotion.Value > 100 {
+			violations.Add("value", "must be between 1 and 100 for a percentage")
+		}
+	case model.ActionFixedAmount, model.ActionFixedPrice:
+		if promotion.Value == 0 {
+			violations.Add("value", "must be greater than 0")
+		}
+	case model.ActionBuyXGetY:
+		if promotion.Value == 0 {
+			violations.Add("value", "must be greater than 0")
+		}
+		if promotion.FreeQuantity == 0 {
+			violations.Add("free_quantity", "must be greater than 0")
+		}
+	default:
+		violations.Add("action", "must be one of percentage, fixed_amount, fixed_price, buy_x_get_y")
+	}
+	if promotion.EndsAt != nil && !promotion.EndsAt.After(promotion.StartsAt) {
+		violations.Add("ends_at", "must be after starts_at")
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.promotionService.Create(ctx, promotion)
+}
+
+func (p *PromotionPolicy) Delete(ctx context.Context, id string) error {
+	return p.promotionService.Delete(ctx, id)
+}
+
+func (p *PromotionPolicy) Quote(ctx context.Context, lines []model.Line) ([]*model.LineQuote, error) {
+	var violations errors.FieldViolations
+	for _, line := range lines {
+		if line.Quantity == 0 {
+			violations.Add("lines.quantity", "must be greater than 0")
+			break
+		}
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.promotionService.Quote(ctx, lines)
+}
+
+// QuoteEach prices the products of a listing, each one as a cart of its own
+func (p *PromotionPolicy) QuoteEach(ctx context.Context, lines []model.Line) ([]*model.LineQuote, error) {
+	return p.promotionService.QuoteEach(ctx, lines)
+}
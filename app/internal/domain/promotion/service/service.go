@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/promotion/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/promotion/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type PromotionService struct {
+	repository dao.PromotionDAO
+}
+
+func NewPromotionService(repository dao.PromotionDAO) *PromotionService {
+	return &PromotionService{repository: repository}
+}
+
+func (s *PromotionService) All(ctx context.Context) ([]*model.Promotion, error) {
+	dbPromotions, err := s.repository.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.All")
+	}
+
+	return newPromotionsFromDAO(dbPromotions), nil
+}
+
+func (s *PromotionService) Create(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error) {
+	if err := s.repository.Create(ctx, promotion.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *PromotionService) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+// Quote prices the lines with the promotions active right now
+func (s *PromotionService) Quote(ctx context.Context, lines []model.Line) ([]*model.LineQuote, error) {
+	dbPromotions, err := s.repository.Active(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Active")
+	}
+
+	return model.Quote(newPromotionsFromDAO(dbPromotions), lines, time.Now())
+}
+
+// QuoteEach prices every line as a cart of its own with the promotions active right now
+func (s *PromotionService) QuoteEach(ctx context.Context, lines []model.Line) ([]*model.LineQuote, error) {
+	dbPromotions, err := s.repository.Active(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Active")
+	}
+
+	return model.QuoteEach(newPromotionsFromDAO(dbPromotions), lines, time.Now())
+}
+
+func newPromotionsFromDAO(dbPromotions []*dao.Promotion) []*model.Promotion {
+	promotions := make([]*model.Promotion, 0, len(dbPromotions))
+	for _, dbP := range dbPromotions {
+		promotions = append(promotions, model.NewPromotionFromDAO(dbP))
+	}
+	return promotions
+}
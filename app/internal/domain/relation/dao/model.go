@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Relation struct {
+	ID        string
+	ProductID string
+	RelatedID string
+	Type      string
+	Position  sql.NullInt32
+	CreatedAt sql.NullTime
+}
+
+type Bundle struct {
+	ProductID     string
+	PriceOverride sql.NullInt64
+	Components    []*Component
+}
+
+type Component struct {
+	ProductID string
+	Quantity  uint32
+}
@@ -0,0 +1,373 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type relationDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewRelationDAOPostgres(client PostgreSQLClient) RelationDAO {
+	return &relationDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme               = "public"
+	table                = "product_relation"
+	tableScheme          = scheme + "." + table
+	bundleTableScheme    = scheme + ".product_bundle"
+	componentTableScheme = scheme + ".product_bundle_component"
+	productTableScheme   = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+var errProductNotFound = errors.NotFound("product not found")
+
+// All returns relations of the product of the given types, of every type if none is given.
+// Ordered relations come first in their order, the rest follow in order of creation.
+func (s *relationDAOPostgres) All(ctx context.Context, productID string, types []string) ([]*Relation, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.queryBuilder.
+		Select("id").
+		Columns(
+			"product_id",
+			"related_id",
+			"type",
+			"position",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID, "product_id": productID}).
+		OrderBy("type", "position NULLS LAST", "created_at", "id")
+	if len(types) != 0 {
+		query = query.Where(sq.Eq{"type": types})
+	}
+
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Relation, 0)
+
+	for rows.Next() {
+		r := Relation{}
+		if err = rows.Scan(
+			&r.ID,
+			&r.ProductID,
+			&r.RelatedID,
+			&r.Type,
+			&r.Position,
+			&r.CreatedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &r)
+	}
+
+	return list, nil
+}
+
+// Create links two products of the tenant, a link of the same type between them is a conflict
+func (s *relationDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table": tableScheme,
+		"args":  m,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := s.lockProducts(ctx, tx, tenantID, m["product_id"].(string), m["related_id"].(string)); err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr := s.queryBuilder.
+			Insert(tableScheme).
+			SetMap(m).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *relationDAOPostgres) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Delete(tableScheme).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("relation not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// Bundle returns the bundle with its components in their order
+func (s *relationDAOPostgres) Bundle(ctx context.Context, productID string) (*Bundle, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("b.price_override").
+		Columns(
+			"c.product_id",
+			"c.quantity",
+		).
+		From(bundleTableScheme + " b").
+		Join(componentTableScheme + " c ON c.bundle_id = b.product_id").
+		Where(sq.Eq{"b." + tenantColumn: tenantID, "b.product_id": productID}).
+		OrderBy("c.position").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": bundleTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bundle := Bundle{ProductID: productID}
+
+	for rows.Next() {
+		c := Component{}
+		if err = rows.Scan(&bundle.PriceOverride, &c.ProductID, &c.Quantity); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		bundle.Components = append(bundle.Components, &c)
+	}
+
+	if len(bundle.Components) == 0 {
+		err = db.ErrDoQuery(errors.NotFound("bundle not found"))
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &bundle, nil
+}
+
+// SetBundle replaces the bundle of the product in a single transaction,
+// a bundle without components is removed
+func (s *relationDAOPostgres) SetBundle(ctx context.Context, bundle *Bundle) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":      bundleTableScheme,
+		"product_id": bundle.ProductID,
+	})
+
+	ids := []string{bundle.ProductID}
+	for _, c := range bundle.Components {
+		ids = append(ids, c.ProductID)
+	}
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := s.lockProducts(ctx, tx, tenantID, ids...); err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr := s.queryBuilder.
+			Delete(componentTableScheme).
+			Where(sq.Eq{"bundle_id": bundle.ProductID}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		if len(bundle.Components) == 0 {
+			sql, args, buildErr = s.queryBuilder.
+				Delete(bundleTableScheme).
+				Where(sq.Eq{tenantColumn: tenantID, "product_id": bundle.ProductID}).
+				ToSql()
+		} else {
+			sql, args, buildErr = s.queryBuilder.
+				Insert(bundleTableScheme).
+				Columns(tenantColumn, "product_id", "price_override").
+				Values(tenantID, bundle.ProductID, bundle.PriceOverride).
+				Suffix("ON CONFLICT (product_id) DO UPDATE SET price_override = EXCLUDED.price_override").
+				ToSql()
+		}
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		if len(bundle.Components) == 0 {
+			return nil
+		}
+
+		insert := s.queryBuilder.
+			Insert(componentTableScheme).
+			Columns("bundle_id", "product_id", "quantity", "position")
+		for i, c := range bundle.Components {
+			insert = insert.Values(bundle.ProductID, c.ProductID, c.Quantity, i)
+		}
+
+		sql, args, buildErr = insert.ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// lockProducts makes sure every product belongs to the tenant and is not deleted until the transaction ends
+func (s *relationDAOPostgres) lockProducts(ctx context.Context, tx pgx.Tx, tenantID string, ids ...string) error {
+	unique := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		unique[id] = true
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Select("id").
+		From(productTableScheme).
+		Where(sq.Eq{"id": ids, tenantColumn: tenantID}).
+		Suffix("FOR SHARE").
+		ToSql()
+	if buildErr != nil {
+		return db.ErrCreateQuery(buildErr)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	defer rows.Close()
+
+	found := 0
+	for rows.Next() {
+		found++
+	}
+	if err = rows.Err(); err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	if found != len(unique) {
+		return errProductNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/relation/dao"
+)
+
+const (
+	TypeRelated    = "related"
+	TypeAccessory  = "accessory"
+	TypeReplacedBy = "replaced_by"
+)
+
+// Relation is a directed link from a product to a related one.
+// Links of a type are shown in Position order, unordered ones go last.
+type Relation struct {
+	ID        string
+	ProductID string
+	RelatedID string
+	Type      string
+	Position  *int32
+	CreatedAt time.Time
+}
+
+// Bundle is a product sold as a set of other products. Its price is the sum
+// of the component prices unless it is overridden.
+type Bundle struct {
+	ProductID     string
+	Components    []*Component
+	PriceOverride *uint64
+}
+
+type Component struct {
+	ProductID string
+	Quantity  uint32
+}
+
+func IsType(relationType string) bool {
+	switch relationType {
+	case TypeRelated, TypeAccessory, TypeReplacedBy:
+		return true
+	}
+	return false
+}
+
+func NewRelationFromPB(req *pbProducts.CreateProductRelationRequest) *Relation {
+	return &Relation{
+		ID:        uuid.New().String(),
+		ProductID: req.GetProductId(),
+		RelatedID: req.GetRelatedId(),
+		Type:      req.GetType(),
+		Position:  req.Position,
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewBundleFromPB(req *pbProducts.SetProductBundleRequest) *Bundle {
+	components := make([]*Component, len(req.GetComponents()))
+	for i, c := range req.GetComponents() {
+		components[i] = &Component{
+			ProductID: c.GetProductId(),
+			Quantity:  c.GetQuantity(),
+		}
+	}
+
+	return &Bundle{
+		ProductID:     req.GetProductId(),
+		Components:    components,
+		PriceOverride: req.PriceOverride,
+	}
+}
+
+// Price returns the overridden price or the sum of the component prices.
+// It is unknown when a component has no price in prices.
+func (b *Bundle) Price(prices map[string]uint64) (uint64, bool) {
+	if b.PriceOverride != nil {
+		return *b.PriceOverride, true
+	}
+
+	var sum uint64
+	for _, c := range b.Components {
+		price, ok := prices[c.ProductID]
+		if !ok {
+			return 0, false
+		}
+		sum += price * uint64(c.Quantity)
+	}
+	return sum, true
+}
+
+func (b *Bundle) ComponentIDs() []string {
+	ids := make([]string, len(b.Components))
+	for i, c := range b.Components {
+		ids[i] = c.ProductID
+	}
+	return ids
+}
+
+func (r *Relation) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         r.ID,
+		"product_id": r.ProductID,
+		"related_id": r.RelatedID,
+		"type":       r.Type,
+		"position":   r.Position,
+		"created_at": r.CreatedAt,
+	}
+}
+
+func (r *Relation) ToProto() *pbProducts.ProductRelation {
+	return &pbProducts.ProductRelation{
+		Id:        r.ID,
+		ProductId: r.ProductID,
+		RelatedId: r.RelatedID,
+		Type:      r.Type,
+		Position:  r.Position,
+		CreatedAt: r.CreatedAt.UnixMilli(),
+	}
+}
+
+// ToProto presents the bundle with its price, zero if the price is unknown
+func (b *Bundle) ToProto(price uint64) *pbProducts.ProductBundle {
+	components := make([]*pbProducts.BundleComponent, len(b.Components))
+	for i, c := range b.Components {
+		components[i] = &pbProducts.BundleComponent{
+			ProductId: c.ProductID,
+			Quantity:  c.Quantity,
+		}
+	}
+
+	return &pbProducts.ProductBundle{
+		ProductId:     b.ProductID,
+		Components:    components,
+		PriceOverride: b.PriceOverride,
+		Price:         price,
+	}
+}
+
+func (b *Bundle) ToDAO() *dao.Bundle {
+	components := make([]*dao.Component, len(b.Components))
+	for i, c := range b.Components {
+		components[i] = &dao.Component{ProductID: c.ProductID, Quantity: c.Quantity}
+	}
+
+	bundle := &dao.Bundle{ProductID: b.ProductID, Components: components}
+	if b.PriceOverride != nil {
+		bundle.PriceOverride = sql.NullInt64{Int64: int64(*b.PriceOverride), Valid: true}
+	}
+	return bundle
+}
+
+func NewRelationFromDAO(r *dao.Relation) *Relation {
+	var position *int32
+	if r.Position.Valid {
+		position = &r.Position.Int32
+	}
+
+	return &Relation{
+		ID:        r.ID,
+		ProductID: r.ProductID,
+		RelatedID: r.RelatedID,
+		Type:      r.Type,
+		Position:  position,
+		CreatedAt: r.CreatedAt.Time,
+	}
+}
+
+func NewBundleFromDAO(b *dao.Bundle) *Bundle {
+	var priceOverride *uint64
+	if b.PriceOverride.Valid {
+		price := uint64(b.PriceOverride.Int64)
+		priceOverride = &price
+	}
+
+	components := make([]*Component, len(b.Components))
+	for i, c := range b.Components {
+		components[i] = &Component{ProductID: c.ProductID, Quantity: c.Quantity}
+	}
+
+	return &Bundle{
+		ProductID:     b.ProductID,
+		Components:    components,
+		PriceOverride: priceOverride,
+	}
+}
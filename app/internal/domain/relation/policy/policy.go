@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/relation/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/relation/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type RelationPolicy struct {
+	relationService *service.RelationService
+}
+
+func NewRelationPolicy(relationService *service.RelationService) *RelationPolicy {
+	return &RelationPolicy{relationService: relationService}
+}
+
+func (p *RelationPolicy) All(ctx context.Context, productID string, types []string) ([]*model.Relation, error) {
+	var violations errors.FieldViolations
+	for i, t := range types {
+		if !model.IsType(t) {
+			violations.Add(fmt.Sprintf("types[%d]", i), "must be one of related, accessory, replaced_by")
+		}
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	relations, err := p.relationService.All(ctx, productID, types)
+	if err != nil {
+		return nil, errors.Wrap(err, "relationService.All")
+	}
+
+	return relations, nil
+}
+
+func (p *RelationPolicy) Create(ctx context.Context, relation *model.Relation) (*model.Relation, error) {
+	var violations errors.FieldViolations
+	if relation.ProductID == "" {
+		violations.Add("product_id", "must not be empty")
+	}
+	if relation.RelatedID == "" {
+		violations.Add("related_id", "must not be empty")
+	}
+	if relation.ProductID == relation.RelatedID {
+		violations.Add("related_id", "must differ from product_id")
+	}
+	if !model.IsType(relation.Type) {
+		violations.Add("type", "must be one of related, accessory, replaced_by")
+	}
+	if relation.Position != nil && *relation.Position < 0 {
+		violations.Add("position", "must not be negative")
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.relationService.Create(ctx, relation)
+}
+
+func (p *RelationPolicy) Delete(ctx context.Context, id string) error {
+	return p.relationService.Delete(ctx, id)
+}
+
+func (p *RelationPolicy) Bundle(ctx context.Context, productID string) (*model.Bundle, error) {
+	return p.relationService.Bundle(ctx, productID)
+}
+
+// SetBundle replaces the components of the bundle, no components turn the product back into a plain one
+func (p *RelationPolicy) SetBundle(ctx context.Context, bundle *model.Bundle) error {
+	var violations errors.FieldViolations
+	seen := make(map[string]bool, len(bundle.Components))
+	for i, c := range bundle.Components {
+		field := fmt.Sprintf("components[%d]", i)
+		if c.ProductID == "" || c.ProductID == bundle.ProductID {
+			violations.Add(field+".product_id", "must be another product")
+		}
+		if seen[c.ProductID] {
+			violations.Add(field+".product_id", "must be unique")
+		}
+		if c.Quantity == 0 {
+			violations.Add(field+".quantity", "must be greater than 0")
+		}
+		seen[c.ProductID] = true
+	}
+	if len(bundle.Components) == 0 && bundle.PriceOverride != nil {
+		violations.Add("price_override", "must not be set for a bundle without components")
+	}
+	if err := violations.Err(); err != nil {
+		return err
+	}
+
+	return p.relationService.SetBundle(ctx, bundle)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/relation/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/relation/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type RelationService struct {
+	repository dao.RelationDAO
+}
+
+func NewRelationService(repository dao.RelationDAO) *RelationService {
+	return &RelationService{repository: repository}
+}
+
+func (s *RelationService) All(ctx context.Context, productID string, types []string) ([]*model.Relation, error) {
+	dbRelations, err := s.repository.All(ctx, productID, types)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.All")
+	}
+
+	relations := make([]*model.Relation, 0, len(dbRelations))
+	for _, dbR := range dbRelations {
+		relations = append(relations, model.NewRelationFromDAO(dbR))
+	}
+
+	return relations, nil
+}
+
+func (s *RelationService) Create(ctx context.Context, relation *model.Relation) (*model.Relation, error) {
+	if err := s.repository.Create(ctx, relation.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return relation, nil
+}
+
+func (s *RelationService) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *RelationService) Bundle(ctx context.Context, productID string) (*model.Bundle, error) {
+	dbBundle, err := s.repository.Bundle(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewBundleFromDAO(dbBundle), nil
+}
+
+func (s *RelationService) SetBundle(ctx context.Context, bundle *model.Bundle) error {
+	return s.repository.SetBundle(ctx, bundle.ToDAO())
+}
@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Review struct {
+	ID               string
+	ProductID        string
+	AuthorID         string
+	AuthorName       string
+	Score            uint32
+	Text             string
+	VerifiedPurchase bool
+	Status           string
+	ModeratedAt      sql.NullTime
+	CreatedAt        sql.NullTime
+}
+
+// Summary is the aggregate of the approved reviews of a product,
+// Histogram[i] counts the reviews scored i+1
+type Summary struct {
+	ProductID string
+	Count     uint64
+	ScoreSum  uint64
+	Histogram [ScoreMax]uint64
+}
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+
+	ScoreMin = 1
+	ScoreMax = 5
+)
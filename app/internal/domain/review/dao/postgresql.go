@@ -0,0 +1,402 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type reviewDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewReviewDAOPostgres(client PostgreSQLClient) ReviewDAO {
+	return &reviewDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme             = "public"
+	table              = "product_review"
+	tableScheme        = scheme + "." + table
+	summaryTable       = "product_review_summary"
+	summaryTableScheme = scheme + "." + summaryTable
+	productTableScheme = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+var errReviewNotFound = errors.NotFound("review not found")
+
+// scoreColumn is the summary column counting reviews with the score
+func scoreColumn(score uint32) string {
+	return fmt.Sprintf("score_%d", score)
+}
+
+// All returns reviews of the product in the statuses, newest first
+func (s *reviewDAOPostgres) All(
+	ctx context.Context,
+	productID string,
+	statuses []string,
+	limit, offset uint64,
+) ([]*Review, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.queryBuilder.
+		Select("id").
+		Columns(
+			"product_id",
+			"author_id",
+			"author_name",
+			"score",
+			"text",
+			"verified_purchase",
+			"status",
+			"moderated_at",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID, "product_id": productID}).
+		OrderBy("created_at DESC", "id").
+		Offset(offset)
+	if len(statuses) != 0 {
+		query = query.Where(sq.Eq{"status": statuses})
+	}
+	if limit != 0 {
+		query = query.Limit(limit)
+	}
+
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Review, 0)
+
+	for rows.Next() {
+		r := Review{}
+		if err = rows.Scan(
+			&r.ID,
+			&r.ProductID,
+			&r.AuthorID,
+			&r.AuthorName,
+			&r.Score,
+			&r.Text,
+			&r.VerifiedPurchase,
+			&r.Status,
+			&r.ModeratedAt,
+			&r.CreatedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &r)
+	}
+
+	return list, nil
+}
+
+// Create stores a review of a product of the tenant. An author reviews a product once,
+// a second review is a conflict.
+func (s *reviewDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table": tableScheme,
+		"args":  m,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Select("id").
+			From(productTableScheme).
+			Where(sq.Eq{"id": m["product_id"], tenantColumn: tenantID}).
+			Suffix("FOR SHARE").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var id string
+		err := tx.QueryRow(ctx, sql, args...).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.NotFound("product not found")
+		}
+		if err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Insert(tableScheme).
+			SetMap(m).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Moderate changes the status of the review and keeps the summary of the product in step:
+// a review counts in it while it is approved
+func (s *reviewDAOPostgres) Moderate(ctx context.Context, id, status string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":  tableScheme,
+		"id":     id,
+		"status": status,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Select("product_id").
+			Columns("score", "status").
+			From(tableScheme).
+			Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+			Suffix("FOR UPDATE").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var productID, previous string
+		var score uint32
+		err := tx.QueryRow(ctx, sql, args...).Scan(&productID, &score, &previous)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errReviewNotFound
+		}
+		if err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Update(tableScheme).
+			Set("status", status).
+			Set("moderated_at", sq.Expr("NOW()")).
+			Where(sq.Eq{"id": id}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		var delta int64
+		switch {
+		case previous != StatusApproved && status == StatusApproved:
+			delta = 1
+		case previous == StatusApproved && status != StatusApproved:
+			delta = -1
+		default:
+			return nil
+		}
+
+		if err := s.applyToSummary(ctx, tx, tenantID, productID, score, delta); err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Delete removes the review, an approved one is taken out of the summary
+func (s *reviewDAOPostgres) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table": tableScheme,
+		"id":    id,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Delete(tableScheme).
+			Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+			Suffix("RETURNING product_id, score, status").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var productID, status string
+		var score uint32
+		err := tx.QueryRow(ctx, sql, args...).Scan(&productID, &score, &status)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errReviewNotFound
+		}
+		if err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		if status != StatusApproved {
+			return nil
+		}
+
+		if err := s.applyToSummary(ctx, tx, tenantID, productID, score, -1); err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// applyToSummary adds delta reviews with the score to the summary of the product
+func (s *reviewDAOPostgres) applyToSummary(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, productID string,
+	score uint32,
+	delta int64,
+) error {
+	columns := []string{tenantColumn, "product_id", "review_count", "score_sum"}
+	values := []interface{}{tenantID, productID, delta, delta * int64(score)}
+	updates := fmt.Sprintf(
+		"review_count = %[1]s.review_count + EXCLUDED.review_count, score_sum = %[1]s.score_sum + EXCLUDED.score_sum",
+		summaryTable,
+	)
+	for sc := uint32(ScoreMin); sc <= ScoreMax; sc++ {
+		var count int64
+		if sc == score {
+			count = delta
+		}
+		columns = append(columns, scoreColumn(sc))
+		values = append(values, count)
+		updates += fmt.Sprintf(", %[2]s = %[1]s.%[2]s + EXCLUDED.%[2]s", summaryTable, scoreColumn(sc))
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(summaryTableScheme).
+		Columns(columns...).
+		Values(values...).
+		Suffix("ON CONFLICT (product_id) DO UPDATE SET " + updates).
+		ToSql()
+	if buildErr != nil {
+		return db.ErrCreateQuery(buildErr)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	return nil
+}
+
+// Summary returns the aggregate of the approved reviews, an empty one for a product without them
+func (s *reviewDAOPostgres) Summary(ctx context.Context, productID string) (*Summary, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []string{"score_sum"}
+	for sc := uint32(ScoreMin); sc <= ScoreMax; sc++ {
+		columns = append(columns, scoreColumn(sc))
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("review_count").
+		Columns(columns...).
+		From(summaryTableScheme).
+		Where(sq.Eq{tenantColumn: tenantID, "product_id": productID}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": summaryTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	summary := Summary{ProductID: productID}
+	dest := []interface{}{&summary.Count, &summary.ScoreSum}
+	for i := range summary.Histogram {
+		dest = append(dest, &summary.Histogram[i])
+	}
+
+	err = s.client.QueryRow(ctx, sql, args...).Scan(dest...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &summary, nil
+	}
+	if err != nil {
+		err = db.ErrScan(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &summary, nil
+}
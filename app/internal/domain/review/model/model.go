@@ -0,0 +1,125 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/review/dao"
+)
+
+// Review is a customer's opinion of a product. It is shown to customers
+// and counts in the product rating once approved by a moderator.
+type Review struct {
+	ID               string
+	ProductID        string
+	AuthorID         string
+	AuthorName       string
+	Score            uint32
+	Text             string
+	VerifiedPurchase bool
+	Status           string
+	ModeratedAt      *time.Time
+	CreatedAt        time.Time
+}
+
+// Summary aggregates the approved reviews of a product,
+// Histogram[i] counts the reviews scored i+1
+type Summary struct {
+	ProductID string
+	Count     uint64
+	Average   float64
+	Histogram []uint64
+}
+
+func NewReviewFromPB(req *pbProducts.CreateReviewRequest) *Review {
+	return &Review{
+		ID:               uuid.New().String(),
+		ProductID:        req.GetProductId(),
+		AuthorID:         req.GetAuthorId(),
+		AuthorName:       req.GetAuthorName(),
+		Score:            req.GetScore(),
+		Text:             req.GetText(),
+		VerifiedPurchase: req.GetVerifiedPurchase(),
+		Status:           dao.StatusPending,
+		CreatedAt:        time.Now(),
+	}
+}
+
+func (r *Review) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":                r.ID,
+		"product_id":        r.ProductID,
+		"author_id":         r.AuthorID,
+		"author_name":       r.AuthorName,
+		"score":             r.Score,
+		"text":              r.Text,
+		"verified_purchase": r.VerifiedPurchase,
+		"status":            r.Status,
+		"created_at":        r.CreatedAt,
+	}
+}
+
+func (r *Review) ToProto() *pbProducts.Review {
+	var moderatedAt *int64
+	if r.ModeratedAt != nil {
+		ms := r.ModeratedAt.UnixMilli()
+		moderatedAt = &ms
+	}
+
+	return &pbProducts.Review{
+		Id:               r.ID,
+		ProductId:        r.ProductID,
+		AuthorId:         r.AuthorID,
+		AuthorName:       r.AuthorName,
+		Score:            r.Score,
+		Text:             r.Text,
+		VerifiedPurchase: r.VerifiedPurchase,
+		Status:           r.Status,
+		ModeratedAt:      moderatedAt,
+		CreatedAt:        r.CreatedAt.UnixMilli(),
+	}
+}
+
+func (s *Summary) ToProto() *pbProducts.ReviewSummary {
+	return &pbProducts.ReviewSummary{
+		ProductId: s.ProductID,
+		Count:     s.Count,
+		Average:   s.Average,
+		Histogram: s.Histogram,
+	}
+}
+
+func NewReviewFromDAO(r *dao.Review) *Review {
+	var moderatedAt *time.Time
+	if r.ModeratedAt.Valid {
+		moderatedAt = &r.ModeratedAt.Time
+	}
+
+	return &Review{
+		ID:               r.ID,
+		ProductID:        r.ProductID,
+		AuthorID:         r.AuthorID,
+		AuthorName:       r.AuthorName,
+		Score:            r.Score,
+		Text:             r.Text,
+		VerifiedPurchase: r.VerifiedPurchase,
+		Status:           r.Status,
+		ModeratedAt:      moderatedAt,
+		CreatedAt:        r.CreatedAt.Time,
+	}
+}
+
+func NewSummaryFromDAO(s *dao.Summary) *Summary {
+	var average float64
+	if s.Count != 0 {
+		average = float64(s.ScoreSum) / float64(s.Count)
+	}
+
+	return &Summary{
+		ProductID: s.ProductID,
+		Count:     s.Count,
+		Average:   average,
+		Histogram: append([]uint64(nil), s.Histogram[:]...),
+	}
+}
@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	apiKeyModel "github.com/ilkinabd/goods-manager/app/internal/domain/apikey/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/review/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/review/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/review/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+const (
+	AuthorNameMaxLength = 100
+	TextMaxLength       = 5000
+
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type ReviewPolicy struct {
+	reviewService *service.ReviewService
+	adminRoleID   uint64
+}
+
+func NewReviewPolicy(reviewService *service.ReviewService, adminRoleID uint64) *ReviewPolicy {
+	return &ReviewPolicy{
+		reviewService: reviewService,
+		adminRoleID:   adminRoleID,
+	}
+}
+
+// moderator reports whether the caller is an admin or an integration allowed to change
+// the catalog, which may see every review and vouch for purchases
+func (p *ReviewPolicy) moderator(ctx context.Context) bool {
+	return jwt.HasRole(ctx, p.adminRoleID) || jwt.HasScope(ctx, apiKeyModel.ScopeProductsWrite)
+}
+
+// All lists reviews of the product page by page. Customers see approved reviews only,
+// moderators may filter by status.
+func (p *ReviewPolicy) All(
+	ctx context.Context,
+	productID, status string,
+	limit, offset uint64,
+) ([]*model.Review, error) {
+	var violations errors.FieldViolations
+	if status != "" && !isStatus(status) {
+		violations.Add("status", "must be one of pending, approved, rejected")
+	}
+	if limit > maxLimit {
+		violations.Add("limit", fmt.Sprintf("must not exceed %d", maxLimit))
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	var statuses []string
+	switch {
+	case !p.moderator(ctx):
+		statuses = []string{dao.StatusApproved}
+	case status != "":
+		statuses = []string{status}
+	}
+
+	reviews, err := p.reviewService.All(ctx, productID, statuses, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "reviewService.All")
+	}
+
+	return reviews, nil
+}
+
+// Create accepts a review for moderation. Customers review on their own behalf and
+// can not claim a verified purchase, integrations name the author and vouch for it.
+// API keys need the products:write scope to create reviews.
+func (p *ReviewPolicy) Create(ctx context.Context, review *model.Review) (*model.Review, error) {
+	if _, ok := jwt.GetAPIKeyID(ctx); !ok {
+		userID, err := jwt.GetUserID(ctx)
+		if err != nil {
+			return nil, errors.Unauthenticated("sign in to review products")
+		}
+		review.AuthorID = userID
+		review.VerifiedPurchase = false
+	}
+
+	var violations errors.FieldViolations
+	if review.AuthorID == "" {
+		violations.Add("author_id", "must not be empty")
+	}
+	nameLength := utf8.RuneCountInString(review.AuthorName)
+	if nameLength == 0 || nameLength > AuthorNameMaxLength {
+		violations.Add("author_name", fmt.Sprintf("must be from 1 to %d characters long", AuthorNameMaxLength))
+	}
+	if review.Score < dao.ScoreMin || review.Score > dao.ScoreMax {
+		violations.Add("score", fmt.Sprintf("must be from %d to %d", dao.ScoreMin, dao.ScoreMax))
+	}
+	if utf8.RuneCountInString(review.Text) > TextMaxLength {
+		violations.Add("text", fmt.Sprintf("must be at most %d characters long", TextMaxLength))
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.reviewService.Create(ctx, review)
+}
+
+// Moderate approves or rejects a review, an approved review can be rejected later and vice versa
+func (p *ReviewPolicy) Moderate(ctx context.Context, id, status string) error {
+	if status != dao.StatusApproved && status != dao.StatusRejected {
+		var violations errors.FieldViolations
+		violations.Add("status", "must be one of approved, rejected")
+		return violations.Err()
+	}
+
+	return p.reviewService.Moderate(ctx, id, status)
+}
+
+func (p *ReviewPolicy) Delete(ctx context.Context, id string) error {
+	return p.reviewService.Delete(ctx, id)
+}
+
+func (p *ReviewPolicy) Summary(ctx context.Context, productID string) (*model.Summary, error) {
+	return p.reviewService.Summary(ctx, productID)
+}
+
+func isStatus(status string) bool {
+	switch status {
+	case dao.StatusPending, dao.StatusApproved, dao.StatusRejected:
+		return true
+	}
+	return false
+}
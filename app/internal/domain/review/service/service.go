@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/review/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/review/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type ReviewService struct {
+	repository dao.ReviewDAO
+}
+
+func NewReviewService(repository dao.ReviewDAO) *ReviewService {
+	return &ReviewService{repository: repository}
+}
+
+func (s *ReviewService) All(
+	ctx context.Context,
+	productID string,
+	statuses []string,
+	limit, offset uint64,
+) ([]*model.Review, error) {
+	dbReviews, err := s.repository.All(ctx, productID, statuses, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.All")
+	}
+
+	reviews := make([]*model.Review, 0, len(dbReviews))
+	for _, dbR := range dbReviews {
+		reviews = append(reviews, model.NewReviewFromDAO(dbR))
+	}
+
+	return reviews, nil
+}
+
+func (s *ReviewService) Create(ctx context.Context, review *model.Review) (*model.Review, error) {
+	if err := s.repository.Create(ctx, review.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (s *ReviewService) Moderate(ctx context.Context, id, status string) error {
+	return s.repository.Moderate(ctx, id, status)
+}
+
+func (s *ReviewService) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *ReviewService) Summary(ctx context.Context, productID string) (*model.Summary, error) {
+	dbSummary, err := s.repository.Summary(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Summary")
+	}
+
+	return model.NewSummaryFromDAO(dbSummary), nil
+}
@@ -0,0 +1,23 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type SlugDAO interface {
+	Assign(ctx context.Context, productID, locale, slug string) error
+	AssignTx(ctx context.Context, tx pgx.Tx, productID, locale, slug string) error
+	Find(ctx context.Context, slug string, locales []string) (*Slug, error)
+	Current(ctx context.Context, productIDs []string, locales []string) ([]*Slug, error)
+	History(ctx context.Context, productID string) ([]*Slug, error)
+}
@@ -0,0 +1,13 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Slug struct {
+	ProductID string
+	Locale    string
+	Slug      string
+	IsCurrent bool
+	CreatedAt sql.NullTime
+}
@@ -0,0 +1,258 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type slugDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewSlugDAOPostgres(client PostgreSQLClient) SlugDAO {
+	return &slugDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme             = "public"
+	table              = "product_slug"
+	tableScheme        = scheme + "." + table
+	productTableScheme = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+// ErrSlugTaken is returned when the slug belongs, or used to belong, to another product
+var ErrSlugTaken = errors.Conflict("slug is taken")
+
+func (s *slugDAOPostgres) selectQuery(tenantID string) sq.SelectBuilder {
+	return s.queryBuilder.
+		Select("product_id").
+		Columns(
+			"locale",
+			"slug",
+			"is_current",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID})
+}
+
+// Assign makes the slug the current one of the product in the locale. The previous
+// current slug stays as a redirect, an own former slug is brought back to current.
+func (s *slugDAOPostgres) Assign(ctx context.Context, productID, locale, slug string) error {
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return s.AssignTx(ctx, tx, productID, locale, slug)
+	})
+}
+
+// AssignTx is Assign in the transaction of the caller, e.g. the one writing the product
+func (s *slugDAOPostgres) AssignTx(ctx context.Context, tx pgx.Tx, productID, locale, slug string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":      tableScheme,
+		"product_id": productID,
+		"locale":     locale,
+		"slug":       slug,
+	})
+
+	sql, args, buildErr := s.queryBuilder.
+		Select("id").
+		From(productTableScheme).
+		Where(sq.Eq{"id": productID, tenantColumn: tenantID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	var id string
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return err
+	}
+
+	sql, args, buildErr = s.queryBuilder.
+		Select("product_id").
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID, "locale": locale, "slug": slug}).
+		ToSql()
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	var owner string
+	err = tx.QueryRow(ctx, sql, args...).Scan(&owner)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return err
+	case owner != productID:
+		return ErrSlugTaken
+	}
+
+	sql, args, buildErr = s.queryBuilder.
+		Update(tableScheme).
+		Set("is_current", false).
+		Where(sq.Eq{tenantColumn: tenantID, "product_id": productID, "locale": locale, "is_current": true}).
+		ToSql()
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return err
+	}
+
+	sql, args, buildErr = s.queryBuilder.
+		Insert(tableScheme).
+		Columns(tenantColumn, "product_id", "locale", "slug", "is_current", "created_at").
+		Values(tenantID, productID, locale, slug, true, sq.Expr("NOW()")).
+		Suffix("ON CONFLICT (" + tenantColumn + ", locale, slug) DO UPDATE SET is_current = true").
+		ToSql()
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	// a concurrent assignment of the same slug ends in a unique violation, i.e. a conflict
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Find returns the slug in the first of the locales it exists in
+func (s *slugDAOPostgres) Find(ctx context.Context, slug string, locales []string) (*Slug, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.selectQuery(tenantID).
+		Where(sq.Eq{"slug": slug, "locale": locales}).
+		OrderByClause("array_position(?::text[], locale)", locales).
+		Limit(1).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	var sl Slug
+	if err = s.client.QueryRow(ctx, sql, args...).Scan(
+		&sl.ProductID,
+		&sl.Locale,
+		&sl.Slug,
+		&sl.IsCurrent,
+		&sl.CreatedAt,
+	); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &sl, nil
+}
+
+func (s *slugDAOPostgres) Current(ctx context.Context, productIDs []string, locales []string) ([]*Slug, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, s.selectQuery(tenantID).
+		Where(sq.Eq{"product_id": productIDs, "locale": locales, "is_current": true}))
+}
+
+func (s *slugDAOPostgres) History(ctx context.Context, productID string) ([]*Slug, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, s.selectQuery(tenantID).
+		Where(sq.Eq{"product_id": productID}).
+		OrderBy("locale", "is_current DESC", "created_at DESC"))
+}
+
+func (s *slugDAOPostgres) list(ctx context.Context, query sq.SelectBuilder) ([]*Slug, error) {
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Slug, 0)
+
+	for rows.Next() {
+		sl := Slug{}
+		if err = rows.Scan(
+			&sl.ProductID,
+			&sl.Locale,
+			&sl.Slug,
+			&sl.IsCurrent,
+			&sl.CreatedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &sl)
+	}
+
+	return list, nil
+}
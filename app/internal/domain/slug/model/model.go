@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/slug/dao"
+)
+
+// Slug addresses a product in a locale. Former slugs of a product are kept
+// as redirects to the current one.
+type Slug struct {
+	ProductID string
+	Locale    string
+	Slug      string
+	IsCurrent bool
+	CreatedAt time.Time
+}
+
+// Lookup is the product found by a slug and the slug it has to be redirected to, if any
+type Lookup struct {
+	ProductID   string
+	Locale      string
+	CurrentSlug string
+	Redirect    bool
+}
+
+func (s *Slug) ToProto() *pbProducts.ProductSlug {
+	return &pbProducts.ProductSlug{
+		ProductId: s.ProductID,
+		Locale:    s.Locale,
+		Slug:      s.Slug,
+		IsCurrent: s.IsCurrent,
+		CreatedAt: s.CreatedAt.UnixMilli(),
+	}
+}
+
+func NewSlugFromDAO(s *dao.Slug) *Slug {
+	return &Slug{
+		ProductID: s.ProductID,
+		Locale:    s.Locale,
+		Slug:      s.Slug,
+		IsCurrent: s.IsCurrent,
+		CreatedAt: s.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,63 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/slug/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/slug/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/locale"
+	"github.com/ilkinabd/goods-manager/app/pkg/slug"
+)
+
+type SlugPolicy struct {
+	slugService *service.SlugService
+	resolver    *locale.Resolver
+}
+
+func NewSlugPolicy(slugService *service.SlugService, resolver *locale.Resolver) *SlugPolicy {
+	return &SlugPolicy{slugService: slugService, resolver: resolver}
+}
+
+// Set assigns an edited slug, the default locale is used when none is given
+func (p *SlugPolicy) Set(ctx context.Context, productID, l, sl string) error {
+	if l == "" {
+		l = p.resolver.Default()
+	}
+
+	var violations errors.FieldViolations
+	if !slug.Valid(sl) {
+		violations.Add("slug", "must consist of lower case latin letters, digits and single dashes")
+	}
+	if !p.resolver.IsSupported(l) {
+		violations.Add("locale", "is not supported")
+	}
+	if err := violations.Err(); err != nil {
+		return err
+	}
+
+	return p.slugService.Set(ctx, productID, l, sl)
+}
+
+// Lookup finds the slug along the fallback chain of the locale in context
+func (p *SlugPolicy) Lookup(ctx context.Context, sl string) (*model.Lookup, error) {
+	lookup, err := p.slugService.Lookup(ctx, sl, p.chain(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "slugService.Lookup")
+	}
+
+	return lookup, nil
+}
+
+func (p *SlugPolicy) Current(ctx context.Context, productIDs []string) (map[string]string, error) {
+	return p.slugService.Current(ctx, p.chain(ctx), productIDs)
+}
+
+func (p *SlugPolicy) History(ctx context.Context, productID string) ([]*model.Slug, error) {
+	return p.slugService.History(ctx, productID)
+}
+
+func (p *SlugPolicy) chain(ctx context.Context) []string {
+	l, _ := locale.FromContext(ctx)
+	return p.resolver.Chain(l)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/slug/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/slug/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/slug"
+	"github.com/jackc/pgx/v4"
+)
+
+// maxAttempts bounds the numbered candidates tried for a taken slug
+const maxAttempts = 50
+
+type SlugService struct {
+	repository    dao.SlugDAO
+	defaultLocale string
+}
+
+func NewSlugService(repository dao.SlugDAO, defaultLocale string) *SlugService {
+	return &SlugService{repository: repository, defaultLocale: defaultLocale}
+}
+
+// Generate assigns a slug made from the name in the default locale in the transaction
+// writing the product. A taken slug gets a number appended, e.g. iphone-15-2.
+func (s *SlugService) Generate(ctx context.Context, tx pgx.Tx, productID, name string) error {
+	base := slug.Make(name)
+	if base == "" {
+		return nil
+	}
+
+	current, err := s.repository.Current(ctx, []string{productID}, []string{s.defaultLocale})
+	if err != nil {
+		return errors.Wrap(err, "repository.Current")
+	}
+	if len(current) != 0 && current[0].Slug == base {
+		return nil
+	}
+
+	candidate := base
+	for attempt := 2; attempt <= maxAttempts+1; attempt++ {
+		// every attempt gets a savepoint, a slug taken concurrently fails the statement
+		// and would abort the transaction of the product otherwise
+		err = tx.BeginFunc(ctx, func(attemptTx pgx.Tx) error {
+			return s.repository.AssignTx(ctx, attemptTx, productID, s.defaultLocale, candidate)
+		})
+		if errors.KindOf(err) != errors.KindConflict {
+			return err
+		}
+		candidate = slug.WithSuffix(base, attempt)
+	}
+
+	return errors.Wrap(err, "no free slug for "+base)
+}
+
+func (s *SlugService) Set(ctx context.Context, productID, locale, sl string) error {
+	return s.repository.Assign(ctx, productID, locale, sl)
+}
+
+// Lookup finds the slug in the first locale of the chain having it
+func (s *SlugService) Lookup(ctx context.Context, sl string, chain []string) (*model.Lookup, error) {
+	found, err := s.repository.Find(ctx, sl, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := &model.Lookup{
+		ProductID:   found.ProductID,
+		Locale:      found.Locale,
+		CurrentSlug: found.Slug,
+		Redirect:    !found.IsCurrent,
+	}
+	if !lookup.Redirect {
+		return lookup, nil
+	}
+
+	current, err := s.repository.Current(ctx, []string{found.ProductID}, []string{found.Locale})
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Current")
+	}
+	if len(current) != 0 {
+		lookup.CurrentSlug = current[0].Slug
+	}
+
+	return lookup, nil
+}
+
+// Current returns for every product its current slug in the first locale of the chain it has one in
+func (s *SlugService) Current(ctx context.Context, chain []string, productIDs []string) (map[string]string, error) {
+	current := make(map[string]string, len(productIDs))
+	if len(productIDs) == 0 {
+		return current, nil
+	}
+
+	dbSlugs, err := s.repository.Current(ctx, productIDs, chain)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Current")
+	}
+
+	rank := make(map[string]int, len(chain))
+	for i, l := range chain {
+		rank[l] = i
+	}
+
+	best := make(map[string]int, len(productIDs))
+	for _, dbS := range dbSlugs {
+		if r, ok := best[dbS.ProductID]; !ok || rank[dbS.Locale] < r {
+			best[dbS.ProductID] = rank[dbS.Locale]
+			current[dbS.ProductID] = dbS.Slug
+		}
+	}
+
+	return current, nil
+}
+
+func (s *SlugService) History(ctx context.Context, productID string) ([]*model.Slug, error) {
+	dbSlugs, err := s.repository.History(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.History")
+	}
+
+	slugs := make([]*model.Slug, 0, len(dbSlugs))
+	for _, dbS := range dbSlugs {
+		slugs = append(slugs, model.NewSlugFromDAO(dbS))
+	}
+
+	return slugs, nil
+}
@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type TagDAO interface {
+	All(context.Context) ([]*Tag, error)
+	Create(context.Context, map[string]interface{}) error
+	Delete(context.Context, string) error
+	Assign(ctx context.Context, productIDs, tagIDs []string) error
+	Unassign(ctx context.Context, productIDs, tagIDs []string) error
+	ProductTags(ctx context.Context, productIDs []string) ([]*ProductTag, error)
+	Cloud(ctx context.Context, statuses []string) ([]*TagCount, error)
+}
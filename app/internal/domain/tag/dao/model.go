@@ -0,0 +1,22 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Tag struct {
+	ID        string
+	Name      string
+	Slug      string
+	CreatedAt sql.NullTime
+}
+
+type ProductTag struct {
+	ProductID string
+	Tag
+}
+
+type TagCount struct {
+	Tag
+	ProductCount uint64
+}
@@ -0,0 +1,426 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type tagDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewTagDAOPostgres(client PostgreSQLClient) TagDAO {
+	return &tagDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme             = "public"
+	table              = "tag"
+	tableScheme        = scheme + "." + table
+	linkTableScheme    = scheme + ".product_tag"
+	productTableScheme = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+func (s *tagDAOPostgres) All(ctx context.Context) ([]*Tag, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("id").
+		Columns(
+			"name",
+			"slug",
+			"created_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID}).
+		OrderBy("slug").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Tag, 0)
+
+	for rows.Next() {
+		t := Tag{}
+		if err = rows.Scan(&t.ID, &t.Name, &t.Slug, &t.CreatedAt); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &t)
+	}
+
+	return list, nil
+}
+
+// Create stores a tag, a tag with the same slug is a conflict
+func (s *tagDAOPostgres) Create(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(tableScheme).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// Delete removes the tag together with its links to products
+func (s *tagDAOPostgres) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table": tableScheme,
+		"id":    id,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Delete(tableScheme).
+			Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if exec, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		} else if exec.RowsAffected() == 0 {
+			return errors.NotFound("tag not found")
+		}
+
+		sql, args, buildErr = s.queryBuilder.
+			Delete(linkTableScheme).
+			Where(sq.Eq{"tag_id": id}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Assign links every product to every tag, existing links are kept as they are
+func (s *tagDAOPostgres) Assign(ctx context.Context, productIDs, tagIDs []string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":       linkTableScheme,
+		"product_ids": productIDs,
+		"tag_ids":     tagIDs,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := s.ensureOwned(ctx, tx, productTableScheme, tenantID, productIDs, "product not found"); err != nil {
+			logger.Error(err)
+			return err
+		}
+		if err := s.ensureOwned(ctx, tx, tableScheme, tenantID, tagIDs, "tag not found"); err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		insert := s.queryBuilder.
+			Insert(linkTableScheme).
+			Columns("product_id", "tag_id").
+			Suffix("ON CONFLICT DO NOTHING")
+		for _, productID := range productIDs {
+			for _, tagID := range tagIDs {
+				insert = insert.Values(productID, tagID)
+			}
+		}
+
+		sql, args, buildErr := insert.ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Unassign removes the links between the products and the tags, missing links are ignored
+func (s *tagDAOPostgres) Unassign(ctx context.Context, productIDs, tagIDs []string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	tenantTags := sq.Select("id").
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID})
+
+	sql, args, buildErr := s.queryBuilder.
+		Delete(linkTableScheme).
+		Where(sq.Eq{"product_id": productIDs, "tag_id": tagIDs}).
+		Where(sq.Expr("tag_id IN (?)", tenantTags)).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": linkTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// ProductTags returns the tags of the products with a single query
+func (s *tagDAOPostgres) ProductTags(ctx context.Context, productIDs []string) ([]*ProductTag, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("pt.product_id").
+		Columns(
+			"t.id",
+			"t.name",
+			"t.slug",
+			"t.created_at",
+		).
+		From(linkTableScheme + " pt").
+		Join(tableScheme + " t ON t.id = pt.tag_id").
+		Where(sq.Eq{"t." + tenantColumn: tenantID, "pt.product_id": productIDs}).
+		OrderBy("t.slug").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": linkTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*ProductTag, 0)
+
+	for rows.Next() {
+		pt := ProductTag{}
+		if err = rows.Scan(&pt.ProductID, &pt.ID, &pt.Name, &pt.Slug, &pt.CreatedAt); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &pt)
+	}
+
+	return list, nil
+}
+
+// Cloud counts the products in the statuses every tag is assigned to,
+// products in any status when none is given. Tags without such products are left out.
+func (s *tagDAOPostgres) Cloud(ctx context.Context, statuses []string) ([]*TagCount, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.queryBuilder.
+		Select("t.id").
+		Columns(
+			"t.name",
+			"t.slug",
+			"t.created_at",
+			"COUNT(*)",
+		).
+		From(tableScheme+" t").
+		Join(linkTableScheme+" pt ON pt.tag_id = t.id").
+		Join(productTableScheme+" p ON p.id = pt.product_id").
+		Where(sq.Eq{"t." + tenantColumn: tenantID}).
+		GroupBy("t.id").
+		OrderBy("COUNT(*) DESC", "t.slug")
+	if len(statuses) != 0 {
+		query = query.Where(sq.Eq{"p.status": statuses})
+	}
+
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*TagCount, 0)
+
+	for rows.Next() {
+		tc := TagCount{}
+		if err = rows.Scan(&tc.ID, &tc.Name, &tc.Slug, &tc.CreatedAt, &tc.ProductCount); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &tc)
+	}
+
+	return list, nil
+}
+
+// ensureOwned makes sure every row of the table with the ids belongs to the tenant
+// and stays until the transaction ends
+func (s *tagDAOPostgres) ensureOwned(
+	ctx context.Context,
+	tx pgx.Tx,
+	tableScheme, tenantID string,
+	ids []string,
+	notFound string,
+) error {
+	unique := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		unique[id] = true
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Select("id").
+		From(tableScheme).
+		Where(sq.Eq{"id": ids, tenantColumn: tenantID}).
+		Suffix("FOR SHARE").
+		ToSql()
+	if buildErr != nil {
+		return db.ErrCreateQuery(buildErr)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	defer rows.Close()
+
+	found := 0
+	for rows.Next() {
+		found++
+	}
+	if err = rows.Err(); err != nil {
+		return db.ErrDoQuery(err)
+	}
+
+	if found != len(unique) {
+		return errors.NotFound(notFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tag/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/slug"
+)
+
+// Tag is a label cutting across categories, e.g. "eco" or "gift-idea".
+// Products are filtered by the slug of the tag.
+type Tag struct {
+	ID        string
+	Name      string
+	Slug      string
+	CreatedAt time.Time
+}
+
+// TagCount is a tag of the tag cloud with the number of products it labels
+type TagCount struct {
+	Tag
+	ProductCount uint64
+}
+
+func NewTagFromPB(req *pbProducts.CreateTagRequest) *Tag {
+	return &Tag{
+		ID:        uuid.New().String(),
+		Name:      req.GetName(),
+		Slug:      slug.Make(req.GetName()),
+		CreatedAt: time.Now(),
+	}
+}
+
+func (t *Tag) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         t.ID,
+		"name":       t.Name,
+		"slug":       t.Slug,
+		"created_at": t.CreatedAt,
+	}
+}
+
+func (t *Tag) ToProto() *pbProducts.Tag {
+	return &pbProducts.Tag{
+		Id:        t.ID,
+		Name:      t.Name,
+		Slug:      t.Slug,
+		CreatedAt: t.CreatedAt.UnixMilli(),
+	}
+}
+
+func (tc *TagCount) ToProto() *pbProducts.TagCount {
+	return &pbProducts.TagCount{
+		Tag:          tc.Tag.ToProto(),
+		ProductCount: tc.ProductCount,
+	}
+}
+
+func NewTagFromDAO(t *dao.Tag) *Tag {
+	return &Tag{
+		ID:        t.ID,
+		Name:      t.Name,
+		Slug:      t.Slug,
+		CreatedAt: t.CreatedAt.Time,
+	}
+}
+
+// NewProductTagsFromDAO groups the tags by product
+func NewProductTagsFromDAO(productTags []*dao.ProductTag) map[string][]*Tag {
+	tags := make(map[string][]*Tag)
+	for _, pt := range productTags {
+		tags[pt.ProductID] = append(tags[pt.ProductID], NewTagFromDAO(&pt.Tag))
+	}
+	return tags
+}
+
+func NewTagCountFromDAO(tc *dao.TagCount) *TagCount {
+	return &TagCount{
+		Tag:          *NewTagFromDAO(&tc.Tag),
+		ProductCount: tc.ProductCount,
+	}
+}
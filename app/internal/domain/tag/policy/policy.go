@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tag/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tag/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+const (
+	NameMaxLength = 64
+
+	// maxAssignments limits the links made or removed by a single bulk request
+	maxAssignments = 10000
+)
+
+type TagPolicy struct {
+	tagService *service.TagService
+}
+
+func NewTagPolicy(tagService *service.TagService) *TagPolicy {
+	return &TagPolicy{tagService: tagService}
+}
+
+func (p *TagPolicy) All(ctx context.Context) ([]*model.Tag, error) {
+	tags, err := p.tagService.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "tagService.All")
+	}
+
+	return tags, nil
+}
+
+func (p *TagPolicy) Create(ctx context.Context, tag *model.Tag) (*model.Tag, error) {
+	var violations errors.FieldViolations
+	if utf8.RuneCountInString(tag.Name) > NameMaxLength {
+		violations.Add("name", fmt.Sprintf("must be at most %d characters long", NameMaxLength))
+	}
+	if tag.Slug == "" {
+		violations.Add("name", "must contain letters or digits")
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.tagService.Create(ctx, tag)
+}
+
+func (p *TagPolicy) Delete(ctx context.Context, id string) error {
+	return p.tagService.Delete(ctx, id)
+}
+
+func (p *TagPolicy) Assign(ctx context.Context, productIDs, tagIDs []string) error {
+	if err := validateAssignment(productIDs, tagIDs); err != nil {
+		return err
+	}
+
+	return p.tagService.Assign(ctx, productIDs, tagIDs)
+}
+
+func (p *TagPolicy) Unassign(ctx context.Context, productIDs, tagIDs []string) error {
+	if err := validateAssignment(productIDs, tagIDs); err != nil {
+		return err
+	}
+
+	return p.tagService.Unassign(ctx, productIDs, tagIDs)
+}
+
+func (p *TagPolicy) ProductTags(ctx context.Context, productIDs []string) (map[string][]*model.Tag, error) {
+	return p.tagService.ProductTags(ctx, productIDs)
+}
+
+// Cloud counts products in the statuses per tag, in every status when none is given
+func (p *TagPolicy) Cloud(ctx context.Context, statuses []string) ([]*model.TagCount, error) {
+	return p.tagService.Cloud(ctx, statuses)
+}
+
+func validateAssignment(productIDs, tagIDs []string) error {
+	var violations errors.FieldViolations
+	if len(productIDs) == 0 {
+		violations.Add("product_ids", "must not be empty")
+	}
+	if len(tagIDs) == 0 {
+		violations.Add("tag_ids", "must not be empty")
+	}
+	if len(productIDs)*len(tagIDs) > maxAssignments {
+		violations.Add("product_ids", fmt.Sprintf("must not make more than %d links at once", maxAssignments))
+	}
+	return violations.Err()
+}
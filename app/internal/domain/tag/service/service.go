@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tag/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tag/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type TagService struct {
+	repository dao.TagDAO
+}
+
+func NewTagService(repository dao.TagDAO) *TagService {
+	return &TagService{repository: repository}
+}
+
+func (s *TagService) All(ctx context.Context) ([]*model.Tag, error) {
+	dbTags, err := s.repository.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.All")
+	}
+
+	tags := make([]*model.Tag, 0, len(dbTags))
+	for _, dbT := range dbTags {
+		tags = append(tags, model.NewTagFromDAO(dbT))
+	}
+
+	return tags, nil
+}
+
+func (s *TagService) Create(ctx context.Context, tag *model.Tag) (*model.Tag, error) {
+	if err := s.repository.Create(ctx, tag.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *TagService) Assign(ctx context.Context, productIDs, tagIDs []string) error {
+	return s.repository.Assign(ctx, productIDs, tagIDs)
+}
+
+func (s *TagService) Unassign(ctx context.Context, productIDs, tagIDs []string) error {
+	return s.repository.Unassign(ctx, productIDs, tagIDs)
+}
+
+// ProductTags returns the tags of every product which has them
+func (s *TagService) ProductTags(ctx context.Context, productIDs []string) (map[string][]*model.Tag, error) {
+	if len(productIDs) == 0 {
+		return map[string][]*model.Tag{}, nil
+	}
+
+	productTags, err := s.repository.ProductTags(ctx, productIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.ProductTags")
+	}
+
+	return model.NewProductTagsFromDAO(productTags), nil
+}
+
+func (s *TagService) Cloud(ctx context.Context, statuses []string) ([]*model.TagCount, error) {
+	dbCounts, err := s.repository.Cloud(ctx, statuses)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Cloud")
+	}
+
+	counts := make([]*model.TagCount, 0, len(dbCounts))
+	for _, dbC := range dbCounts {
+		counts = append(counts, model.NewTagCountFromDAO(dbC))
+	}
+
+	return counts, nil
+}
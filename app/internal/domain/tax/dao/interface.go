@@ -0,0 +1,33 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type TaxDAO interface {
+	AllClasses(context.Context) ([]*Class, error)
+	CreateClass(context.Context, map[string]interface{}) error
+	ClassExists(context.Context, string) (bool, error)
+	DeleteClass(context.Context, string) error
+	SetCategoryClass(ctx context.Context, categoryID uint32, classID *string) error
+
+	AllRegions(context.Context) ([]*Region, error)
+	Region(ctx context.Context, code string) (*Region, error)
+	SetRegion(context.Context, map[string]interface{}) error
+
+	Rates(ctx context.Context, region string) ([]*Rate, error)
+	RatesAt(ctx context.Context, region string, at time.Time) ([]*Rate, error)
+	CreateRate(context.Context, map[string]interface{}) error
+	CancelScheduledRate(context.Context, string) error
+}
@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Class struct {
+	ID        string
+	Name      string
+	Code      string
+	CreatedAt sql.NullTime
+}
+
+type Region struct {
+	Code             string
+	Name             string
+	PricesIncludeTax bool
+	CreatedAt        sql.NullTime
+	UpdatedAt        sql.NullTime
+}
+
+type Rate struct {
+	ID     string
+	Region string
+	// ClassID is empty for the default rate of the region
+	ClassID       sql.NullString
+	BasisPoints   uint32
+	EffectiveFrom sql.NullTime
+	EffectiveTo   sql.NullTime
+	CreatedAt     sql.NullTime
+}
@@ -0,0 +1,567 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type taxDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewTaxDAOPostgres(client PostgreSQLClient) TaxDAO {
+	return &taxDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme                   = "public"
+	classTableScheme         = scheme + ".tax_class"
+	regionTableScheme        = scheme + ".tax_region"
+	rateTableScheme          = scheme + ".tax_rate"
+	categoryClassTableScheme = scheme + ".category_tax_class"
+	productTableScheme       = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+var (
+	errClassNotFound  = errors.NotFound("tax class not found")
+	errRegionNotFound = errors.NotFound("tax region not found")
+	// ErrClassInUse is returned on deletion of a tax class products or categories still refer to
+	ErrClassInUse = errors.Conflict("tax class is used by products or categories")
+)
+
+func (s *taxDAOPostgres) AllClasses(ctx context.Context) ([]*Class, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.queryBuilder.
+		Select("id").
+		Columns(
+			"name",
+			"code",
+			"created_at",
+		).
+		From(classTableScheme).
+		Where(sq.Eq{tenantColumn: tenantID}).
+		OrderBy("name").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": classTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Class, 0)
+
+	for rows.Next() {
+		c := Class{}
+		if err = rows.Scan(&c.ID, &c.Name, &c.Code, &c.CreatedAt); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &c)
+	}
+
+	return list, nil
+}
+
+// CreateClass stores a tax class, a class with the same code is a conflict
+func (s *taxDAOPostgres) CreateClass(ctx context.Context, m map[string]interface{}) error {
+	return s.insert(ctx, classTableScheme, m)
+}
+
+func (s *taxDAOPostgres) ClassExists(ctx context.Context, id string) (bool, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From(classTableScheme).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		Suffix(")").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": classTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return false, buildErr
+	}
+
+	var exists bool
+	if err := s.client.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// DeleteClass removes a tax class no product or category refers to along with its rates
+func (s *taxDAOPostgres) DeleteClass(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table": classTableScheme,
+		"id":    id,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Select("id").
+			From(classTableScheme).
+			Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+			Suffix("FOR UPDATE").
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var classID string
+		err := tx.QueryRow(ctx, sql, args...).Scan(&classID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errClassNotFound
+		}
+		if err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		products := sq.Select("1").
+			From(productTableScheme).
+			Where(sq.Eq{"tax_class_id": id, tenantColumn: tenantID})
+		categories := sq.Select("1").
+			From(categoryClassTableScheme).
+			Where(sq.Eq{"tax_class_id": id, tenantColumn: tenantID})
+
+		sql, args, buildErr = s.queryBuilder.
+			Select().
+			Column(sq.Expr("EXISTS (?)", products)).
+			Column(sq.Expr("EXISTS (?)", categories)).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var usedByProducts, usedByCategories bool
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&usedByProducts, &usedByCategories); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+		if usedByProducts || usedByCategories {
+			return ErrClassInUse
+		}
+
+		deletes := []sq.DeleteBuilder{
+			s.queryBuilder.Delete(rateTableScheme).Where(sq.Eq{"tax_class_id": id, tenantColumn: tenantID}),
+			s.queryBuilder.Delete(classTableScheme).Where(sq.Eq{"id": id, tenantColumn: tenantID}),
+		}
+		for _, d := range deletes {
+			sql, args, buildErr = d.ToSql()
+			if buildErr != nil {
+				buildErr = db.ErrCreateQuery(buildErr)
+				logger.Error(buildErr)
+				return buildErr
+			}
+
+			if _, err := tx.Exec(ctx, sql, args...); err != nil {
+				err = db.ErrDoQuery(err)
+				logger.Error(err)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetCategoryClass sets the class products of the category are taxed in unless they
+// have their own, no class removes it. Categories are shared, the class is set per tenant.
+func (s *taxDAOPostgres) SetCategoryClass(ctx context.Context, categoryID uint32, classID *string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query sq.Sqlizer
+	if classID == nil {
+		query = s.queryBuilder.
+			Delete(categoryClassTableScheme).
+			Where(sq.Eq{tenantColumn: tenantID, "category_id": categoryID})
+	} else {
+		query = s.queryBuilder.
+			Insert(categoryClassTableScheme).
+			Columns(tenantColumn, "category_id", "tax_class_id").
+			Values(tenantID, categoryID, *classID).
+			Suffix("ON CONFLICT (" + tenantColumn + ", category_id) DO UPDATE SET tax_class_id = EXCLUDED.tax_class_id")
+	}
+
+	sql, args, buildErr := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": categoryClassTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *taxDAOPostgres) regionQuery(tenantID string) sq.SelectBuilder {
+	return s.queryBuilder.
+		Select("code").
+		Columns(
+			"name",
+			"prices_include_tax",
+			"created_at",
+			"updated_at",
+		).
+		From(regionTableScheme).
+		Where(sq.Eq{tenantColumn: tenantID})
+}
+
+func scanRegion(row interface{ Scan(...interface{}) error }, r *Region) error {
+	return row.Scan(
+		&r.Code,
+		&r.Name,
+		&r.PricesIncludeTax,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+}
+
+func (s *taxDAOPostgres) AllRegions(ctx context.Context) ([]*Region, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.regionQuery(tenantID).
+		OrderBy("code").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": regionTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Region, 0)
+
+	for rows.Next() {
+		r := Region{}
+		if err = scanRegion(rows, &r); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &r)
+	}
+
+	return list, nil
+}
+
+func (s *taxDAOPostgres) Region(ctx context.Context, code string) (*Region, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.regionQuery(tenantID).
+		Where(sq.Eq{"code": code}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": regionTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	r := Region{}
+	err = scanRegion(s.client.QueryRow(ctx, sql, args...), &r)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errRegionNotFound
+	}
+	if err != nil {
+		err = db.ErrScan(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// SetRegion creates the region or updates the one with the same code
+func (s *taxDAOPostgres) SetRegion(ctx context.Context, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(regionTableScheme).
+		SetMap(m).
+		Suffix("ON CONFLICT (" + tenantColumn + ", code) DO UPDATE SET name = EXCLUDED.name, " +
+			"prices_include_tax = EXCLUDED.prices_include_tax, updated_at = NOW()").
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": regionTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *taxDAOPostgres) rateQuery(tenantID, region string) sq.SelectBuilder {
+	return s.queryBuilder.
+		Select("id").
+		Columns(
+			"region_code",
+			"tax_class_id",
+			"basis_points",
+			"effective_from",
+			"effective_to",
+			"created_at",
+		).
+		From(rateTableScheme).
+		Where(sq.Eq{tenantColumn: tenantID, "region_code": region})
+}
+
+// Rates returns the timeline of the region, latest entries first
+func (s *taxDAOPostgres) Rates(ctx context.Context, region string) ([]*Rate, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rates(ctx, s.rateQuery(tenantID, region).
+		OrderBy("effective_from DESC", "created_at DESC"))
+}
+
+// RatesAt returns the rate of every class of the region in effect at the moment,
+// the default rate of the region has no class. Like prices, the latest started entry wins.
+func (s *taxDAOPostgres) RatesAt(ctx context.Context, region string, at time.Time) ([]*Rate, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rates(ctx, s.rateQuery(tenantID, region).
+		Options("DISTINCT ON (tax_class_id)").
+		Where(sq.LtOrEq{"effective_from": at}).
+		Where(sq.Or{sq.Eq{"effective_to": nil}, sq.Gt{"effective_to": at}}).
+		OrderBy("tax_class_id", "effective_from DESC", "created_at DESC"))
+}
+
+func (s *taxDAOPostgres) rates(ctx context.Context, query sq.SelectBuilder) ([]*Rate, error) {
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": rateTableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Rate, 0)
+
+	for rows.Next() {
+		r := Rate{}
+		if err = rows.Scan(
+			&r.ID,
+			&r.Region,
+			&r.ClassID,
+			&r.BasisPoints,
+			&r.EffectiveFrom,
+			&r.EffectiveTo,
+			&r.CreatedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &r)
+	}
+
+	return list, nil
+}
+
+func (s *taxDAOPostgres) CreateRate(ctx context.Context, m map[string]interface{}) error {
+	return s.insert(ctx, rateTableScheme, m)
+}
+
+// CancelScheduledRate deletes a rate which has not started yet. Started rates are history.
+func (s *taxDAOPostgres) CancelScheduledRate(ctx context.Context, id string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Delete(rateTableScheme).
+		Where(sq.Eq{"id": id, tenantColumn: tenantID}).
+		Where(sq.Expr("effective_from > NOW()")).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": rateTableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("scheduled tax rate not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+func (s *taxDAOPostgres) insert(ctx context.Context, table string, m map[string]interface{}) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	m[tenantColumn] = tenantID
+
+	sql, args, buildErr := s.queryBuilder.
+		Insert(table).
+		SetMap(m).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": table,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if _, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tax/dao"
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+	"github.com/ilkinabd/goods-manager/app/pkg/slug"
+	"github.com/ilkinabd/goods-manager/app/pkg/tax"
+)
+
+// Class groups goods taxed alike, e.g. reduced for food and books
+type Class struct {
+	ID        string
+	Name      string
+	Code      string
+	CreatedAt time.Time
+}
+
+func NewClassFromPB(req *pbProducts.CreateTaxClassRequest) *Class {
+	code := req.GetCode()
+	if code == "" {
+		code = slug.Make(req.GetName())
+	}
+
+	return &Class{
+		ID:        uuid.New().String(),
+		Name:      req.GetName(),
+		Code:      code,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (c *Class) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         c.ID,
+		"name":       c.Name,
+		"code":       c.Code,
+		"created_at": c.CreatedAt,
+	}
+}
+
+func (c *Class) ToProto() *pbProducts.TaxClass {
+	return &pbProducts.TaxClass{
+		Id:        c.ID,
+		Name:      c.Name,
+		Code:      c.Code,
+		CreatedAt: c.CreatedAt.UnixMilli(),
+	}
+}
+
+func NewClassFromDAO(c *dao.Class) *Class {
+	return &Class{
+		ID:        c.ID,
+		Name:      c.Name,
+		Code:      c.Code,
+		CreatedAt: c.CreatedAt.Time,
+	}
+}
+
+// Region is where a set of rates applies, e.g. a country. Prices of a region including
+// tax are gross prices and the tax is extracted from them, otherwise it is added on top.
+type Region struct {
+	Code             string
+	Name             string
+	PricesIncludeTax bool
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+func NewRegionFromPB(req *pbProducts.SetTaxRegionRequest) *Region {
+	return &Region{
+		Code:             req.GetCode(),
+		Name:             req.GetName(),
+		PricesIncludeTax: req.GetPricesIncludeTax(),
+		CreatedAt:        time.Now(),
+	}
+}
+
+func (r *Region) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"code":               r.Code,
+		"name":               r.Name,
+		"prices_include_tax": r.PricesIncludeTax,
+		"created_at":         r.CreatedAt,
+	}
+}
+
+func (r *Region) ToProto() *pbProducts.TaxRegion {
+	var updatedAt int64
+	if r.UpdatedAt != nil {
+		updatedAt = r.UpdatedAt.UnixMilli()
+	}
+
+	return &pbProducts.TaxRegion{
+		Code:             r.Code,
+		Name:             r.Name,
+		PricesIncludeTax: r.PricesIncludeTax,
+		CreatedAt:        r.CreatedAt.UnixMilli(),
+		UpdatedAt:        updatedAt,
+	}
+}
+
+func NewRegionFromDAO(r *dao.Region) *Region {
+	var updatedAt *time.Time
+	if r.UpdatedAt.Valid {
+		updatedAt = &r.UpdatedAt.Time
+	}
+
+	return &Region{
+		Code:             r.Code,
+		Name:             r.Name,
+		PricesIncludeTax: r.PricesIncludeTax,
+		CreatedAt:        r.CreatedAt.Time,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+// Rate is an entry of the timeline of a class in a region. A rate without a class
+// is the default rate of the region, it applies to goods without a class of their own.
+type Rate struct {
+	ID            string
+	Region        string
+	ClassID       *string
+	BasisPoints   tax.BasisPoints
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	CreatedAt     time.Time
+}
+
+// NewRateFromPB creates a rate, one without a start takes effect right away
+func NewRateFromPB(req *pbProducts.ScheduleTaxRateRequest) *Rate {
+	now := time.Now()
+
+	from := now
+	if req.GetEffectiveFrom() != 0 {
+		from = time.UnixMilli(req.GetEffectiveFrom())
+	}
+
+	var to *time.Time
+	if req.EffectiveTo != nil {
+		t := time.UnixMilli(req.GetEffectiveTo())
+		to = &t
+	}
+
+	var classID *string
+	if req.GetTaxClassId() != "" {
+		classID = req.TaxClassId
+	}
+
+	return &Rate{
+		ID:            uuid.New().String(),
+		Region:        req.GetRegionCode(),
+		ClassID:       classID,
+		BasisPoints:   tax.BasisPoints(req.GetBasisPoints()),
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		CreatedAt:     now,
+	}
+}
+
+func (r *Rate) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":             r.ID,
+		"region_code":    r.Region,
+		"tax_class_id":   r.ClassID,
+		"basis_points":   uint32(r.BasisPoints),
+		"effective_from": r.EffectiveFrom,
+		"effective_to":   r.EffectiveTo,
+		"created_at":     r.CreatedAt,
+	}
+}
+
+func (r *Rate) ToProto() *pbProducts.TaxRate {
+	var to *int64
+	if r.EffectiveTo != nil {
+		ms := r.EffectiveTo.UnixMilli()
+		to = &ms
+	}
+
+	return &pbProducts.TaxRate{
+		Id:            r.ID,
+		RegionCode:    r.Region,
+		TaxClassId:    r.ClassID,
+		BasisPoints:   uint32(r.BasisPoints),
+		EffectiveFrom: r.EffectiveFrom.UnixMilli(),
+		EffectiveTo:   to,
+		CreatedAt:     r.CreatedAt.UnixMilli(),
+	}
+}
+
+func NewRateFromDAO(r *dao.Rate) *Rate {
+	var classID *string
+	if r.ClassID.Valid {
+		classID = &r.ClassID.String
+	}
+
+	var to *time.Time
+	if r.EffectiveTo.Valid {
+		to = &r.EffectiveTo.Time
+	}
+
+	return &Rate{
+		ID:            r.ID,
+		Region:        r.Region,
+		ClassID:       classID,
+		BasisPoints:   tax.BasisPoints(r.BasisPoints),
+		EffectiveFrom: r.EffectiveFrom.Time,
+		EffectiveTo:   to,
+		CreatedAt:     r.CreatedAt.Time,
+	}
+}
+
+// RegionRates are the rates of a region in effect at a moment
+type RegionRates struct {
+	Region *Region
+	// byClass holds the rates of the classes, the default rate is kept under the empty class
+	byClass map[string]*Rate
+}
+
+func NewRegionRates(region *Region, rates []*Rate) *RegionRates {
+	byClass := make(map[string]*Rate, len(rates))
+	for _, r := range rates {
+		var classID string
+		if r.ClassID != nil {
+			classID = *r.ClassID
+		}
+		byClass[classID] = r
+	}
+
+	return &RegionRates{Region: region, byClass: byClass}
+}
+
+// For returns the rate of the class or else the default rate of the region,
+// false when the region has neither
+func (rr *RegionRates) For(classID *string) (*Rate, bool) {
+	if classID != nil {
+		if r, ok := rr.byClass[*classID]; ok {
+			return r, true
+		}
+	}
+
+	r, ok := rr.byClass[""]
+	return r, ok
+}
+
+// Breakdown splits the price of a good of the class in the region,
+// no rate is returned when the region has none for the class
+func (rr *RegionRates) Breakdown(classID *string, price money.Money) (*Rate, tax.Breakdown, error) {
+	rate, ok := rr.For(classID)
+	if !ok {
+		return nil, tax.Breakdown{}, nil
+	}
+
+	breakdown, err := tax.Split(price, rate.BasisPoints, rr.Region.PricesIncludeTax)
+	if err != nil {
+		return nil, tax.Breakdown{}, err
+	}
+
+	return rate, breakdown, nil
+}
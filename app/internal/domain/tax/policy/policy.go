@@ -0,0 +1,173 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tax/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tax/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/tax"
+)
+
+const (
+	NameMaxLength = 128
+	CodeMaxLength = 64
+)
+
+// regionCode is an ISO 3166-1 alpha-2 country code or an ISO 3166-2 subdivision, e.g. DE or US-CA
+var regionCode = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+type TaxPolicy struct {
+	taxService *service.TaxService
+}
+
+func NewTaxPolicy(taxService *service.TaxService) *TaxPolicy {
+	return &TaxPolicy{taxService: taxService}
+}
+
+func (p *TaxPolicy) AllClasses(ctx context.Context) ([]*model.Class, error) {
+	classes, err := p.taxService.AllClasses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "taxService.AllClasses")
+	}
+
+	return classes, nil
+}
+
+func (p *TaxPolicy) CreateClass(ctx context.Context, class *model.Class) (*model.Class, error) {
+	var violations errors.FieldViolations
+	if class.Name == "" || utf8.RuneCountInString(class.Name) > NameMaxLength {
+		violations.Add("name", fmt.Sprintf("must be from 1 to %d characters long", NameMaxLength))
+	}
+	if class.Code == "" || utf8.RuneCountInString(class.Code) > CodeMaxLength {
+		violations.Add("code", fmt.Sprintf("must be from 1 to %d characters long", CodeMaxLength))
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.taxService.CreateClass(ctx, class)
+}
+
+func (p *TaxPolicy) DeleteClass(ctx context.Context, id string) error {
+	return p.taxService.DeleteClass(ctx, id)
+}
+
+// SetCategoryClass sets the class inherited by products of the category, an empty class removes it
+func (p *TaxPolicy) SetCategoryClass(ctx context.Context, categoryID uint32, classID string) error {
+	if classID == "" {
+		return p.taxService.SetCategoryClass(ctx, categoryID, nil)
+	}
+
+	if err := p.validateClass(ctx, classID); err != nil {
+		return err
+	}
+
+	return p.taxService.SetCategoryClass(ctx, categoryID, &classID)
+}
+
+func (p *TaxPolicy) AllRegions(ctx context.Context) ([]*model.Region, error) {
+	regions, err := p.taxService.AllRegions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "taxService.AllRegions")
+	}
+
+	return regions, nil
+}
+
+func (p *TaxPolicy) SetRegion(ctx context.Context, region *model.Region) (*model.Region, error) {
+	var violations errors.FieldViolations
+	if !regionCode.MatchString(region.Code) {
+		violations.Add("code", "must be an ISO 3166 country or subdivision code, e.g. DE or US-CA")
+	}
+	if region.Name == "" || utf8.RuneCountInString(region.Name) > NameMaxLength {
+		violations.Add("name", fmt.Sprintf("must be from 1 to %d characters long", NameMaxLength))
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.taxService.SetRegion(ctx, region)
+}
+
+func (p *TaxPolicy) Rates(ctx context.Context, region string) ([]*model.Rate, error) {
+	if _, err := p.taxService.Region(ctx, region); err != nil {
+		return nil, err
+	}
+
+	rates, err := p.taxService.Rates(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "taxService.Rates")
+	}
+
+	return rates, nil
+}
+
+// RatesAt returns the rates of the region in effect at the moment, now by default
+func (p *TaxPolicy) RatesAt(ctx context.Context, region string, at time.Time) (*model.RegionRates, error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	return p.taxService.RatesAt(ctx, region, at)
+}
+
+// ScheduleRate adds a rate to the timeline of the region. A rate may start right away
+// but not in the past, the rates already charged stay as they were.
+func (p *TaxPolicy) ScheduleRate(ctx context.Context, rate *model.Rate) (*model.Rate, error) {
+	var violations errors.FieldViolations
+	if rate.BasisPoints > tax.Percent100 {
+		violations.Add("basis_points", fmt.Sprintf("must be at most %d", tax.Percent100))
+	}
+	if rate.EffectiveFrom.Before(rate.CreatedAt) {
+		violations.Add("effective_from", "must not be in the past")
+	}
+	if rate.EffectiveTo != nil && !rate.EffectiveTo.After(rate.EffectiveFrom) {
+		violations.Add("effective_to", "must be after effective_from")
+	}
+
+	_, err := p.taxService.Region(ctx, rate.Region)
+	if errors.KindOf(err) == errors.KindNotFound {
+		violations.Add("region_code", "tax region does not exist")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if rate.ClassID != nil {
+		exists, err := p.taxService.ClassExists(ctx, *rate.ClassID)
+		if err != nil {
+			return nil, errors.Wrap(err, "taxService.ClassExists")
+		}
+		if !exists {
+			violations.Add("tax_class_id", "tax class does not exist")
+		}
+	}
+
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.taxService.ScheduleRate(ctx, rate)
+}
+
+func (p *TaxPolicy) CancelScheduledRate(ctx context.Context, id string) error {
+	return p.taxService.CancelScheduledRate(ctx, id)
+}
+
+func (p *TaxPolicy) validateClass(ctx context.Context, classID string) error {
+	exists, err := p.taxService.ClassExists(ctx, classID)
+	if err != nil {
+		return errors.Wrap(err, "taxService.ClassExists")
+	}
+	if !exists {
+		var violations errors.FieldViolations
+		violations.Add("tax_class_id", "tax class does not exist")
+		return violations.Err()
+	}
+
+	return nil
+}
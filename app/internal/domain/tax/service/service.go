@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tax/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/tax/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type TaxService struct {
+	repository dao.TaxDAO
+}
+
+func NewTaxService(repository dao.TaxDAO) *TaxService {
+	return &TaxService{repository: repository}
+}
+
+func (s *TaxService) AllClasses(ctx context.Context) ([]*model.Class, error) {
+	dbClasses, err := s.repository.AllClasses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.AllClasses")
+	}
+
+	classes := make([]*model.Class, 0, len(dbClasses))
+	for _, dbC := range dbClasses {
+		classes = append(classes, model.NewClassFromDAO(dbC))
+	}
+
+	return classes, nil
+}
+
+func (s *TaxService) CreateClass(ctx context.Context, class *model.Class) (*model.Class, error) {
+	if err := s.repository.CreateClass(ctx, class.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return class, nil
+}
+
+func (s *TaxService) ClassExists(ctx context.Context, id string) (bool, error) {
+	return s.repository.ClassExists(ctx, id)
+}
+
+func (s *TaxService) DeleteClass(ctx context.Context, id string) error {
+	return s.repository.DeleteClass(ctx, id)
+}
+
+func (s *TaxService) SetCategoryClass(ctx context.Context, categoryID uint32, classID *string) error {
+	return s.repository.SetCategoryClass(ctx, categoryID, classID)
+}
+
+func (s *TaxService) AllRegions(ctx context.Context) ([]*model.Region, error) {
+	dbRegions, err := s.repository.AllRegions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.AllRegions")
+	}
+
+	regions := make([]*model.Region, 0, len(dbRegions))
+	for _, dbR := range dbRegions {
+		regions = append(regions, model.NewRegionFromDAO(dbR))
+	}
+
+	return regions, nil
+}
+
+func (s *TaxService) Region(ctx context.Context, code string) (*model.Region, error) {
+	dbR, err := s.repository.Region(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRegionFromDAO(dbR), nil
+}
+
+func (s *TaxService) SetRegion(ctx context.Context, region *model.Region) (*model.Region, error) {
+	if err := s.repository.SetRegion(ctx, region.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return s.Region(ctx, region.Code)
+}
+
+func (s *TaxService) Rates(ctx context.Context, region string) ([]*model.Rate, error) {
+	dbRates, err := s.repository.Rates(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Rates")
+	}
+
+	return newRatesFromDAO(dbRates), nil
+}
+
+// RatesAt returns the region with the rates in effect at the moment
+func (s *TaxService) RatesAt(ctx context.Context, code string, at time.Time) (*model.RegionRates, error) {
+	region, err := s.Region(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRates, err := s.repository.RatesAt(ctx, code, at)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.RatesAt")
+	}
+
+	return model.NewRegionRates(region, newRatesFromDAO(dbRates)), nil
+}
+
+func (s *TaxService) ScheduleRate(ctx context.Context, rate *model.Rate) (*model.Rate, error) {
+	if err := s.repository.CreateRate(ctx, rate.ToMap()); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (s *TaxService) CancelScheduledRate(ctx context.Context, id string) error {
+	return s.repository.CancelScheduledRate(ctx, id)
+}
+
+func newRatesFromDAO(dbRates []*dao.Rate) []*model.Rate {
+	rates := make([]*model.Rate, 0, len(dbRates))
+	for _, dbR := range dbRates {
+		rates = append(rates, model.NewRateFromDAO(dbR))
+	}
+	return rates
+}
@@ -0,0 +1,22 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type PostgreSQLClient interface {
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type TranslationDAO interface {
+	Translations(ctx context.Context, productIDs []string, locales []string) ([]*Translation, error)
+	Upsert(context.Context, []*Translation) error
+	Delete(ctx context.Context, productID, locale string) error
+	Missing(ctx context.Context, locale string, limit, offset uint64) ([]*Untranslated, error)
+}
@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Translation struct {
+	ProductID           string
+	Locale              string
+	Name                string
+	Description         string
+	SpecificationLabels map[string]string
+	UpdatedAt           sql.NullTime
+}
+
+// Untranslated is the base content of a product missing a translation
+type Untranslated struct {
+	ProductID   string
+	Name        string
+	Description string
+}
@@ -0,0 +1,274 @@
+package dao
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	db "github.com/ilkinabd/goods-manager/app/pkg/client/postgresql/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"github.com/jackc/pgx/v4"
+)
+
+type translationDAOPostgres struct {
+	queryBuilder sq.StatementBuilderType
+	client       PostgreSQLClient
+}
+
+func NewTranslationDAOPostgres(client PostgreSQLClient) TranslationDAO {
+	return &translationDAOPostgres{
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		client:       client,
+	}
+}
+
+const (
+	scheme             = "public"
+	table              = "product_translation"
+	tableScheme        = scheme + "." + table
+	productTableScheme = scheme + ".product"
+
+	tenantColumn = "tenant_id"
+)
+
+func (s *translationDAOPostgres) Translations(
+	ctx context.Context,
+	productIDs []string,
+	locales []string,
+) ([]*Translation, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.queryBuilder.
+		Select("product_id").
+		Columns(
+			"locale",
+			"name",
+			"description",
+			"specification_labels",
+			"updated_at",
+		).
+		From(tableScheme).
+		Where(sq.Eq{tenantColumn: tenantID, "product_id": productIDs}).
+		OrderBy("product_id", "locale")
+	// no locales means every locale
+	if len(locales) != 0 {
+		query = query.Where(sq.Eq{"locale": locales})
+	}
+
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Translation, 0)
+
+	for rows.Next() {
+		t := Translation{}
+		if err = rows.Scan(
+			&t.ProductID,
+			&t.Locale,
+			&t.Name,
+			&t.Description,
+			&t.SpecificationLabels,
+			&t.UpdatedAt,
+		); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &t)
+	}
+
+	return list, nil
+}
+
+// Upsert writes the translations in a single transaction, all products have to belong to the tenant
+func (s *translationDAOPostgres) Upsert(ctx context.Context, translations []*Translation) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]bool, len(translations))
+	insert := s.queryBuilder.
+		Insert(tableScheme).
+		Columns("product_id", "locale", tenantColumn, "name", "description", "specification_labels", "updated_at")
+	for _, t := range translations {
+		ids[t.ProductID] = true
+		insert = insert.Values(t.ProductID, t.Locale, tenantID, t.Name, t.Description, t.SpecificationLabels, sq.Expr("NOW()"))
+	}
+	insert = insert.Suffix("ON CONFLICT (product_id, locale) DO UPDATE SET " +
+		"name = EXCLUDED.name, description = EXCLUDED.description, " +
+		"specification_labels = EXCLUDED.specification_labels, updated_at = EXCLUDED.updated_at")
+
+	productIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		productIDs = append(productIDs, id)
+	}
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"table":    tableScheme,
+		"products": productIDs,
+	})
+
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql, args, buildErr := s.queryBuilder.
+			Select("COUNT(*)").
+			From(productTableScheme).
+			Where(sq.Eq{"id": productIDs, tenantColumn: tenantID}).
+			ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		var found int
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&found); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+		if found != len(productIDs) {
+			err := errors.NotFound("product not found")
+			logger.Error(err)
+			return err
+		}
+
+		sql, args, buildErr = insert.ToSql()
+		if buildErr != nil {
+			buildErr = db.ErrCreateQuery(buildErr)
+			logger.Error(buildErr)
+			return buildErr
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			err = db.ErrDoQuery(err)
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *translationDAOPostgres) Delete(ctx context.Context, productID, locale string) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql, args, buildErr := s.queryBuilder.
+		Delete(tableScheme).
+		Where(sq.Eq{"product_id": productID, "locale": locale, tenantColumn: tenantID}).
+		ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if buildErr != nil {
+		buildErr = db.ErrCreateQuery(buildErr)
+		logger.Error(buildErr)
+		return buildErr
+	}
+
+	if exec, execErr := s.client.Exec(ctx, sql, args...); execErr != nil {
+		execErr = db.ErrDoQuery(execErr)
+		logger.Error(execErr)
+		return execErr
+	} else if exec.RowsAffected() == 0 {
+		execErr = db.ErrDoQuery(errors.NotFound("translation not found"))
+		logger.Error(execErr)
+		return execErr
+	}
+
+	return nil
+}
+
+// Missing returns products of the tenant which have no translation to the locale yet
+func (s *translationDAOPostgres) Missing(
+	ctx context.Context,
+	locale string,
+	limit, offset uint64,
+) ([]*Untranslated, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.queryBuilder.
+		Select("p.id").
+		Columns(
+			"p.name",
+			"p.description",
+		).
+		From(productTableScheme+" p").
+		Where(sq.Eq{"p." + tenantColumn: tenantID}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM "+tableScheme+" t WHERE t.product_id = p.id AND t.locale = ?)", locale)).
+		OrderBy("p.created_at", "p.id").
+		Offset(offset)
+	if limit != 0 {
+		query = query.Limit(limit)
+	}
+
+	sql, args, err := query.ToSql()
+
+	logger := logging.WithFields(ctx, map[string]interface{}{
+		"sql":   sql,
+		"table": tableScheme,
+		"args":  args,
+	})
+	if err != nil {
+		err = db.ErrCreateQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		err = db.ErrDoQuery(err)
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]*Untranslated, 0)
+
+	for rows.Next() {
+		u := Untranslated{}
+		if err = rows.Scan(&u.ProductID, &u.Name, &u.Description); err != nil {
+			err = db.ErrScan(err)
+			logger.Error(err)
+			return nil, err
+		}
+
+		list = append(list, &u)
+	}
+
+	return list, nil
+}
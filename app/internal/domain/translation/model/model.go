@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	pbProducts "github.com/ilkinabd/goods-contracts/gen/go/products/v1"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/translation/dao"
+)
+
+// Translation is the content of a product in a locale.
+// SpecificationLabels map specification keys to their display labels.
+type Translation struct {
+	ProductID           string
+	Locale              string
+	Name                string
+	Description         string
+	SpecificationLabels map[string]string
+	UpdatedAt           time.Time
+}
+
+// Untranslated is the base content of a product to be translated
+type Untranslated struct {
+	ProductID   string
+	Name        string
+	Description string
+}
+
+func NewTranslationsFromPB(translationsPB []*pbProducts.ProductTranslation) []*Translation {
+	translations := make([]*Translation, len(translationsPB))
+	for i, t := range translationsPB {
+		translations[i] = &Translation{
+			ProductID:           t.GetProductId(),
+			Locale:              t.GetLocale(),
+			Name:                t.GetName(),
+			Description:         t.GetDescription(),
+			SpecificationLabels: t.GetSpecificationLabels(),
+		}
+	}
+	return translations
+}
+
+func (t *Translation) ToDAO() *dao.Translation {
+	labels := t.SpecificationLabels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return &dao.Translation{
+		ProductID:           t.ProductID,
+		Locale:              t.Locale,
+		Name:                t.Name,
+		Description:         t.Description,
+		SpecificationLabels: labels,
+	}
+}
+
+func (t *Translation) ToProto() *pbProducts.ProductTranslation {
+	return &pbProducts.ProductTranslation{
+		ProductId:           t.ProductID,
+		Locale:              t.Locale,
+		Name:                t.Name,
+		Description:         t.Description,
+		SpecificationLabels: t.SpecificationLabels,
+		UpdatedAt:           t.UpdatedAt.UnixMilli(),
+	}
+}
+
+func (u *Untranslated) ToProto() *pbProducts.UntranslatedProduct {
+	return &pbProducts.UntranslatedProduct{
+		ProductId:   u.ProductID,
+		Name:        u.Name,
+		Description: u.Description,
+	}
+}
+
+func NewTranslationFromDAO(t *dao.Translation) *Translation {
+	return &Translation{
+		ProductID:           t.ProductID,
+		Locale:              t.Locale,
+		Name:                t.Name,
+		Description:         t.Description,
+		SpecificationLabels: t.SpecificationLabels,
+		UpdatedAt:           t.UpdatedAt.Time,
+	}
+}
+
+func NewUntranslatedFromDAO(u *dao.Untranslated) *Untranslated {
+	return &Untranslated{
+		ProductID:   u.ProductID,
+		Name:        u.Name,
+		Description: u.Description,
+	}
+}
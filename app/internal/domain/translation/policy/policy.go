@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/translation/model"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/translation/service"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/locale"
+)
+
+const (
+	defaultTextSearchConfig = "simple"
+	defaultMissingLimit     = 100
+	maxMissingLimit         = 500
+)
+
+type TranslationPolicy struct {
+	translationService *service.TranslationService
+	resolver           *locale.Resolver
+	textSearch         map[string]string
+}
+
+// NewTranslationPolicy creates a policy. textSearch maps a locale to the
+// PostgreSQL text search configuration used to search its content.
+func NewTranslationPolicy(
+	translationService *service.TranslationService,
+	resolver *locale.Resolver,
+	textSearch map[string]string,
+) *TranslationPolicy {
+	return &TranslationPolicy{
+		translationService: translationService,
+		resolver:           resolver,
+		textSearch:         textSearch,
+	}
+}
+
+func (p *TranslationPolicy) All(ctx context.Context, productID string) ([]*model.Translation, error) {
+	translations, err := p.translationService.All(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "translationService.All")
+	}
+
+	return translations, nil
+}
+
+// Localized looks the products up along the fallback chain of the locale in context.
+// Products missing from the result keep their base content.
+func (p *TranslationPolicy) Localized(ctx context.Context, productIDs []string) (map[string]*model.Translation, error) {
+	return p.translationService.Localized(ctx, p.resolver.Chain(p.Locale(ctx)), productIDs)
+}
+
+// Locale returns the locale negotiated for the request, or the default one
+func (p *TranslationPolicy) Locale(ctx context.Context) string {
+	if l, ok := locale.FromContext(ctx); ok {
+		return l
+	}
+	return p.resolver.Default()
+}
+
+// TextSearchConfig returns the text search configuration for the locale in context
+func (p *TranslationPolicy) TextSearchConfig(ctx context.Context) string {
+	if config, ok := p.textSearch[p.Locale(ctx)]; ok {
+		return config
+	}
+	return defaultTextSearchConfig
+}
+
+func (p *TranslationPolicy) Set(ctx context.Context, translations []*model.Translation) error {
+	var violations errors.FieldViolations
+	if len(translations) == 0 {
+		violations.Add("translations", "must not be empty")
+	}
+	for i, t := range translations {
+		field := fmt.Sprintf("translations[%d]", i)
+		if t.ProductID == "" {
+			violations.Add(field+".product_id", "must not be empty")
+		}
+		if !p.resolver.IsSupported(t.Locale) {
+			violations.Add(field+".locale", "is not supported")
+		}
+		if t.Name == "" {
+			violations.Add(field+".name", "must not be empty")
+		}
+	}
+	if err := violations.Err(); err != nil {
+		return err
+	}
+
+	return p.translationService.Set(ctx, translations)
+}
+
+func (p *TranslationPolicy) Delete(ctx context.Context, productID, l string) error {
+	return p.translationService.Delete(ctx, productID, l)
+}
+
+func (p *TranslationPolicy) Missing(
+	ctx context.Context,
+	l string,
+	limit, offset uint64,
+) ([]*model.Untranslated, error) {
+	var violations errors.FieldViolations
+	if !p.resolver.IsSupported(l) {
+		violations.Add("locale", "is not supported")
+	}
+	if limit > maxMissingLimit {
+		violations.Add("limit", fmt.Sprintf("must not exceed %d", maxMissingLimit))
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = defaultMissingLimit
+	}
+
+	return p.translationService.Missing(ctx, l, limit, offset)
+}
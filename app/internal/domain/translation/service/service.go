@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/internal/domain/translation/dao"
+	"github.com/ilkinabd/goods-manager/app/internal/domain/translation/model"
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+type TranslationService struct {
+	repository dao.TranslationDAO
+}
+
+func NewTranslationService(repository dao.TranslationDAO) *TranslationService {
+	return &TranslationService{repository: repository}
+}
+
+func (s *TranslationService) All(ctx context.Context, productID string) ([]*model.Translation, error) {
+	dbTranslations, err := s.repository.Translations(ctx, []string{productID}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Translations")
+	}
+
+	translations := make([]*model.Translation, 0, len(dbTranslations))
+	for _, dbT := range dbTranslations {
+		translations = append(translations, model.NewTranslationFromDAO(dbT))
+	}
+
+	return translations, nil
+}
+
+// Localized returns for every product the translation to the first locale of the chain it has one for
+func (s *TranslationService) Localized(
+	ctx context.Context,
+	chain []string,
+	productIDs []string,
+) (map[string]*model.Translation, error) {
+	localized := make(map[string]*model.Translation, len(productIDs))
+	if len(productIDs) == 0 {
+		return localized, nil
+	}
+
+	dbTranslations, err := s.repository.Translations(ctx, productIDs, chain)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Translations")
+	}
+
+	rank := make(map[string]int, len(chain))
+	for i, l := range chain {
+		rank[l] = i
+	}
+
+	for _, dbT := range dbTranslations {
+		current, ok := localized[dbT.ProductID]
+		if !ok || rank[dbT.Locale] < rank[current.Locale] {
+			localized[dbT.ProductID] = model.NewTranslationFromDAO(dbT)
+		}
+	}
+
+	return localized, nil
+}
+
+func (s *TranslationService) Set(ctx context.Context, translations []*model.Translation) error {
+	translationsDAO := make([]*dao.Translation, len(translations))
+	for i, t := range translations {
+		translationsDAO[i] = t.ToDAO()
+	}
+
+	return s.repository.Upsert(ctx, translationsDAO)
+}
+
+func (s *TranslationService) Delete(ctx context.Context, productID, locale string) error {
+	return s.repository.Delete(ctx, productID, locale)
+}
+
+func (s *TranslationService) Missing(
+	ctx context.Context,
+	locale string,
+	limit, offset uint64,
+) ([]*model.Untranslated, error) {
+	dbMissing, err := s.repository.Missing(ctx, locale, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository.Missing")
+	}
+
+	missing := make([]*model.Untranslated, 0, len(dbMissing))
+	for _, dbU := range dbMissing {
+		missing = append(missing, model.NewUntranslatedFromDAO(dbU))
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,11 @@
+CREATE TABLE public.api_key
+(
+    id           uuid PRIMARY KEY,
+    name         text        NOT NULL,
+    prefix       text        NOT NULL,
+    hash         text        NOT NULL UNIQUE,
+    scopes       text[]      NOT NULL DEFAULT '{}',
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+    last_used_at timestamptz,
+    revoked_at   timestamptz
+);
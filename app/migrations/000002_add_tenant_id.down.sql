@@ -0,0 +1,5 @@
+ALTER TABLE public.api_key
+    DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE public.product
+    DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,17 @@
+-- rows written before tenants were introduced go to the default tenant, the one anonymous
+-- calls are scoped to. Set goods.default_tenant when TENANT_DEFAULT is not "default".
+ALTER TABLE public.product
+    ADD COLUMN tenant_id text NOT NULL
+        DEFAULT COALESCE(NULLIF(current_setting('goods.default_tenant', true), ''), 'default');
+ALTER TABLE public.product
+    ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX product_tenant_id_idx ON public.product (tenant_id);
+
+ALTER TABLE public.api_key
+    ADD COLUMN tenant_id text NOT NULL
+        DEFAULT COALESCE(NULLIF(current_setting('goods.default_tenant', true), ''), 'default');
+ALTER TABLE public.api_key
+    ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX api_key_tenant_id_idx ON public.api_key (tenant_id, created_at DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE public.category_attribute
+(
+    category_id    integer NOT NULL REFERENCES public.category (id) ON DELETE CASCADE,
+    name           text    NOT NULL,
+    type           text    NOT NULL CHECK (type IN ('int', 'decimal', 'enum', 'bool', 'string', 'list')),
+    unit           text    NOT NULL DEFAULT '',
+    required       boolean NOT NULL DEFAULT false,
+    allowed_values text[]  NOT NULL DEFAULT '{}',
+    position       integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (category_id, name)
+);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS public.product_option;
+
+DROP INDEX IF EXISTS public.product_tenant_id_sku_idx;
+
+ALTER TABLE public.product
+    DROP COLUMN IF EXISTS options,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS parent_id;
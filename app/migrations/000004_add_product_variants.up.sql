@@ -0,0 +1,16 @@
+ALTER TABLE public.product
+    ADD COLUMN parent_id uuid REFERENCES public.product (id) ON DELETE CASCADE,
+    ADD COLUMN sku       text,
+    ADD COLUMN options   jsonb;
+
+CREATE INDEX product_parent_id_idx ON public.product (parent_id);
+CREATE UNIQUE INDEX product_tenant_id_sku_idx ON public.product (tenant_id, sku) WHERE sku IS NOT NULL;
+
+CREATE TABLE public.product_option
+(
+    product_id    uuid    NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    name          text    NOT NULL,
+    option_values text[]  NOT NULL,
+    position      integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, name)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.inventory_reservation;
+DROP TABLE IF EXISTS public.inventory_stock;
+DROP TABLE IF EXISTS public.warehouse;
@@ -0,0 +1,33 @@
+CREATE TABLE public.warehouse
+(
+    id         uuid PRIMARY KEY,
+    tenant_id  text        NOT NULL,
+    name       text        NOT NULL,
+    code       text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, code)
+);
+
+CREATE TABLE public.inventory_stock
+(
+    product_id   uuid   NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    warehouse_id uuid   NOT NULL REFERENCES public.warehouse (id) ON DELETE CASCADE,
+    on_hand      bigint NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    reserved     bigint NOT NULL DEFAULT 0 CHECK (reserved >= 0 AND reserved <= on_hand),
+    PRIMARY KEY (product_id, warehouse_id)
+);
+
+CREATE TABLE public.inventory_reservation
+(
+    id           uuid PRIMARY KEY,
+    tenant_id    text        NOT NULL,
+    product_id   uuid        NOT NULL,
+    warehouse_id uuid        NOT NULL,
+    quantity     bigint      NOT NULL CHECK (quantity > 0),
+    status       text        NOT NULL CHECK (status IN ('active', 'released', 'committed', 'expired')),
+    expires_at   timestamptz,
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (product_id, warehouse_id) REFERENCES public.inventory_stock (product_id, warehouse_id) ON DELETE CASCADE
+);
+
+CREATE INDEX inventory_reservation_active_idx ON public.inventory_reservation (expires_at) WHERE status = 'active';
@@ -0,0 +1,23 @@
+CREATE TABLE public.product_price
+(
+    id             uuid PRIMARY KEY,
+    tenant_id      text        NOT NULL,
+    product_id     uuid        NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    price          bigint      NOT NULL CHECK (price >= 0),
+    effective_from timestamptz NOT NULL,
+    effective_to   timestamptz CHECK (effective_to > effective_from),
+    created_at     timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX product_price_product_idx ON public.product_price (tenant_id, product_id, effective_from DESC);
+CREATE INDEX product_price_due_idx ON public.product_price (effective_from, effective_to);
+
+-- the timeline of every product starts with its current price, so a sale ending falls back to it
+INSERT INTO public.product_price (id, tenant_id, product_id, price, effective_from, created_at)
+SELECT gen_random_uuid(), p.tenant_id, p.id, p.price, NOW(), NOW()
+FROM public.product p
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM public.product_price pp
+    WHERE pp.tenant_id = p.tenant_id AND pp.product_id = p.id AND pp.effective_to IS NULL
+);
@@ -0,0 +1,17 @@
+CREATE TABLE public.promotion
+(
+    id            uuid PRIMARY KEY,
+    tenant_id     text        NOT NULL,
+    name          text        NOT NULL,
+    priority      integer     NOT NULL DEFAULT 0,
+    stackable     boolean     NOT NULL DEFAULT false,
+    conditions    jsonb       NOT NULL DEFAULT '{}',
+    action        text        NOT NULL CHECK (action IN ('percentage', 'fixed_amount', 'fixed_price', 'buy_x_get_y')),
+    value         bigint      NOT NULL DEFAULT 0 CHECK (value >= 0),
+    free_quantity bigint      NOT NULL DEFAULT 0 CHECK (free_quantity >= 0),
+    starts_at     timestamptz NOT NULL,
+    ends_at       timestamptz CHECK (ends_at > starts_at),
+    created_at    timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX promotion_tenant_id_idx ON public.promotion (tenant_id, starts_at, ends_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.price_list_entry;
+DROP TABLE IF EXISTS public.price_list;
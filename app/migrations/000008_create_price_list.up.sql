@@ -0,0 +1,24 @@
+CREATE TABLE public.price_list
+(
+    id             uuid PRIMARY KEY,
+    tenant_id      text        NOT NULL,
+    name           text        NOT NULL,
+    currency_id    integer     NOT NULL REFERENCES public.currency (id),
+    customer_group text,
+    channel        text,
+    priority       integer     NOT NULL DEFAULT 0,
+    created_at     timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX price_list_tenant_id_idx ON public.price_list (tenant_id);
+
+CREATE TABLE public.price_list_entry
+(
+    price_list_id uuid   NOT NULL REFERENCES public.price_list (id) ON DELETE CASCADE,
+    product_id    uuid   NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    min_quantity  bigint NOT NULL CHECK (min_quantity > 0),
+    price         bigint NOT NULL CHECK (price >= 0),
+    PRIMARY KEY (price_list_id, product_id, min_quantity)
+);
+
+CREATE INDEX price_list_entry_product_id_idx ON public.price_list_entry (product_id);
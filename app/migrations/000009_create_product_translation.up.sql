@@ -0,0 +1,13 @@
+CREATE TABLE public.product_translation
+(
+    product_id           uuid        NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    locale               text        NOT NULL,
+    tenant_id            text        NOT NULL,
+    name                 text        NOT NULL,
+    description          text        NOT NULL DEFAULT '',
+    specification_labels jsonb       NOT NULL DEFAULT '{}',
+    updated_at           timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, locale)
+);
+
+CREATE INDEX product_translation_tenant_id_idx ON public.product_translation (tenant_id, locale);
@@ -0,0 +1,13 @@
+CREATE TABLE public.product_slug
+(
+    tenant_id  text        NOT NULL,
+    product_id uuid        NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    locale     text        NOT NULL,
+    slug       text        NOT NULL,
+    is_current boolean     NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, locale, slug)
+);
+
+-- older slugs of a product redirect to the current one, a single one per locale
+CREATE UNIQUE INDEX product_slug_current_idx ON public.product_slug (product_id, locale) WHERE is_current;
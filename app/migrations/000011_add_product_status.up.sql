@@ -0,0 +1,11 @@
+-- products written before the lifecycle were all visible, they stay published
+ALTER TABLE public.product
+    ADD COLUMN status       text NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'in_review', 'published', 'archived')),
+    ADD COLUMN publish_at   timestamptz,
+    ADD COLUMN unpublish_at timestamptz;
+ALTER TABLE public.product
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX product_publish_at_idx ON public.product (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX product_unpublish_at_idx ON public.product (unpublish_at) WHERE unpublish_at IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.product_bundle_component;
+DROP TABLE IF EXISTS public.product_bundle;
+DROP TABLE IF EXISTS public.product_relation;
@@ -0,0 +1,30 @@
+CREATE TABLE public.product_relation
+(
+    id         uuid PRIMARY KEY,
+    tenant_id  text        NOT NULL,
+    product_id uuid        NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    related_id uuid        NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    type       text        NOT NULL CHECK (type IN ('related', 'accessory', 'replaced_by')),
+    position   integer,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, related_id, type),
+    CHECK (product_id <> related_id)
+);
+
+CREATE INDEX product_relation_tenant_id_idx ON public.product_relation (tenant_id, product_id);
+
+CREATE TABLE public.product_bundle
+(
+    product_id     uuid PRIMARY KEY REFERENCES public.product (id) ON DELETE CASCADE,
+    tenant_id      text NOT NULL,
+    price_override bigint CHECK (price_override >= 0)
+);
+
+CREATE TABLE public.product_bundle_component
+(
+    bundle_id  uuid    NOT NULL REFERENCES public.product_bundle (product_id) ON DELETE CASCADE,
+    product_id uuid    NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    quantity   integer NOT NULL CHECK (quantity > 0),
+    position   integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (bundle_id, product_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.product_review_summary;
+DROP TABLE IF EXISTS public.product_review;
@@ -0,0 +1,32 @@
+CREATE TABLE public.product_review
+(
+    id                uuid PRIMARY KEY,
+    tenant_id         text        NOT NULL,
+    product_id        uuid        NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    author_id         text        NOT NULL,
+    author_name       text        NOT NULL DEFAULT '',
+    score             smallint    NOT NULL CHECK (score BETWEEN 1 AND 5),
+    text              text        NOT NULL DEFAULT '',
+    verified_purchase boolean     NOT NULL DEFAULT false,
+    status            text        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    moderated_at      timestamptz,
+    created_at        timestamptz NOT NULL DEFAULT NOW(),
+    -- an author reviews a product once, a second review is a conflict
+    UNIQUE (product_id, author_id)
+);
+
+CREATE INDEX product_review_product_idx ON public.product_review (tenant_id, product_id, created_at DESC);
+
+-- the aggregate of the approved reviews, kept in the transactions moderating them
+CREATE TABLE public.product_review_summary
+(
+    product_id   uuid PRIMARY KEY REFERENCES public.product (id) ON DELETE CASCADE,
+    tenant_id    text   NOT NULL,
+    review_count bigint NOT NULL DEFAULT 0 CHECK (review_count >= 0),
+    score_sum    bigint NOT NULL DEFAULT 0 CHECK (score_sum >= 0),
+    score_1      bigint NOT NULL DEFAULT 0 CHECK (score_1 >= 0),
+    score_2      bigint NOT NULL DEFAULT 0 CHECK (score_2 >= 0),
+    score_3      bigint NOT NULL DEFAULT 0 CHECK (score_3 >= 0),
+    score_4      bigint NOT NULL DEFAULT 0 CHECK (score_4 >= 0),
+    score_5      bigint NOT NULL DEFAULT 0 CHECK (score_5 >= 0)
+);
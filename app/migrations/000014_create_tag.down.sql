@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.product_tag;
+DROP TABLE IF EXISTS public.tag;
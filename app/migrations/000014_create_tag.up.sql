@@ -0,0 +1,18 @@
+CREATE TABLE public.tag
+(
+    id         uuid PRIMARY KEY,
+    tenant_id  text        NOT NULL,
+    name       text        NOT NULL,
+    slug       text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, slug)
+);
+
+CREATE TABLE public.product_tag
+(
+    product_id uuid NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    tag_id     uuid NOT NULL REFERENCES public.tag (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE INDEX product_tag_tag_id_idx ON public.product_tag (tag_id);
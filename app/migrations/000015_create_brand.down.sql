@@ -0,0 +1,4 @@
+ALTER TABLE public.product
+    DROP COLUMN IF EXISTS brand_id;
+
+DROP TABLE IF EXISTS public.brand;
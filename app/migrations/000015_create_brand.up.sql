@@ -0,0 +1,19 @@
+CREATE TABLE public.brand
+(
+    id            uuid PRIMARY KEY,
+    tenant_id     text        NOT NULL,
+    name          text        NOT NULL,
+    slug          text        NOT NULL,
+    logo_image_id text,
+    country       char(2),
+    website       text,
+    created_at    timestamptz NOT NULL DEFAULT NOW(),
+    updated_at    timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, slug)
+);
+
+-- brands in use are not deleted, the products have to be moved off them first
+ALTER TABLE public.product
+    ADD COLUMN brand_id uuid REFERENCES public.brand (id);
+
+CREATE INDEX product_brand_id_idx ON public.product (brand_id);
@@ -0,0 +1,14 @@
+CREATE TABLE public.product_barcode
+(
+    tenant_id  text        NOT NULL,
+    product_id uuid        NOT NULL REFERENCES public.product (id) ON DELETE CASCADE,
+    code       text        NOT NULL,
+    type       text        NOT NULL,
+    gtin       char(14)    NOT NULL,
+    position   integer     NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    -- a GTIN is unique within the tenant, a barcode of another product is a conflict
+    PRIMARY KEY (tenant_id, gtin)
+);
+
+CREATE INDEX product_barcode_product_id_idx ON public.product_barcode (product_id, position);
@@ -0,0 +1,11 @@
+ALTER TABLE public.category
+    DROP COLUMN IF EXISTS shipping_class;
+
+ALTER TABLE public.product
+    DROP COLUMN IF EXISTS shipping_class,
+    DROP COLUMN IF EXISTS dimension_unit,
+    DROP COLUMN IF EXISTS height_cm,
+    DROP COLUMN IF EXISTS width_cm,
+    DROP COLUMN IF EXISTS length_cm,
+    DROP COLUMN IF EXISTS weight_unit,
+    DROP COLUMN IF EXISTS weight_grams;
@@ -0,0 +1,12 @@
+-- weights are kept in grams and dimensions in centimeters, the units are the ones they were given in
+ALTER TABLE public.product
+    ADD COLUMN weight_grams   double precision CHECK (weight_grams >= 0),
+    ADD COLUMN weight_unit    text CHECK (weight_unit IN ('g', 'kg', 'lb')),
+    ADD COLUMN length_cm      double precision CHECK (length_cm >= 0),
+    ADD COLUMN width_cm       double precision CHECK (width_cm >= 0),
+    ADD COLUMN height_cm      double precision CHECK (height_cm >= 0),
+    ADD COLUMN dimension_unit text CHECK (dimension_unit IN ('cm', 'in')),
+    ADD COLUMN shipping_class text CHECK (shipping_class IN ('standard', 'bulky', 'fragile'));
+
+ALTER TABLE public.category
+    ADD COLUMN shipping_class text CHECK (shipping_class IN ('standard', 'bulky', 'fragile'));
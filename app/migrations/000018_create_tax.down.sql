@@ -0,0 +1,7 @@
+ALTER TABLE public.product
+    DROP COLUMN IF EXISTS tax_class_id;
+
+DROP TABLE IF EXISTS public.category_tax_class;
+DROP TABLE IF EXISTS public.tax_rate;
+DROP TABLE IF EXISTS public.tax_region;
+DROP TABLE IF EXISTS public.tax_class;
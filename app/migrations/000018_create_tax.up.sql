@@ -0,0 +1,50 @@
+CREATE TABLE public.tax_class
+(
+    id         uuid PRIMARY KEY,
+    tenant_id  text        NOT NULL,
+    name       text        NOT NULL,
+    code       text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, code)
+);
+
+CREATE TABLE public.tax_region
+(
+    tenant_id          text        NOT NULL,
+    code               text        NOT NULL,
+    name               text        NOT NULL,
+    prices_include_tax boolean     NOT NULL DEFAULT false,
+    created_at         timestamptz NOT NULL DEFAULT NOW(),
+    updated_at         timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, code)
+);
+
+-- a rate without a tax class is the default rate of the region
+CREATE TABLE public.tax_rate
+(
+    id             uuid PRIMARY KEY,
+    tenant_id      text        NOT NULL,
+    region_code    text        NOT NULL,
+    tax_class_id   uuid REFERENCES public.tax_class (id),
+    basis_points   integer     NOT NULL CHECK (basis_points >= 0),
+    effective_from timestamptz NOT NULL,
+    effective_to   timestamptz CHECK (effective_to > effective_from),
+    created_at     timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (tenant_id, region_code) REFERENCES public.tax_region (tenant_id, code) ON DELETE CASCADE
+);
+
+CREATE INDEX tax_rate_region_idx ON public.tax_rate (tenant_id, region_code, effective_from DESC);
+
+-- categories are shared, their tax classes are set per tenant
+CREATE TABLE public.category_tax_class
+(
+    tenant_id    text    NOT NULL,
+    category_id  integer NOT NULL REFERENCES public.category (id) ON DELETE CASCADE,
+    tax_class_id uuid    NOT NULL REFERENCES public.tax_class (id),
+    PRIMARY KEY (tenant_id, category_id)
+);
+
+ALTER TABLE public.product
+    ADD COLUMN tax_class_id uuid REFERENCES public.tax_class (id);
+
+CREATE INDEX product_tax_class_id_idx ON public.product (tax_class_id);
@@ -0,0 +1,6 @@
+ALTER TABLE public.promotion
+    DROP COLUMN IF EXISTS amounts_in_hundredths;
+
+ALTER TABLE public.currency
+    DROP COLUMN IF EXISTS exponent,
+    DROP COLUMN IF EXISTS code;
@@ -0,0 +1,11 @@
+-- the codes and exponents are filled in by cmd/migrate-money, which rescales the amounts
+ALTER TABLE public.currency
+    ADD COLUMN code     char(3),
+    ADD COLUMN exponent smallint CHECK (exponent BETWEEN 0 AND 4);
+
+-- promotions there were are kept in hundredths until migrate-money rescales them,
+-- promotions created from now on are in minor units already
+ALTER TABLE public.promotion
+    ADD COLUMN amounts_in_hundredths boolean NOT NULL DEFAULT true;
+ALTER TABLE public.promotion
+    ALTER COLUMN amounts_in_hundredths SET DEFAULT false;
@@ -0,0 +1,16 @@
+-- events outlive their products, product_id is not a reference
+CREATE TABLE public.product_outbox
+(
+    seq                  bigserial PRIMARY KEY,
+    tenant_id            text        NOT NULL,
+    product_id           uuid        NOT NULL,
+    type                 text        NOT NULL CHECK (type IN ('product.created', 'product.updated', 'product.deleted')),
+    payload              jsonb       NOT NULL,
+    -- the category the product was in before the change, watchers of it learn the product left
+    previous_category_id bigint      NOT NULL,
+    created_at           timestamptz NOT NULL DEFAULT NOW(),
+    published_at         timestamptz
+);
+
+CREATE INDEX product_outbox_unpublished_idx ON public.product_outbox (seq) WHERE published_at IS NULL;
+CREATE INDEX product_outbox_published_at_idx ON public.product_outbox (published_at);
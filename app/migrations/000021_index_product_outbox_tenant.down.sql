@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public.product_outbox_tenant_id_seq_idx;
@@ -0,0 +1,2 @@
+-- watchers read the events of their tenant after a position
+CREATE INDEX product_outbox_tenant_id_seq_idx ON public.product_outbox (tenant_id, seq);
@@ -0,0 +1,6 @@
+-- the highest seq pruned of every tenant, sync tokens of the tenant before it have expired
+CREATE TABLE public.product_outbox_state
+(
+    tenant_id  text PRIMARY KEY,
+    pruned_seq bigint NOT NULL
+);
@@ -0,0 +1,115 @@
+package grpcerror
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errorDomain     = "goods-manager"
+	internalMessage = "internal error"
+)
+
+// UnaryServerInterceptor translates errors returned by handlers into gRPC statuses.
+// It has to be the outermost interceptor so auth and rate limit errors pass through it too.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, ToStatus(ctx, info.FullMethod, err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return ToStatus(ss.Context(), info.FullMethod, err).Err()
+		}
+		return nil
+	}
+}
+
+// ToStatus converts err into a status whose message never contains the internal cause
+func ToStatus(ctx context.Context, method string, err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	logger := logging.WithError(ctx, err).WithField("method", method)
+
+	domainErr, ok := errors.AsDomain(err)
+	if !ok {
+		logger.Error("unhandled error")
+		return status.New(codes.Internal, internalMessage)
+	}
+
+	code := codeOf(domainErr.Kind)
+	switch code {
+	case codes.Internal:
+		logger.Error("unhandled error")
+		return status.New(codes.Internal, internalMessage)
+	case codes.Unavailable:
+		logger.Error("dependency is unavailable")
+	default:
+		logger.Debug("request failed")
+	}
+
+	st := status.New(code, domainErr.Message)
+
+	detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: domainErr.Kind.String(),
+		Domain: errorDomain,
+	})
+	if detailsErr != nil {
+		return st
+	}
+
+	if len(domainErr.Violations) == 0 {
+		return detailed
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range domainErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	withViolations, detailsErr := detailed.WithDetails(badRequest)
+	if detailsErr != nil {
+		return detailed
+	}
+
+	return withViolations
+}
+
+func codeOf(kind errors.Kind) codes.Code {
+	switch kind {
+	case errors.KindNotFound:
+		return codes.NotFound
+	case errors.KindConflict:
+		return codes.AlreadyExists
+	case errors.KindValidation:
+		return codes.InvalidArgument
+	case errors.KindPermission:
+		return codes.PermissionDenied
+	case errors.KindUnauthenticated:
+		return codes.Unauthenticated
+	case errors.KindUnavailable:
+		return codes.Unavailable
+	case errors.KindOutOfRange:
+		return codes.OutOfRange
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyHeader is the gRPC metadata entry and the HTTP header used to pass an API key
+const APIKeyHeader = "x-api-key"
+
+type APIKeyClaims struct {
+	KeyID    string
+	TenantID string
+	Scopes   []string
+}
+
+// APIKeyValidator resolves a plain API key into its claims
+type APIKeyValidator interface {
+	ValidateAPIKey(ctx context.Context, key string) (*APIKeyClaims, error)
+}
+
+func (c *APIKeyClaims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func apiKeyFromMD(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(APIKeyHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// GetAPIKeyID returns the ID of the API key the request was authenticated with, if it was
+func GetAPIKeyID(ctx context.Context) (string, bool) {
+	keyID, ok := ctx.Value(ctxAPIKeyID{}).(string)
+	return keyID, ok && keyID != ""
+}
+
+// HasScope reports whether the request was authenticated with an API key having the scope
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, _ := ctx.Value(ctxAPIKeyScopes{}).([]string)
+	claims := APIKeyClaims{Scopes: scopes}
+	return claims.HasScope(scope)
+}
@@ -0,0 +1,10 @@
+package jwt
+
+// keys of the values the auth middlewares put into the request context
+type (
+	ctxUserID        struct{}
+	ctxRoleID        struct{}
+	ctxCustomerGroup struct{}
+	ctxAPIKeyID      struct{}
+	ctxAPIKeyScopes  struct{}
+)
@@ -1,5 +1,10 @@
 package jwt
 
-import "github.com/pkg/errors"
+import "github.com/ilkinabd/goods-manager/app/pkg/errors"
 
-var ErrBadToken = errors.New("malformed token")
+var (
+	ErrBadToken      = errors.Unauthenticated("malformed token")
+	ErrBadAPIKey     = errors.Unauthenticated("bad api key")
+	ErrRevokedAPIKey = errors.Unauthenticated("api key is revoked")
+	ErrForbidden     = errors.PermissionDenied("forbidden")
+)
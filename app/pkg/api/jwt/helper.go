@@ -37,14 +37,14 @@ func (h *Helper) ParseToken(tokenS string) (jwt.MapClaims, error) {
 	}
 }
 
-func (h *Helper) GeneratePair(userID, issuerName string, roleID uint64) (*Pair, error) {
-	claims := newAccessTokenClaims(userID, issuerName, roleID)
+func (h *Helper) GeneratePair(userID, issuerName, tenantID, customerGroup string, roleID uint64) (*Pair, error) {
+	claims := newAccessTokenClaims(userID, issuerName, tenantID, customerGroup, roleID)
 	accessToken, err := h.generateToken(claims)
 	if err != nil {
 		return nil, err
 	}
 
-	claims = newRefreshTokenClaims(userID, issuerName, roleID)
+	claims = newRefreshTokenClaims(userID, issuerName, tenantID, customerGroup, roleID)
 	refreshToken, err := h.generateToken(claims)
 	if err != nil {
 		return nil, err
@@ -68,12 +68,18 @@ func (h *Helper) ParseMapClaims(mapClaims jwt.MapClaims) *CustomClaims {
 	sec, dec = math.Modf(mapClaims["iss_at"].(float64))
 	IssuedAt := time.Unix(int64(sec), int64(dec*(1e9)))
 
+	// tokens issued before tenants were introduced carry no tenant_id
+	tenantID, _ := mapClaims["tenant_id"].(string)
+	customerGroup, _ := mapClaims["customer_group"].(string)
+
 	return &CustomClaims{
-		ExpireAt:   ExpireAt,
-		UserID:     mapClaims["id"].(string),
-		IssuedAt:   IssuedAt,
-		IssuerName: mapClaims["iss"].(string),
-		RoleID:     uint64(mapClaims["role_id"].(float64)),
+		ExpireAt:      ExpireAt,
+		UserID:        mapClaims["id"].(string),
+		IssuedAt:      IssuedAt,
+		IssuerName:    mapClaims["iss"].(string),
+		RoleID:        uint64(mapClaims["role_id"].(float64)),
+		TenantID:      tenantID,
+		CustomerGroup: customerGroup,
 	}
 }
 
@@ -5,16 +5,42 @@ import (
 
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// TenantHeader is the gRPC metadata entry anonymous callers of public methods
+// name the storefront they browse with
+const TenantHeader = "x-tenant-id"
+
 type AuthInterceptor struct {
 	jwtHelper Helper
 	roles     map[string][]uint64
+
+	apiKeyValidator APIKeyValidator
+	scopes          map[string]string
+
+	defaultTenant string
 }
 
-func NewAuthInterceptor(jwtHelper Helper, roles map[string][]uint64) *AuthInterceptor {
-	return &AuthInterceptor{jwtHelper: jwtHelper, roles: roles}
+// NewAuthInterceptor creates an interceptor authorizing methods from roles by bearer token
+// and methods from scopes by API key. Methods absent in both maps are public, anonymous
+// callers of them get the tenant of the x-tenant-id entry or the default tenant.
+func NewAuthInterceptor(
+	jwtHelper Helper,
+	roles map[string][]uint64,
+	apiKeyValidator APIKeyValidator,
+	scopes map[string]string,
+	defaultTenant string,
+) *AuthInterceptor {
+	return &AuthInterceptor{
+		jwtHelper:       jwtHelper,
+		roles:           roles,
+		apiKeyValidator: apiKeyValidator,
+		scopes:          scopes,
+		defaultTenant:   defaultTenant,
+	}
 }
 
 func (i *AuthInterceptor) AuthorizeHandler(ctx context.Context) (context.Context, error) {
@@ -22,18 +48,33 @@ func (i *AuthInterceptor) AuthorizeHandler(ctx context.Context) (context.Context
 	method := fromContext.Method()
 
 	accessibleRoles, ok := i.roles[method]
-	if !ok {
-		// everyone can access
-		return ctx, nil
+	scope, scoped := i.scopes[method]
+	public := !ok && !scoped
+
+	if key := apiKeyFromMD(ctx); key != "" {
+		return i.authorizeAPIKey(ctx, key, scope, scoped || public)
 	}
 
 	token, err := grpc_auth.AuthFromMD(ctx, "bearer")
 	if err != nil {
+		if public {
+			// everyone can access
+			return i.anonymous(ctx), nil
+		}
 		return nil, err
 	}
 
+	if !ok && !public {
+		// method is reachable by API keys only
+		return nil, ErrForbidden
+	}
+
 	tokenMC, err := i.jwtHelper.ParseToken(token)
 	if err != nil {
+		if public {
+			// an expired or foreign token does not keep anyone from public methods
+			return i.anonymous(ctx), nil
+		}
 		return nil, ErrBadToken
 	}
 
@@ -41,6 +82,26 @@ func (i *AuthInterceptor) AuthorizeHandler(ctx context.Context) (context.Context
 
 	grpc_ctxtags.Extract(ctx).Set("role_id", claims.RoleID)
 	grpc_ctxtags.Extract(ctx).Set("user_id", claims.UserID)
+	grpc_ctxtags.Extract(ctx).Set("tenant_id", claims.TenantID)
+
+	ctx = context.WithValue(ctx, ctxRoleID{}, claims.RoleID)
+	ctx = context.WithValue(ctx, ctxUserID{}, claims.UserID)
+	ctx = context.WithValue(ctx, ctxCustomerGroup{}, claims.CustomerGroup)
+
+	if public {
+		if claims.TenantID == "" {
+			// tokens issued before tenants were introduced
+			return i.anonymous(ctx), nil
+		}
+		return tenant.ContextWithTenant(ctx, claims.TenantID), nil
+	}
+
+	ctx = tenant.ContextWithTenant(ctx, claims.TenantID)
+
+	// an empty list of roles lets in every signed-in user
+	if len(accessibleRoles) == 0 {
+		return ctx, nil
+	}
 
 	for _, role := range accessibleRoles {
 		if role == claims.RoleID {
@@ -48,8 +109,52 @@ func (i *AuthInterceptor) AuthorizeHandler(ctx context.Context) (context.Context
 		}
 	}
 
-	ctx = context.WithValue(ctx, "role_id", claims.RoleID)
-	ctx = context.WithValue(ctx, "user_id", claims.UserID)
+	return nil, ErrForbidden
+}
+
+func (i *AuthInterceptor) authorizeAPIKey(ctx context.Context, key, scope string, allowed bool) (context.Context, error) {
+	if !allowed || i.apiKeyValidator == nil {
+		return nil, ErrForbidden
+	}
+
+	claims, err := i.apiKeyValidator.ValidateAPIKey(ctx, key)
+	if err != nil {
+		return nil, ErrBadAPIKey
+	}
+
+	if scope != "" && !claims.HasScope(scope) {
+		return nil, ErrForbidden
+	}
+
+	grpc_ctxtags.Extract(ctx).Set("api_key_id", claims.KeyID)
+	grpc_ctxtags.Extract(ctx).Set("tenant_id", claims.TenantID)
+
+	ctx = context.WithValue(ctx, ctxAPIKeyID{}, claims.KeyID)
+	ctx = context.WithValue(ctx, ctxAPIKeyScopes{}, claims.Scopes)
+
+	return tenant.ContextWithTenant(ctx, claims.TenantID), nil
+}
+
+// anonymous scopes the call of a public method to the tenant the caller names,
+// to the default tenant when it names none
+func (i *AuthInterceptor) anonymous(ctx context.Context) context.Context {
+	tenantID := i.defaultTenant
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TenantHeader); len(values) != 0 && values[0] != "" {
+			tenantID = values[0]
+		}
+	}
+
+	if tenantID == "" {
+		return ctx
+	}
+
+	grpc_ctxtags.Extract(ctx).Set("tenant_id", tenantID)
+	return tenant.ContextWithTenant(ctx, tenantID)
+}
 
-	return ctx, nil
+// HasRole reports whether the request was authenticated with a token of the role
+func HasRole(ctx context.Context, roleID uint64) bool {
+	role, ok := ctx.Value(ctxRoleID{}).(uint64)
+	return ok && role == roleID
 }
@@ -0,0 +1,174 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	publicMethod = "/products.v1.ProductService/AllProducts"
+	adminMethod  = "/products.v1.ProductService/CreateProduct"
+	userMethod   = "/products.v1.ProductService/CreateReview"
+	adminRoleID  = 1
+)
+
+type methodStream struct {
+	grpc.ServerTransportStream
+	method string
+}
+
+func (s methodStream) Method() string { return s.method }
+
+type keyValidator map[string]*APIKeyClaims
+
+func (v keyValidator) ValidateAPIKey(_ context.Context, key string) (*APIKeyClaims, error) {
+	if claims, ok := v[key]; ok {
+		return claims, nil
+	}
+	return nil, errors.New("unknown key")
+}
+
+func callContext(method string, md ...string) context.Context {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), methodStream{method: method})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(md...))
+}
+
+func newTestInterceptor() (*AuthInterceptor, Helper) {
+	helper := NewHelper("secret")
+	interceptor := NewAuthInterceptor(
+		helper,
+		map[string][]uint64{adminMethod: {adminRoleID}, userMethod: {}},
+		nil,
+		nil,
+		"default",
+	)
+	return interceptor, helper
+}
+
+func TestAuthorizeHandlerScopesAnonymousCalls(t *testing.T) {
+	interceptor, helper := newTestInterceptor()
+
+	// tokens issued before tenants were introduced carry none
+	pair, err := helper.GeneratePair("user", "test", "", "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		md     []string
+		tenant string
+	}{
+		{name: "no credentials", tenant: "default"},
+		{name: "tenant named", md: []string{TenantHeader, "shop"}, tenant: "shop"},
+		{name: "bad token", md: []string{"authorization", "bearer garbage"}, tenant: "default"},
+		{name: "bad token with tenant named", md: []string{"authorization", "bearer garbage", TenantHeader, "shop"}, tenant: "shop"},
+		{name: "token without tenant", md: []string{"authorization", "bearer " + pair.AccessToken}, tenant: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := interceptor.AuthorizeHandler(callContext(publicMethod, tt.md...))
+			if err != nil {
+				t.Fatalf("public method refused: %v", err)
+			}
+
+			tenantID, err := tenant.FromContext(ctx)
+			if err != nil {
+				t.Fatalf("no tenant in context: %v", err)
+			}
+			if tenantID != tt.tenant {
+				t.Errorf("got tenant %q, want %q", tenantID, tt.tenant)
+			}
+		})
+	}
+}
+
+func TestAuthorizeHandlerTakesTenantFromToken(t *testing.T) {
+	interceptor, helper := newTestInterceptor()
+
+	pair, err := helper.GeneratePair("user", "test", "tenant-a", "", adminRoleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, method := range []string{publicMethod, adminMethod} {
+		// the tenant named by the caller never overrides the one of the token
+		ctx, err := interceptor.AuthorizeHandler(callContext(method,
+			"authorization", "bearer "+pair.AccessToken,
+			TenantHeader, "tenant-b",
+		))
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+
+		if tenantID, _ := tenant.FromContext(ctx); tenantID != "tenant-a" {
+			t.Errorf("%s: got tenant %q, want tenant-a", method, tenantID)
+		}
+	}
+}
+
+func TestAuthorizeHandlerRefusesBadTokenOnProtectedMethod(t *testing.T) {
+	interceptor, _ := newTestInterceptor()
+
+	_, err := interceptor.AuthorizeHandler(callContext(adminMethod, "authorization", "bearer garbage"))
+	if !errors.Is(err, ErrBadToken) {
+		t.Errorf("got error %v, want %v", err, ErrBadToken)
+	}
+
+	_, err = interceptor.AuthorizeHandler(callContext(adminMethod, TenantHeader, "tenant-a"))
+	if err == nil {
+		t.Error("protected method was reached anonymously")
+	}
+}
+
+func TestAuthorizeHandlerKeepsScopesOfAPIKey(t *testing.T) {
+	interceptor := NewAuthInterceptor(
+		NewHelper("secret"),
+		nil,
+		keyValidator{"key": {KeyID: "k1", TenantID: "tenant-a", Scopes: []string{"products:read"}}},
+		nil,
+		"default",
+	)
+
+	ctx, err := interceptor.AuthorizeHandler(callContext(publicMethod, APIKeyHeader, "key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !HasScope(ctx, "products:read") {
+		t.Error("scope of the API key is missing")
+	}
+	if HasScope(ctx, "products:write") {
+		t.Error("API key got a scope it does not have")
+	}
+
+	ctx, err = interceptor.AuthorizeHandler(callContext(publicMethod))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if HasScope(ctx, "products:read") {
+		t.Error("anonymous caller got a scope")
+	}
+}
+
+func TestAuthorizeHandlerLetsSignedInUsersCallMethodsWithoutRoles(t *testing.T) {
+	interceptor, helper := newTestInterceptor()
+
+	pair, err := helper.GeneratePair("user", "test", "tenant-a", "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = interceptor.AuthorizeHandler(callContext(userMethod, "authorization", "bearer "+pair.AccessToken)); err != nil {
+		t.Errorf("signed-in user refused: %v", err)
+	}
+
+	if _, err = interceptor.AuthorizeHandler(callContext(userMethod)); err == nil {
+		t.Error("method was reached anonymously")
+	}
+}
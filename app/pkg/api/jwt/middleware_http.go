@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
 )
 
 func Middleware(h http.HandlerFunc, secretJWT string, roleID ...uint64) http.HandlerFunc {
@@ -36,7 +38,7 @@ func Middleware(h http.HandlerFunc, secretJWT string, roleID ...uint64) http.Han
 			}
 			claims := helper.ParseMapClaims(mapClaims)
 
-			pair, err := helper.GeneratePair(claims.UserID, claims.IssuerName, claims.RoleID)
+			pair, err := helper.GeneratePair(claims.UserID, claims.IssuerName, claims.TenantID, claims.CustomerGroup, claims.RoleID)
 			if err != nil {
 				w.WriteHeader(http.StatusUnauthorized)
 				w.Write([]byte("bad access and refresh cookies"))
@@ -67,8 +69,41 @@ func Middleware(h http.HandlerFunc, secretJWT string, roleID ...uint64) http.Han
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), "user_id", tokenClaims.UserID)
-		ctx = context.WithValue(ctx, "user_role_id", tokenClaims.RoleID)
+		ctx := context.WithValue(r.Context(), ctxUserID{}, tokenClaims.UserID)
+		ctx = context.WithValue(ctx, ctxRoleID{}, tokenClaims.RoleID)
+		ctx = context.WithValue(ctx, ctxCustomerGroup{}, tokenClaims.CustomerGroup)
+		ctx = tenant.ContextWithTenant(ctx, tokenClaims.TenantID)
+		h(w, r.WithContext(ctx))
+	}
+}
+
+// MiddlewareWithAPIKey accepts requests carrying an API key with the given scope
+// in the x-api-key header and falls back to the cookie based Middleware otherwise.
+func MiddlewareWithAPIKey(h http.HandlerFunc, secretJWT string, validator APIKeyValidator, scope string, roleID ...uint64) http.HandlerFunc {
+	cookieMiddleware := Middleware(h, secretJWT, roleID...)
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(APIKeyHeader)
+		if key == "" {
+			cookieMiddleware(w, r)
+			return
+		}
+
+		claims, err := validator.ValidateAPIKey(r.Context(), key)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("bad api key"))
+			return
+		}
+
+		if !claims.HasScope(scope) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("forbidden"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), ctxAPIKeyID{}, claims.KeyID)
+		ctx = context.WithValue(ctx, ctxAPIKeyScopes{}, claims.Scopes)
+		ctx = tenant.ContextWithTenant(ctx, claims.TenantID)
 		h(w, r.WithContext(ctx))
 	}
 }
@@ -79,7 +114,7 @@ func unauthorized(w http.ResponseWriter, err error) {
 }
 
 func GetUserID(ctx context.Context) (string, error) {
-	if mr, ok := ctx.Value("user_id").(string); ok && mr != "" {
+	if mr, ok := ctx.Value(ctxUserID{}).(string); ok && mr != "" {
 		return mr, nil
 
 	}
@@ -87,9 +122,14 @@ func GetUserID(ctx context.Context) (string, error) {
 }
 
 func GetRoleID(ctx context.Context) (int, error) {
-	mr := ctx.Value("user_role_id")
-	if roleID, ok := mr.(int); ok {
-		return roleID, nil
+	if roleID, ok := ctx.Value(ctxRoleID{}).(uint64); ok {
+		return int(roleID), nil
 	}
 	return 0, fmt.Errorf("something wrong with user role id in context")
 }
+
+// GetCustomerGroup returns the customer group of the token owner, if there is one
+func GetCustomerGroup(ctx context.Context) (string, bool) {
+	group, ok := ctx.Value(ctxCustomerGroup{}).(string)
+	return group, ok && group != ""
+}
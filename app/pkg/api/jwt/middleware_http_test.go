@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/tenant"
+)
+
+func TestMiddlewareWithAPIKeyAuthorizesByScope(t *testing.T) {
+	validator := keyValidator{"key": {KeyID: "k1", TenantID: "tenant-a", Scopes: []string{"products:read"}}}
+
+	var tenantID, keyID string
+	reached := func(w http.ResponseWriter, r *http.Request) {
+		tenantID, _ = tenant.FromContext(r.Context())
+		keyID, _ = GetAPIKeyID(r.Context())
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		scope  string
+		status int
+	}{
+		{name: "key with scope", key: "key", scope: "products:read", status: http.StatusOK},
+		{name: "key without scope", key: "key", scope: "products:write", status: http.StatusForbidden},
+		{name: "unknown key", key: "other", scope: "products:read", status: http.StatusUnauthorized},
+		{name: "no key and no cookie", scope: "products:read", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantID, keyID = "", ""
+			handler := MiddlewareWithAPIKey(reached, "secret", validator, tt.scope, adminRoleID)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.key != "" {
+				r.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("got status %d, want %d", w.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if tenantID != "tenant-a" || keyID != "k1" {
+				t.Errorf("got tenant %q and key %q, want tenant-a and k1", tenantID, keyID)
+			}
+		})
+	}
+}
@@ -24,36 +24,43 @@ type CustomClaims struct {
 	IssuedAt   time.Time
 	IssuerName string
 	RoleID     uint64
+	TenantID   string
+	// CustomerGroup selects the price list, empty for retail customers
+	CustomerGroup string
 }
 
-func newAccessTokenClaims(userID, issuerName string, roleID uint64) *CustomClaims {
-	claims := newClaims(userID, issuerName, roleID)
+func newAccessTokenClaims(userID, issuerName, tenantID, customerGroup string, roleID uint64) *CustomClaims {
+	claims := newClaims(userID, issuerName, tenantID, customerGroup, roleID)
 	claims.ExpireAt = claims.ExpireAt.Add(AccessTokenDuration * time.Minute)
 	return claims
 }
 
-func newRefreshTokenClaims(userID, issuerName string, roleID uint64) *CustomClaims {
-	claims := newClaims(userID, issuerName, roleID)
+func newRefreshTokenClaims(userID, issuerName, tenantID, customerGroup string, roleID uint64) *CustomClaims {
+	claims := newClaims(userID, issuerName, tenantID, customerGroup, roleID)
 	claims.ExpireAt = claims.ExpireAt.Add(RefreshTokenDuration * time.Hour)
 	return claims
 }
 
-func newClaims(userID, issuerName string, roleID uint64) *CustomClaims {
+func newClaims(userID, issuerName, tenantID, customerGroup string, roleID uint64) *CustomClaims {
 	return &CustomClaims{
-		ExpireAt:   time.Now(),
-		UserID:     userID,
-		IssuedAt:   time.Now(),
-		IssuerName: issuerName,
-		RoleID:     roleID,
+		ExpireAt:      time.Now(),
+		UserID:        userID,
+		IssuedAt:      time.Now(),
+		IssuerName:    issuerName,
+		RoleID:        roleID,
+		TenantID:      tenantID,
+		CustomerGroup: customerGroup,
 	}
 }
 
 func (c *CustomClaims) ToMapClaims() jwt.MapClaims {
 	return jwt.MapClaims{
-		"exp":     jwt.At(c.ExpireAt),
-		"id":      c.UserID,
-		"iss_at":  jwt.At(time.Now()),
-		"iss":     c.IssuerName,
-		"role_id": c.RoleID,
+		"exp":            jwt.At(c.ExpireAt),
+		"id":             c.UserID,
+		"iss_at":         jwt.At(time.Now()),
+		"iss":            c.IssuerName,
+		"role_id":        c.RoleID,
+		"tenant_id":      c.TenantID,
+		"customer_group": c.CustomerGroup,
 	}
 }
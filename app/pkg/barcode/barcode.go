@@ -0,0 +1,81 @@
+package barcode
+
+import (
+	"errors"
+	"strings"
+)
+
+// Type is the symbology a barcode is encoded in, told apart by the number of digits
+type Type string
+
+const (
+	EAN8   Type = "ean8"
+	UPCA   Type = "upc_a"
+	EAN13  Type = "ean13"
+	GTIN14 Type = "gtin14"
+)
+
+// gtinLength is the length every barcode is padded to for comparison
+const gtinLength = 14
+
+var (
+	ErrNotDigits  = errors.New("must contain digits only")
+	ErrLength     = errors.New("must have 8, 12, 13 or 14 digits")
+	ErrCheckDigit = errors.New("has a wrong check digit")
+)
+
+var types = map[int]Type{
+	8:  EAN8,
+	12: UPCA,
+	13: EAN13,
+	14: GTIN14,
+}
+
+// Barcode is a validated product code
+type Barcode struct {
+	// Code as it was given, without surrounding spaces
+	Code string
+	Type Type
+	// GTIN is the code padded with zeros to 14 digits, e.g. the UPC-A 012345678905
+	// and the EAN-13 0012345678905 share the GTIN 00012345678905
+	GTIN string
+}
+
+// Parse detects the type of the code by its length and validates its check digit
+func Parse(code string) (Barcode, error) {
+	code = strings.TrimSpace(code)
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return Barcode{}, ErrNotDigits
+		}
+	}
+
+	t, ok := types[len(code)]
+	if !ok {
+		return Barcode{}, ErrLength
+	}
+
+	if CheckDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return Barcode{}, ErrCheckDigit
+	}
+
+	return Barcode{
+		Code: code,
+		Type: t,
+		GTIN: strings.Repeat("0", gtinLength-len(code)) + code,
+	}, nil
+}
+
+// CheckDigit computes the GS1 mod 10 check digit of the digits preceding it:
+// counting from the right, digits are weighted 3 and 1 in turn
+func CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,86 @@
+package barcode
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseValidCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		typ  Type
+		gtin string
+	}{
+		{name: "GTIN-8", code: "96385074", typ: EAN8, gtin: "00000096385074"},
+		{name: "GTIN-8 check digit 7", code: "73513537", typ: EAN8, gtin: "00000073513537"},
+		{name: "GTIN-12", code: "036000291452", typ: UPCA, gtin: "00036000291452"},
+		{name: "GTIN-13", code: "4006381333931", typ: EAN13, gtin: "04006381333931"},
+		{name: "GTIN-13 check digit 7", code: "5901234123457", typ: EAN13, gtin: "05901234123457"},
+		{name: "GTIN-14", code: "10012345678902", typ: GTIN14, gtin: "10012345678902"},
+		{name: "check digit 0", code: "0000000000000", typ: EAN13, gtin: "00000000000000"},
+		{name: "surrounding spaces", code: " 4006381333931\n", typ: EAN13, gtin: "04006381333931"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Parse(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.Type != tt.typ || b.GTIN != tt.gtin {
+				t.Errorf("got type %s and GTIN %s, want %s and %s", b.Type, b.GTIN, tt.typ, tt.gtin)
+			}
+		})
+	}
+}
+
+func TestParseSharesGTINAcrossLengths(t *testing.T) {
+	upc, err := Parse("012345678905")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ean, err := Parse("0012345678905")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if upc.GTIN != ean.GTIN {
+		t.Errorf("UPC-A %s and EAN-13 %s got different GTINs", upc.GTIN, ean.GTIN)
+	}
+	if upc.Code == ean.Code {
+		t.Error("the codes as given were lost")
+	}
+}
+
+func TestParseRefusesInvalidCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want error
+	}{
+		{name: "GTIN-8 bad check digit", code: "96385075", want: ErrCheckDigit},
+		{name: "GTIN-12 bad check digit", code: "036000291453", want: ErrCheckDigit},
+		{name: "GTIN-13 bad check digit", code: "4006381333932", want: ErrCheckDigit},
+		{name: "GTIN-14 bad check digit", code: "10012345678900", want: ErrCheckDigit},
+		{name: "swapped digits", code: "4006381339331", want: ErrCheckDigit},
+		{name: "empty", code: "", want: ErrLength},
+		{name: "7 digits", code: "9638507", want: ErrLength},
+		{name: "9 digits", code: "963850742", want: ErrLength},
+		{name: "11 digits", code: "03600029145", want: ErrLength},
+		{name: "15 digits", code: "100123456789020", want: ErrLength},
+		{name: "letters", code: "40063813339A1", want: ErrNotDigits},
+		{name: "inner space", code: "4006381 333931", want: ErrNotDigits},
+		{name: "hyphen", code: "400-6381333931", want: ErrNotDigits},
+		{name: "negative sign", code: "-96385074", want: ErrNotDigits},
+		{name: "non-ASCII digits", code: "٩٦٣٨٥٠٧٤", want: ErrNotDigits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.code); !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,27 +1,86 @@
 package model
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgreSQL error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeUniqueViolation      = "23505"
+	codeForeignKeyViolation  = "23503"
+	codeNotNullViolation     = "23502"
+	codeCheckViolation       = "23514"
+	codeInvalidText          = "22P02"
+	codeStringTruncation     = "22001"
+	codeNumericOutOfRange    = "22003"
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+	codeAdminShutdown        = "57P01"
+	codeCannotConnectNow     = "57P03"
+	codeTooManyConnections   = "53300"
+)
 
 func ErrCommit(err error) error {
-	return fmt.Errorf("failed to commit Tx due to error: %v", err)
+	return fmt.Errorf("failed to commit Tx due to error: %w", classify(err))
 }
 
 func ErrRollback(err error) error {
-	return fmt.Errorf("failed to rollback Tx due to error: %v", err)
+	return fmt.Errorf("failed to rollback Tx due to error: %w", classify(err))
 }
 
 func ErrCreateTx(err error) error {
-	return fmt.Errorf("failed to create Tx due to error: %v", err)
+	return fmt.Errorf("failed to create Tx due to error: %w", classify(err))
 }
 
 func ErrCreateQuery(err error) error {
-	return fmt.Errorf("failed to create SQL Query due to error: %v", err)
+	return fmt.Errorf("failed to create SQL Query due to error: %w", err)
 }
 
 func ErrScan(err error) error {
-	return fmt.Errorf("failed to scan due to error: %v", err)
+	return fmt.Errorf("failed to scan due to error: %w", classify(err))
 }
 
 func ErrDoQuery(err error) error {
-	return fmt.Errorf("failed to query due to error: %v", err)
+	return fmt.Errorf("failed to query due to error: %w", classify(err))
+}
+
+// classify wraps database errors with a known meaning into domain errors.
+// Messages of the domain errors never contain SQL or driver details.
+func classify(err error) error {
+	if _, ok := errors.AsDomain(err); ok {
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.WithKind(err, errors.KindNotFound, "not found")
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case codeUniqueViolation:
+			return errors.WithKind(err, errors.KindConflict, "already exists")
+		case codeForeignKeyViolation:
+			return errors.WithKind(err, errors.KindValidation, "references a missing entity")
+		case codeNotNullViolation, codeCheckViolation, codeInvalidText, codeStringTruncation, codeNumericOutOfRange:
+			return errors.WithKind(err, errors.KindValidation, "invalid value")
+		case codeSerializationFailure, codeDeadlockDetected,
+			codeAdminShutdown, codeCannotConnectNow, codeTooManyConnections:
+			return errors.WithKind(err, errors.KindUnavailable, "database is unavailable")
+		}
+		return err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || pgconn.Timeout(err) || errors.Is(err, context.DeadlineExceeded) {
+		return errors.WithKind(err, errors.KindUnavailable, "database is unavailable")
+	}
+
+	return err
 }
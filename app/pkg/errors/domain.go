@@ -0,0 +1,111 @@
+package errors
+
+// Kind classifies an error independently of the layer it was raised in
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindPermission
+	KindUnauthenticated
+	KindUnavailable
+	KindOutOfRange
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "NOT_FOUND"
+	case KindConflict:
+		return "CONFLICT"
+	case KindValidation:
+		return "VALIDATION"
+	case KindPermission:
+		return "PERMISSION_DENIED"
+	case KindUnauthenticated:
+		return "UNAUTHENTICATED"
+	case KindUnavailable:
+		return "UNAVAILABLE"
+	case KindOutOfRange:
+		return "OUT_OF_RANGE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// DomainError is an error of a known Kind. Message is safe to be shown to clients,
+// the wrapped cause is not.
+type DomainError struct {
+	Kind       Kind
+	Message    string
+	Violations FieldViolations
+	cause      error
+}
+
+func (e *DomainError) Error() string {
+	if e.cause == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.cause.Error()
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports domain errors of the same kind and message as equal,
+// so package level sentinels can be matched with Is.
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Kind == e.Kind && t.Message == e.Message
+}
+
+func NotFound(msg string) error {
+	return &DomainError{Kind: KindNotFound, Message: msg}
+}
+
+func Conflict(msg string) error {
+	return &DomainError{Kind: KindConflict, Message: msg}
+}
+
+func Validation(msg string) error {
+	return &DomainError{Kind: KindValidation, Message: msg}
+}
+
+func PermissionDenied(msg string) error {
+	return &DomainError{Kind: KindPermission, Message: msg}
+}
+
+func Unauthenticated(msg string) error {
+	return &DomainError{Kind: KindUnauthenticated, Message: msg}
+}
+
+// OutOfRange is returned for positions past what is kept, e.g. a change log pruned since
+func OutOfRange(msg string) error {
+	return &DomainError{Kind: KindOutOfRange, Message: msg}
+}
+
+// WithKind wraps err into a DomainError with a client safe message
+func WithKind(err error, kind Kind, msg string) error {
+	return &DomainError{Kind: kind, Message: msg, cause: err}
+}
+
+// KindOf returns the kind of the outermost DomainError in the chain of err
+func KindOf(err error) Kind {
+	var domainErr *DomainError
+	if As(err, &domainErr) {
+		return domainErr.Kind
+	}
+	return KindUnknown
+}
+
+// AsDomain returns the outermost DomainError in the chain of err
+func AsDomain(err error) (*DomainError, bool) {
+	var domainErr *DomainError
+	if As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,30 @@
+package errors
+
+// FieldViolation describes a single invalid field of a request
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// FieldViolations collects violations to report all of them at once
+type FieldViolations []FieldViolation
+
+func (v *FieldViolations) Add(field, description string) {
+	*v = append(*v, FieldViolation{Field: field, Description: description})
+}
+
+// Err returns a validation error carrying the violations or nil when there are none
+func (v FieldViolations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &DomainError{Kind: KindValidation, Message: "invalid fields", Violations: v}
+}
+
+// ViolationsOf returns field violations of the outermost DomainError in the chain of err
+func ViolationsOf(err error) FieldViolations {
+	if domainErr, ok := AsDomain(err); ok {
+		return domainErr.Violations
+	}
+	return nil
+}
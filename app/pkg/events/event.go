@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// SpecVersion is the version of the CloudEvents specification events are written in
+const SpecVersion = "1.0"
+
+// Event is a CloudEvents event in the structured JSON mode. TenantID and Sequence
+// are extension attributes, Sequence orders the events of a subject.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	TenantID        string          `json:"tenantid,omitempty"`
+	Sequence        int64           `json:"sequence,string"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// Publisher delivers events to their consumers. An event is delivered once Publish
+// returns without an error, it may be delivered again when Publish fails.
+type Publisher interface {
+	Publish(context.Context, *Event) error
+	Close() error
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// WriterPublisher writes the events as JSON lines, e.g. to a file or the standard output
+type WriterPublisher struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// NewFilePublisher appends the events to the file, the standard output is written to without a path
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	if path == "" || path == "-" {
+		return NewWriterPublisher(os.Stdout), nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WriterPublisher{w: f, closer: f}, nil
+}
+
+func (p *WriterPublisher) Publish(_ context.Context, e *Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err = p.w.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	if f, ok := p.w.(*os.File); ok && p.closer != nil {
+		return f.Sync()
+	}
+
+	return nil
+}
+
+func (p *WriterPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
@@ -0,0 +1,49 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilePublisherAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	ctx := context.Background()
+
+	// a restart appends to the events written before
+	for _, id := range []string{"e1", "e2"} {
+		publisher, err := NewFilePublisher(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = publisher.Publish(ctx, newTestEvent(id)); err != nil {
+			t.Fatal(err)
+		}
+		if err = publisher.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q is no event: %v", scanner.Text(), err)
+		}
+		ids = append(ids, e.ID)
+	}
+
+	if len(ids) != 2 || ids[0] != "e1" || ids[1] != "e2" {
+		t.Errorf("got events %v, want [e1 e2]", ids)
+	}
+}
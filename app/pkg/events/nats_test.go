@@ -0,0 +1,288 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// natsMessage is a message the server received
+type natsMessage struct {
+	subject string
+	header  string
+	payload []byte
+}
+
+// natsServer speaks the part of the NATS client protocol a publisher uses
+type natsServer struct {
+	listener net.Listener
+	info     string
+	// failPublish answers the ping after the next publish with an error
+	failPublish bool
+
+	mu       sync.Mutex
+	connects []map[string]interface{}
+	accepted int
+	messages chan natsMessage
+}
+
+func newNATSServer(t *testing.T, info string) *natsServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &natsServer{listener: listener, info: info, messages: make(chan natsMessage, 10)}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.accepted++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *natsServer) url(userInfo string) string {
+	if userInfo != "" {
+		userInfo += "@"
+	}
+	return "nats://" + userInfo + s.listener.Addr().String()
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	if _, err := fmt.Fprintf(conn, "INFO %s\r\n", s.info); err != nil {
+		return
+	}
+
+	reader := bufio.NewReader(conn)
+	failNextPing := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+
+		switch op {
+		case "CONNECT":
+			var options map[string]interface{}
+			if err = json.Unmarshal([]byte(args), &options); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.connects = append(s.connects, options)
+			s.mu.Unlock()
+		case "PING":
+			if failNextPing {
+				_, _ = conn.Write([]byte("-ERR 'Permissions Violation'\r\n"))
+				return
+			}
+			if _, err = conn.Write([]byte("PONG\r\n")); err != nil {
+				return
+			}
+		case "PUB", "HPUB":
+			fields := strings.Fields(args)
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			headerSize := 0
+			if op == "HPUB" {
+				headerSize, _ = strconv.Atoi(fields[len(fields)-2])
+			}
+
+			body := make([]byte, size+2)
+			if _, err = io.ReadFull(reader, body); err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			failNextPing = s.failPublish
+			s.failPublish = false
+			s.mu.Unlock()
+
+			s.messages <- natsMessage{
+				subject: fields[0],
+				header:  string(body[:headerSize]),
+				payload: body[headerSize:size],
+			}
+		}
+	}
+}
+
+func (s *natsServer) received(t *testing.T) natsMessage {
+	t.Helper()
+
+	select {
+	case m := <-s.messages:
+		return m
+	case <-time.After(time.Second):
+		t.Fatal("no message was received")
+		return natsMessage{}
+	}
+}
+
+func newTestEvent(id string) *Event {
+	return &Event{
+		SpecVersion: SpecVersion,
+		ID:          id,
+		Source:      "goods-manager",
+		Type:        "product.updated",
+		TenantID:    "tenant-a",
+		Sequence:    7,
+		Data:        json.RawMessage(`{"id":"p1"}`),
+	}
+}
+
+func TestNATSPublisherPublishesWithMessageID(t *testing.T) {
+	server := newNATSServer(t, `{"headers":true}`)
+
+	ctx := context.Background()
+	publisher, err := NewNATSPublisher(ctx, server.url("user:secret"), "goods-manager", "goods.", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+
+	if err = publisher.Publish(ctx, newTestEvent("e1")); err != nil {
+		t.Fatal(err)
+	}
+
+	m := server.received(t)
+	if m.subject != "goods.product.updated" {
+		t.Errorf("got subject %q, want goods.product.updated", m.subject)
+	}
+	if !strings.Contains(m.header, "Nats-Msg-Id: e1\r\n") {
+		t.Errorf("header %q carries no message ID", m.header)
+	}
+
+	var e Event
+	if err = json.Unmarshal(m.payload, &e); err != nil {
+		t.Fatalf("payload is no event: %v", err)
+	}
+	if e.ID != "e1" || e.Sequence != 7 || e.TenantID != "tenant-a" {
+		t.Errorf("got event %+v", e)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.connects) != 1 {
+		t.Fatalf("got %d connects, want 1", len(server.connects))
+	}
+	if options := server.connects[0]; options["user"] != "user" || options["pass"] != "secret" || options["headers"] != true {
+		t.Errorf("got connect options %v", options)
+	}
+}
+
+func TestNATSPublisherPublishesWithoutHeaders(t *testing.T) {
+	server := newNATSServer(t, `{}`)
+
+	ctx := context.Background()
+	publisher, err := NewNATSPublisher(ctx, server.url("token"), "goods-manager", "", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+
+	if err = publisher.Publish(ctx, newTestEvent("e1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if m := server.received(t); m.subject != "product.updated" || m.header != "" {
+		t.Errorf("got message %q with header %q", m.subject, m.header)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if options := server.connects[0]; options["auth_token"] != "token" {
+		t.Errorf("got connect options %v", options)
+	}
+}
+
+func TestNATSPublisherReconnectsAfterError(t *testing.T) {
+	server := newNATSServer(t, `{"headers":true}`)
+
+	ctx := context.Background()
+	publisher, err := NewNATSPublisher(ctx, server.url(""), "goods-manager", "goods", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+
+	server.mu.Lock()
+	server.failPublish = true
+	server.mu.Unlock()
+
+	if err = publisher.Publish(ctx, newTestEvent("e1")); err == nil {
+		t.Fatal("error of the server was not reported")
+	}
+	server.received(t)
+
+	// the event is published again on a new connection
+	if err = publisher.Publish(ctx, newTestEvent("e1")); err != nil {
+		t.Fatal(err)
+	}
+	if m := server.received(t); !strings.Contains(m.header, "Nats-Msg-Id: e1\r\n") {
+		t.Errorf("redelivery %q carries no message ID", m.header)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.accepted != 2 {
+		t.Errorf("got %d connections, want 2", server.accepted)
+	}
+}
+
+func TestNATSPublisherRefusesTLSServers(t *testing.T) {
+	server := newNATSServer(t, `{"tls_required":true}`)
+
+	_, err := NewNATSPublisher(context.Background(), server.url(""), "goods-manager", "", time.Second)
+	if !errors.Is(err, ErrNATSTLS) {
+		t.Errorf("got error %v, want %v", err, ErrNATSTLS)
+	}
+}
+
+func TestNATSPublisherTimesOutOnSilentServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// the server accepts the connection but never greets
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+
+	start := time.Now()
+	_, err = NewNATSPublisher(context.Background(), "nats://"+listener.Addr().String(), "goods-manager", "", 50*time.Millisecond)
+	if err == nil {
+		t.Fatal("connected to a server which never greeted")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("gave up after %v, want about the timeout", elapsed)
+	}
+}
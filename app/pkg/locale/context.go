@@ -0,0 +1,18 @@
+package locale
+
+import (
+	"context"
+)
+
+type ctxLocale struct{}
+
+// ContextWithLocale adds the negotiated locale to context
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, ctxLocale{}, locale)
+}
+
+// FromContext returns the negotiated locale, if there is one
+func FromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(ctxLocale{}).(string)
+	return locale, ok && locale != ""
+}
@@ -0,0 +1,38 @@
+package locale
+
+import (
+	"context"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const MetadataKey = "accept-language"
+
+// UnaryServerInterceptor adds the locale negotiated from the accept-language metadata to context
+func UnaryServerInterceptor(resolver *Resolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(negotiate(ctx, resolver), req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams
+func StreamServerInterceptor(resolver *Resolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = negotiate(ss.Context(), resolver)
+		return handler(srv, wrapped)
+	}
+}
+
+func negotiate(ctx context.Context, resolver *Resolver) context.Context {
+	var value string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) != 0 {
+			value = values[0]
+		}
+	}
+
+	return ContextWithLocale(ctx, resolver.Negotiate(value))
+}
@@ -0,0 +1,14 @@
+package locale
+
+import (
+	"net/http"
+)
+
+// Middleware adds the locale negotiated from the Accept-Language header to context
+func Middleware(h http.Handler, resolver *Resolver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := resolver.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", locale)
+		h.ServeHTTP(w, r.WithContext(ContextWithLocale(r.Context(), locale)))
+	})
+}
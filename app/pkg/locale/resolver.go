@@ -0,0 +1,118 @@
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Resolver picks one of the supported locales for a request and knows
+// which locales content is looked up in when a translation is missing.
+type Resolver struct {
+	defaultLocale string
+	supported     map[string]bool
+	fallback      map[string]string
+}
+
+// NewResolver creates a resolver. fallback maps a locale to the next one
+// to try, every chain ends with the default locale.
+func NewResolver(defaultLocale string, supported []string, fallback map[string]string) *Resolver {
+	r := &Resolver{
+		defaultLocale: normalize(defaultLocale),
+		supported:     make(map[string]bool, len(supported)+1),
+		fallback:      make(map[string]string, len(fallback)),
+	}
+	r.supported[r.defaultLocale] = true
+	for _, l := range supported {
+		r.supported[normalize(l)] = true
+	}
+	for from, to := range fallback {
+		r.fallback[normalize(from)] = normalize(to)
+	}
+	return r
+}
+
+func (r *Resolver) Default() string {
+	return r.defaultLocale
+}
+
+func (r *Resolver) IsSupported(locale string) bool {
+	return r.supported[normalize(locale)]
+}
+
+// Negotiate returns the supported locale the Accept-Language value prefers most,
+// or the default one. A regional tag matches its language, e.g. az-AZ matches az.
+func (r *Resolver) Negotiate(acceptLanguage string) string {
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if r.supported[tag] {
+			return tag
+		}
+		if i := strings.IndexByte(tag, '-'); i > 0 && r.supported[tag[:i]] {
+			return tag[:i]
+		}
+	}
+	return r.defaultLocale
+}
+
+// Chain returns the locales to look content up in, the locale itself first
+func (r *Resolver) Chain(locale string) []string {
+	locale = normalize(locale)
+	if locale == "" {
+		locale = r.defaultLocale
+	}
+
+	chain := []string{locale}
+	seen := map[string]bool{locale: true}
+	for next, ok := r.fallback[locale]; ok && !seen[next]; next, ok = r.fallback[next] {
+		chain = append(chain, next)
+		seen[next] = true
+	}
+	if !seen[r.defaultLocale] {
+		chain = append(chain, r.defaultLocale)
+	}
+	return chain
+}
+
+// parseAcceptLanguage returns the tags ordered by quality, e.g. "az,en;q=0.8"
+func parseAcceptLanguage(value string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(value, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := normalize(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if parsed, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	res := make([]string, len(tags))
+	for i, t := range tags {
+		res[i] = t.tag
+	}
+	return res
+}
+
+func normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
@@ -0,0 +1,124 @@
+package measure
+
+import (
+	"errors"
+	"math"
+)
+
+// WeightUnit is a unit weights are given in, they are kept in grams
+type WeightUnit string
+
+// LengthUnit is a unit lengths are given in, they are kept in centimeters
+type LengthUnit string
+
+const (
+	Gram     WeightUnit = "g"
+	Kilogram WeightUnit = "kg"
+	Pound    WeightUnit = "lb"
+
+	Centimeter LengthUnit = "cm"
+	Inch       LengthUnit = "in"
+)
+
+var (
+	ErrWeightUnit = errors.New("must be one of g, kg, lb")
+	ErrLengthUnit = errors.New("must be one of cm, in")
+)
+
+var grams = map[WeightUnit]float64{
+	Gram:     1,
+	Kilogram: 1000,
+	Pound:    453.59237,
+}
+
+var centimeters = map[LengthUnit]float64{
+	Centimeter: 1,
+	Inch:       2.54,
+}
+
+// Weight is a weight in grams with the unit it is shown in
+type Weight struct {
+	Grams float64
+	Unit  WeightUnit
+}
+
+// Dimensions are the length, width and height of a package in centimeters with the unit they are shown in
+type Dimensions struct {
+	Length float64
+	Width  float64
+	Height float64
+	Unit   LengthUnit
+}
+
+func NewWeight(value float64, unit WeightUnit) (Weight, error) {
+	g, err := ToGrams(value, unit)
+	if err != nil {
+		return Weight{}, err
+	}
+	return Weight{Grams: g, Unit: unit}, nil
+}
+
+func NewDimensions(length, width, height float64, unit LengthUnit) (Dimensions, error) {
+	factor, ok := centimeters[unit]
+	if !ok {
+		return Dimensions{}, ErrLengthUnit
+	}
+	return Dimensions{
+		Length: length * factor,
+		Width:  width * factor,
+		Height: height * factor,
+		Unit:   unit,
+	}, nil
+}
+
+// ToGrams converts the weight given in the unit to grams
+func ToGrams(value float64, unit WeightUnit) (float64, error) {
+	factor, ok := grams[unit]
+	if !ok {
+		return 0, ErrWeightUnit
+	}
+	return value * factor, nil
+}
+
+// ToCentimeters converts the length given in the unit to centimeters
+func ToCentimeters(value float64, unit LengthUnit) (float64, error) {
+	factor, ok := centimeters[unit]
+	if !ok {
+		return 0, ErrLengthUnit
+	}
+	return value * factor, nil
+}
+
+// In returns the weight in the unit rounded to a milligram precision of grams,
+// an unknown unit falls back to grams
+func (w Weight) In(unit WeightUnit) float64 {
+	factor, ok := grams[unit]
+	if !ok {
+		factor = 1
+	}
+	return round(w.Grams / factor)
+}
+
+// Value returns the weight in the unit it is shown in
+func (w Weight) Value() float64 {
+	return w.In(w.Unit)
+}
+
+// In returns length, width and height in the unit, an unknown unit falls back to centimeters
+func (d Dimensions) In(unit LengthUnit) (length, width, height float64) {
+	factor, ok := centimeters[unit]
+	if !ok {
+		factor = 1
+	}
+	return round(d.Length / factor), round(d.Width / factor), round(d.Height / factor)
+}
+
+// Volume returns the volume of the package in cubic centimeters
+func (d Dimensions) Volume() float64 {
+	return d.Length * d.Width * d.Height
+}
+
+// round drops the noise of unit conversions
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
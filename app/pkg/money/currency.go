@@ -0,0 +1,42 @@
+package money
+
+import (
+	"errors"
+	"regexp"
+)
+
+// DefaultExponent is the exponent of most currencies, e.g. a euro has 100 cents
+const DefaultExponent = 2
+
+var ErrUnknownCurrency = errors.New("must be an ISO 4217 currency code, e.g. EUR")
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// exponents lists the ISO 4217 currencies whose minor unit differs from the default one
+var exponents = map[string]uint8{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Currency is an ISO 4217 currency. Exponent is the number of decimal digits
+// of its minor unit, e.g. 2 for EUR, 0 for JPY and 3 for KWD.
+type Currency struct {
+	Code     string
+	Exponent uint8
+}
+
+// CurrencyOf returns the currency with the ISO 4217 code and its exponent
+func CurrencyOf(code string) (Currency, error) {
+	if !currencyCode.MatchString(code) {
+		return Currency{}, ErrUnknownCurrency
+	}
+
+	exponent, ok := exponents[code]
+	if !ok {
+		exponent = DefaultExponent
+	}
+
+	return Currency{Code: code, Exponent: exponent}, nil
+}
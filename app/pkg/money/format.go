@@ -0,0 +1,81 @@
+package money
+
+import (
+	"strings"
+)
+
+// convention is how amounts are written in a language
+type convention struct {
+	// group separates thousands, several languages use a no-break space for it
+	group, point string
+	// symbolFirst puts the symbol before the amount, spaced tells whether a space separates them
+	symbolFirst, spaced bool
+}
+
+var (
+	english = convention{group: ",", point: ".", symbolFirst: true}
+
+	conventions = map[string]convention{
+		"en": english,
+		"ja": english,
+		"zh": english,
+		"de": {group: ".", point: ",", spaced: true},
+		"es": {group: ".", point: ",", spaced: true},
+		"it": {group: ".", point: ",", spaced: true},
+		"az": {group: ".", point: ",", spaced: true},
+		"nl": {group: ".", point: ",", symbolFirst: true, spaced: true},
+		"tr": {group: ".", point: ",", symbolFirst: true},
+		"fr": {group: "\u202f", point: ",", spaced: true},
+		"pl": {group: "\u00a0", point: ",", spaced: true},
+		"ru": {group: "\u00a0", point: ",", spaced: true},
+		"uk": {group: "\u00a0", point: ",", spaced: true},
+	}
+
+	symbols = map[string]string{
+		"AZN": "₼",
+		"CNY": "¥",
+		"EUR": "€",
+		"GBP": "£",
+		"INR": "₹",
+		"JPY": "¥",
+		"KRW": "₩",
+		"RUB": "₽",
+		"TRY": "₺",
+		"UAH": "₴",
+		"USD": "$",
+	}
+)
+
+// Format writes the amount the way readers of the locale expect, e.g. $1,234.50 for en,
+// 1.234,50 € for de and 1 234,50 ₽ for ru. Locales are BCP 47 tags, only the language
+// is taken into account and unknown languages are written like English.
+// Currencies without a well-known symbol are written with their code.
+func (m Money) Format(locale string) string {
+	language := strings.ToLower(strings.SplitN(strings.ReplaceAll(locale, "_", "-"), "-", 2)[0])
+	c, ok := conventions[language]
+	if !ok {
+		c = english
+	}
+
+	symbol, ok := symbols[m.Currency.Code]
+	if !ok {
+		symbol = m.Currency.Code
+		c.spaced = true
+	}
+
+	amount := m.decimal(c.point, c.group)
+	sign := ""
+	if m.Amount < 0 {
+		sign, amount = "-", amount[1:]
+	}
+
+	space := ""
+	if c.spaced {
+		space = "\u00a0"
+	}
+
+	if c.symbolFirst {
+		return sign + symbol + space + amount
+	}
+	return sign + amount + space + symbol
+}
@@ -0,0 +1,37 @@
+package money
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	rub := Currency{Code: "RUB", Exponent: 2}
+	usd := Currency{Code: "USD", Exponent: 2}
+
+	tests := []struct {
+		name   string
+		money  Money
+		locale string
+		want   string
+	}{
+		{name: "english", money: New(123450, eur), locale: "en", want: "€1,234.50"},
+		{name: "english with region", money: New(-123450, usd), locale: "en-US", want: "-$1,234.50"},
+		{name: "german", money: New(123450, eur), locale: "de", want: "1.234,50\u00a0€"},
+		{name: "german negative", money: New(-5, eur), locale: "de-AT", want: "-0,05\u00a0€"},
+		{name: "upper case tag", money: New(1999, eur), locale: "DE", want: "19,99\u00a0€"},
+		{name: "russian", money: New(123450, rub), locale: "ru", want: "1\u00a0234,50\u00a0₽"},
+		{name: "french", money: New(123456789, eur), locale: "fr_FR", want: "1\u202f234\u202f567,89\u00a0€"},
+		{name: "dutch", money: New(1999, eur), locale: "nl", want: "€\u00a019,99"},
+		{name: "no minor unit", money: New(1234567, jpy), locale: "ja", want: "¥1,234,567"},
+		{name: "thousandths without symbol", money: New(1234567, kwd), locale: "en", want: "KWD\u00a01,234.567"},
+		{name: "thousandths in german", money: New(-1234567, kwd), locale: "de", want: "-1.234,567\u00a0KWD"},
+		{name: "unknown language", money: New(1999, eur), locale: "xx", want: "€19.99"},
+		{name: "no locale", money: New(100, usd), locale: "", want: "$1.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.Format(tt.locale); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrCurrencyMismatch = errors.New("currencies do not match")
+
+// Money is an amount in minor units of the currency, e.g. 1999 EUR is 19.99 euros
+// and 1999 JPY is 1999 yen. Arithmetic never rounds silently and reports overflows.
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func New(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// FromMinor makes money of an unsigned amount as prices travel in the API
+func FromMinor(amount uint64, currency Currency) (Money, error) {
+	if amount > math.MaxInt64 {
+		return Money{}, ErrOverflow
+	}
+	return New(int64(amount), currency), nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Minor returns the amount for the API, negative amounts are not prices and become zero
+func (m Money) Minor() uint64 {
+	if m.Amount < 0 {
+		return 0
+	}
+	return uint64(m.Amount)
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	sum := m.Amount + o.Amount
+	if (o.Amount > 0 && sum < m.Amount) || (o.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return New(sum, m.Currency), nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(New(-o.Amount, o.Currency))
+}
+
+// Mul multiplies the amount by a quantity
+func (m Money) Mul(n int64) (Money, error) {
+	amount, err := mulDiv(m.Amount, n, 1, HalfUp)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(amount, m.Currency), nil
+}
+
+// MulFrac multiplies the amount by num/den and rounds the result to a minor unit,
+// e.g. MulFrac(19, 100, HalfUp) is 19% of the amount
+func (m Money) MulFrac(num, den int64, mode RoundingMode) (Money, error) {
+	amount, err := mulDiv(m.Amount, num, den, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(amount, m.Currency), nil
+}
+
+// Cmp compares the amounts of the same currency, -1 if m is less than o
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency != o.Currency {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Decimal returns the amount in major units without grouping, e.g. -1234.50
+func (m Money) Decimal() string {
+	return m.decimal(".", "")
+}
+
+// String returns the amount with the currency code, e.g. 1234.50 EUR
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency.Code
+}
+
+func (m Money) decimal(point, group string) string {
+	digits := strconv.FormatUint(absAmount(m.Amount), 10)
+
+	exponent := int(m.Currency.Exponent)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+	if group != "" {
+		whole = groupThousands(whole, group)
+	}
+
+	var b strings.Builder
+	if m.Amount < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(whole)
+	if exponent > 0 {
+		b.WriteString(point)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+func groupThousands(digits, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head != 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() != 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// absAmount returns the absolute amount, which fits an uint64 even for the smallest int64
+func absAmount(amount int64) uint64 {
+	if amount < 0 {
+		return uint64(-(amount + 1)) + 1
+	}
+	return uint64(amount)
+}
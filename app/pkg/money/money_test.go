@@ -0,0 +1,109 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var (
+	eur = Currency{Code: "EUR", Exponent: 2}
+	jpy = Currency{Code: "JPY", Exponent: 0}
+	kwd = Currency{Code: "KWD", Exponent: 3}
+)
+
+func TestCurrencyOf(t *testing.T) {
+	tests := map[string]uint8{"EUR": 2, "USD": 2, "JPY": 0, "KRW": 0, "KWD": 3, "BHD": 3, "CLF": 4}
+
+	for code, want := range tests {
+		t.Run(code, func(t *testing.T) {
+			c, err := CurrencyOf(code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Code != code || c.Exponent != want {
+				t.Errorf("got %+v, want exponent %d", c, want)
+			}
+		})
+	}
+
+	for _, code := range []string{"", "eur", "EURO", "E1R"} {
+		if _, err := CurrencyOf(code); !errors.Is(err, ErrUnknownCurrency) {
+			t.Errorf("%q: got error %v, want %v", code, err, ErrUnknownCurrency)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{name: "cents", money: New(1999, eur), want: "19.99"},
+		{name: "less than a unit", money: New(5, eur), want: "0.05"},
+		{name: "zero", money: New(0, eur), want: "0.00"},
+		{name: "negative", money: New(-123450, eur), want: "-1234.50"},
+		{name: "negative less than a unit", money: New(-5, eur), want: "-0.05"},
+		{name: "no minor unit", money: New(1999, jpy), want: "1999"},
+		{name: "negative without minor unit", money: New(-7, jpy), want: "-7"},
+		{name: "thousandths", money: New(1234567, kwd), want: "1234.567"},
+		{name: "less than a unit of thousandths", money: New(5, kwd), want: "0.005"},
+		{name: "smallest amount", money: New(math.MinInt64, eur), want: "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.Decimal(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := New(1999, eur).String(); got != "19.99 EUR" {
+		t.Errorf("got %q, want %q", got, "19.99 EUR")
+	}
+}
+
+func TestArithmeticWithNegativeAmounts(t *testing.T) {
+	sum, err := New(-500, eur).Add(New(200, eur))
+	if err != nil || sum.Amount != -300 {
+		t.Errorf("add: got %d, %v, want -300", sum.Amount, err)
+	}
+
+	diff, err := New(200, eur).Sub(New(500, eur))
+	if err != nil || diff.Amount != -300 {
+		t.Errorf("sub: got %d, %v, want -300", diff.Amount, err)
+	}
+	if diff.Minor() != 0 {
+		t.Errorf("minor of a negative amount: got %d, want 0", diff.Minor())
+	}
+
+	product, err := New(-250, eur).Mul(3)
+	if err != nil || product.Amount != -750 {
+		t.Errorf("mul: got %d, %v, want -750", product.Amount, err)
+	}
+
+	share, err := New(-150, eur).MulFrac(19, 100, HalfEven)
+	if err != nil || share.Amount != -28 {
+		t.Errorf("mul frac: got %d, %v, want -28", share.Amount, err)
+	}
+
+	if _, err = New(math.MinInt64, eur).Add(New(-1, eur)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("add below the smallest amount: got error %v, want %v", err, ErrOverflow)
+	}
+	if _, err = New(0, eur).Sub(New(math.MinInt64, eur)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("sub of the smallest amount: got error %v, want %v", err, ErrOverflow)
+	}
+	if _, err = New(100, eur).Add(New(100, jpy)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("add of another currency: got error %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if _, err = New(100, eur).Cmp(New(100, kwd)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("cmp of another currency: got error %v, want %v", err, ErrCurrencyMismatch)
+	}
+
+	cmp, err := New(-1, eur).Cmp(New(0, eur))
+	if err != nil || cmp != -1 {
+		t.Errorf("cmp: got %d, %v, want -1", cmp, err)
+	}
+}
@@ -0,0 +1,75 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+)
+
+// RoundingMode decides where an amount exactly halfway between two minor units goes
+type RoundingMode int
+
+const (
+	// HalfUp rounds halves away from zero, 0.5 cent becomes 1 cent and -0.5 cent -1 cent
+	HalfUp RoundingMode = iota
+	// HalfEven rounds halves to the even neighbour, also known as banker's rounding:
+	// 0.5 cent becomes 0 cents and 1.5 cents 2 cents, so repeated rounding does not drift
+	HalfEven
+)
+
+var (
+	ErrOverflow       = errors.New("amount is out of range")
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+// mulDiv computes a * num / den rounded to an integer without intermediate overflow
+func mulDiv(a, num, den int64, mode RoundingMode) (int64, error) {
+	if den == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	product := new(big.Int).Mul(big.NewInt(a), big.NewInt(num))
+	d := big.NewInt(den)
+	q, r := new(big.Int).QuoRem(product, d, new(big.Int))
+
+	if r.Sign() != 0 {
+		// compare the remainder with half of the divisor, both taken as positive
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+		cmp := twice.Cmp(new(big.Int).Abs(d))
+
+		if cmp > 0 || (cmp == 0 && (mode == HalfUp || q.Bit(0) == 1)) {
+			// the quotient is truncated towards zero, rounding moves it away from zero
+			if product.Sign() == d.Sign() {
+				q.Add(q, big.NewInt(1))
+			} else {
+				q.Sub(q, big.NewInt(1))
+			}
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return q.Int64(), nil
+}
+
+// Rescale converts an amount of minor units with one exponent to minor units with another,
+// e.g. 1999 hundredths are 19990 thousandths or, with banker's rounding, 20 units
+func Rescale(amount int64, from, to uint8, mode RoundingMode) (int64, error) {
+	switch {
+	case to > from:
+		return mulDiv(amount, pow10(to-from), 1, mode)
+	case to < from:
+		return mulDiv(amount, 1, pow10(from-to), mode)
+	default:
+		return amount, nil
+	}
+}
+
+func pow10(n uint8) int64 {
+	p := int64(1)
+	for i := uint8(0); i < n; i++ {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,96 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMulDivRoundsTies(t *testing.T) {
+	tests := []struct {
+		name         string
+		a, num, den  int64
+		halfUp, even int64
+	}{
+		{name: "half below one", a: 5, num: 1, den: 10, halfUp: 1, even: 0},
+		{name: "half above odd", a: 15, num: 1, den: 10, halfUp: 2, even: 2},
+		{name: "half above even", a: 25, num: 1, den: 10, halfUp: 3, even: 2},
+		{name: "below half", a: 24, num: 1, den: 10, halfUp: 2, even: 2},
+		{name: "above half", a: 26, num: 1, den: 10, halfUp: 3, even: 3},
+		{name: "thirds", a: 2, num: 1, den: 3, halfUp: 1, even: 1},
+		{name: "negative half below one", a: -5, num: 1, den: 10, halfUp: -1, even: 0},
+		{name: "negative half above odd", a: -15, num: 1, den: 10, halfUp: -2, even: -2},
+		{name: "negative half above even", a: -25, num: 1, den: 10, halfUp: -3, even: -2},
+		{name: "negative divisor", a: 25, num: 1, den: -10, halfUp: -3, even: -2},
+		{name: "percentage", a: 1000, num: 19, den: 100, halfUp: 190, even: 190},
+		{name: "percentage tie above odd", a: 1250, num: 19, den: 100, halfUp: 238, even: 238},
+		{name: "percentage tie above even", a: 150, num: 19, den: 100, halfUp: 29, even: 28},
+		{name: "exact", a: 1000, num: 3, den: 4, halfUp: 750, even: 750},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for mode, want := range map[RoundingMode]int64{HalfUp: tt.halfUp, HalfEven: tt.even} {
+				got, err := mulDiv(tt.a, tt.num, tt.den, mode)
+				if err != nil {
+					t.Fatalf("mode %d: %v", mode, err)
+				}
+				if got != want {
+					t.Errorf("mode %d: %d * %d / %d got %d, want %d", mode, tt.a, tt.num, tt.den, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMulDivRefusesOutOfRange(t *testing.T) {
+	if _, err := mulDiv(1, 1, 0, HalfUp); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("got error %v, want %v", err, ErrDivisionByZero)
+	}
+	if _, err := mulDiv(math.MaxInt64, 2, 1, HalfUp); !errors.Is(err, ErrOverflow) {
+		t.Errorf("got error %v, want %v", err, ErrOverflow)
+	}
+
+	// the intermediate product is out of range, the result is not
+	got, err := mulDiv(math.MaxInt64, 3, 3, HalfUp)
+	if err != nil || got != math.MaxInt64 {
+		t.Errorf("got %d, %v, want %d", got, err, int64(math.MaxInt64))
+	}
+}
+
+func TestRescale(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		from, to uint8
+		mode     RoundingMode
+		want     int64
+	}{
+		{name: "hundredths to thousandths", amount: 1999, from: 2, to: 3, mode: HalfEven, want: 19990},
+		{name: "hundredths to units", amount: 1999, from: 2, to: 0, mode: HalfEven, want: 20},
+		{name: "tie to even up", amount: 12350, from: 2, to: 0, mode: HalfEven, want: 124},
+		{name: "tie to even down", amount: 12250, from: 2, to: 0, mode: HalfEven, want: 122},
+		{name: "tie half up", amount: 12250, from: 2, to: 0, mode: HalfUp, want: 123},
+		{name: "negative tie to even", amount: -12250, from: 2, to: 0, mode: HalfEven, want: -122},
+		{name: "negative tie half up", amount: -12250, from: 2, to: 0, mode: HalfUp, want: -123},
+		{name: "units to ten thousandths", amount: 5, from: 0, to: 4, mode: HalfUp, want: 50000},
+		{name: "thousandths to hundredths", amount: 1005, from: 3, to: 2, mode: HalfEven, want: 100},
+		{name: "same exponent", amount: -42, from: 2, to: 2, mode: HalfUp, want: -42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Rescale(tt.amount, tt.from, tt.to, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Rescale(math.MaxInt64, 0, 4, HalfUp); !errors.Is(err, ErrOverflow) {
+		t.Errorf("got error %v, want %v", err, ErrOverflow)
+	}
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/logging"
+)
+
+type Limiter struct {
+	store        Store
+	defaultLimit Limit
+	limits       map[string]Limit
+}
+
+// NewLimiter creates a limiter applying limits per route (gRPC method or HTTP route)
+// and defaultLimit to routes missing in limits.
+func NewLimiter(store Store, defaultLimit Limit, limits map[string]Limit) *Limiter {
+	return &Limiter{
+		store:        store,
+		defaultLimit: defaultLimit,
+		limits:       limits,
+	}
+}
+
+// Allow takes a token for the client on the route. Store failures let the request through.
+func (l *Limiter) Allow(ctx context.Context, route, client string) (bool, time.Duration) {
+	limit, ok := l.limits[route]
+	if !ok {
+		limit = l.defaultLimit
+	}
+	if limit.Unlimited() {
+		return true, 0
+	}
+
+	allowed, retryAfter, err := l.store.Take(ctx, route+"|"+client, limit)
+	if err != nil {
+		logging.WithError(ctx, err).WithField("route", route).Error("rate limit store failed")
+		return true, 0
+	}
+
+	return allowed, retryAfter
+}
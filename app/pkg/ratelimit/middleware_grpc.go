@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const RetryAfterHeader = "retry-after"
+
+// peerRoute is the route all methods share in the limits per peer
+const peerRoute = "peer"
+
+// UnaryServerInterceptor limits calls per method and client. It has to run after
+// the auth interceptor to key requests by API key or user.
+func UnaryServerInterceptor(limiter *Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := allow(ctx, limiter, info.FullMethod, clientFromGRPC(ctx)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor limits the streams opened per method and client
+func StreamServerInterceptor(limiter *Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allow(ss.Context(), limiter, info.FullMethod, clientFromGRPC(ss.Context())); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// PeerUnaryServerInterceptor limits the calls of every method together per client address.
+// It has to run before the auth interceptor, so floods of bad credentials are cut off
+// before each of them costs a lookup.
+func PeerUnaryServerInterceptor(limiter *Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := allow(ctx, limiter, peerRoute, peerAddress(ctx)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PeerStreamServerInterceptor limits the streams opened per client address like PeerUnaryServerInterceptor
+func PeerStreamServerInterceptor(limiter *Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allow(ss.Context(), limiter, peerRoute, peerAddress(ss.Context())); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func allow(ctx context.Context, limiter *Limiter, route, client string) error {
+	allowed, retryAfter := limiter.Allow(ctx, route, client)
+	if allowed {
+		return nil
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, retryAfterSeconds(retryAfter)))
+
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+func clientFromGRPC(ctx context.Context) string {
+	if keyID, ok := jwt.GetAPIKeyID(ctx); ok {
+		return "key:" + keyID
+	}
+	if userID, err := jwt.GetUserID(ctx); err == nil {
+		return "user:" + userID
+	}
+	return peerAddress(ctx)
+}
+
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	}
+	return "unknown"
+}
+
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
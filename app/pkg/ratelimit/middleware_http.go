@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/api/jwt"
+)
+
+// Middleware limits requests per route ("METHOD /path") and client. Requests are keyed by
+// the user when it runs behind jwt.Middleware and by the client IP otherwise. Credentials
+// are never keyed by before they are validated, fresh made-up ones would get fresh buckets.
+func Middleware(h http.Handler, limiter *Limiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + r.URL.Path
+
+		allowed, retryAfter := limiter.Allow(r.Context(), route, clientFromHTTP(r))
+		if !allowed {
+			w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("rate limit exceeded"))
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+func clientFromHTTP(r *http.Request) string {
+	if userID, err := jwt.GetUserID(r.Context()); err == nil {
+		return "user:" + userID
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return "ip:" + host
+	}
+	return "ip:" + r.RemoteAddr
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestMiddlewareIgnoresUnvalidatedAPIKeys(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), Limit{Rate: 1, Burst: 2}, nil)
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), limiter)
+
+	statuses := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/api/heartbeat", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		// a made-up key per request must not buy a fresh bucket
+		r.Header.Set("x-api-key", "key-"+strconv.Itoa(i))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		statuses = append(statuses, w.Code)
+	}
+
+	if statuses[0] != http.StatusOK || statuses[1] != http.StatusOK || statuses[2] != http.StatusTooManyRequests {
+		t.Errorf("got statuses %v, want the third request limited", statuses)
+	}
+}
+
+func TestPeerUnaryServerInterceptorLimitsBeforeHandler(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), Limit{Rate: 1, Burst: 1}, nil)
+	interceptor := PeerUnaryServerInterceptor(limiter)
+
+	var called int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called++
+		return nil, nil
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}})
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/products.v1.ProductService/AllProducts"}, handler)
+	if err != nil {
+		t.Fatalf("first call limited: %v", err)
+	}
+
+	// the methods share the bucket of the peer
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/products.v1.ProductService/ProductByID"}, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("got error %v, want %v", err, codes.ResourceExhausted)
+	}
+
+	if called != 1 {
+		t.Errorf("handler called %d times, want 1", called)
+	}
+}
@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit describes a token bucket: Rate tokens are added per second up to Burst.
+// A zero Rate disables limiting.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Store keeps token buckets. Implementations may be shared between instances.
+type Store interface {
+	// Take removes one token from the bucket of key. When the bucket is empty it
+	// reports false and the time until the next token is available.
+	Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
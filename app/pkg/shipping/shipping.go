@@ -0,0 +1,35 @@
+package shipping
+
+import (
+	"github.com/ilkinabd/goods-manager/app/pkg/measure"
+)
+
+// Class tells carriers how a package has to be handled
+type Class string
+
+const (
+	Standard Class = "standard"
+	Bulky    Class = "bulky"
+	Fragile  Class = "fragile"
+)
+
+// VolumetricDivisor is the number of cubic centimeters carriers charge as one kilogram
+const VolumetricDivisor = 5000
+
+func IsClass(c string) bool {
+	switch Class(c) {
+	case Standard, Bulky, Fragile:
+		return true
+	default:
+		return false
+	}
+}
+
+// VolumetricWeight is the weight carriers charge for the space a package takes,
+// shown in the unit of the actual weight
+func VolumetricWeight(d measure.Dimensions, unit measure.WeightUnit) measure.Weight {
+	return measure.Weight{
+		Grams: d.Volume() / VolumetricDivisor * 1000,
+		Unit:  unit,
+	}
+}
@@ -0,0 +1,82 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxLength keeps slugs short enough for URLs and indexes
+const MaxLength = 100
+
+var transliteration = map[rune]string{
+	// Azerbaijani and Turkish
+	'ə': "e", 'ğ': "g", 'ı': "i", 'ö': "o", 'ş': "s", 'ü': "u", 'ç': "c",
+	// Latin with diacritics
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u",
+	'ñ': "n", 'ß': "ss", 'ý': "y", 'ÿ': "y",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// Make transliterates s to lower case ASCII words joined by dashes,
+// e.g. "iPhone 15 Pro (256GB)" becomes "iphone-15-pro-256gb".
+func Make(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		latin, known := transliteration[r]
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			dash = false
+		case known:
+			// hard and soft signs have no letter of their own
+			b.WriteString(latin)
+			dash = dash && latin == ""
+		case b.Len() != 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	res := strings.TrimSuffix(b.String(), "-")
+	if len(res) > MaxLength {
+		res = strings.TrimSuffix(res[:MaxLength], "-")
+	}
+	return res
+}
+
+// WithSuffix returns the n-th candidate for a taken slug, e.g. "iphone-15-2"
+func WithSuffix(s string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(s)+len(suffix) > MaxLength {
+		s = strings.TrimSuffix(s[:MaxLength-len(suffix)], "-")
+	}
+	return s + suffix
+}
+
+// Valid reports whether s is a slug Make could have produced
+func Valid(s string) bool {
+	if s == "" || len(s) > MaxLength || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '-' && s[i-1] != '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
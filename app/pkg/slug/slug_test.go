@@ -0,0 +1,92 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMakeTransliterates(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "ascii", in: "iPhone 15 Pro (256GB)", want: "iphone-15-pro-256gb"},
+		{name: "azerbaijani", in: "Çəkic və mişar", want: "cekic-ve-misar"},
+		{name: "turkish", in: "Güneş gözlüğü", want: "gunes-gozlugu"},
+		{name: "german", in: "Straße Größe", want: "strasse-grosse"},
+		{name: "french", in: "Crème brûlée", want: "creme-brulee"},
+		{name: "ligatures", in: "Œuvre æther", want: "oeuvre-aether"},
+		{name: "russian", in: "Ёлка Щука", want: "yolka-shchuka"},
+		{name: "upper case cyrillic", in: "ТЕСТ", want: "test"},
+		{name: "hard and soft signs", in: "Объект мебель", want: "obekt-mebel"},
+		{name: "ukrainian", in: "Їжак Ґанок Єнот", want: "yizhak-ganok-yenot"},
+		{name: "punctuation runs", in: "  --Hello,   World!--  ", want: "hello-world"},
+		{name: "letters without transliteration", in: "产品 42", want: "42"},
+		{name: "letters without transliteration inside", in: "tea 茶 cup", want: "tea-cup"},
+		{name: "nothing left", in: "!!! ???", want: ""},
+		{name: "empty", in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Make(tt.in)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if got != "" && !Valid(got) {
+				t.Errorf("%q is not a valid slug", got)
+			}
+		})
+	}
+}
+
+func TestMakeTruncates(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "at the limit", in: strings.Repeat("a", MaxLength), want: strings.Repeat("a", MaxLength)},
+		{name: "past the limit", in: strings.Repeat("a", MaxLength+50), want: strings.Repeat("a", MaxLength)},
+		{name: "dash at the cut", in: strings.Repeat("a", MaxLength-1) + " b", want: strings.Repeat("a", MaxLength-1)},
+		{
+			name: "inside a transliterated letter",
+			in:   strings.Repeat("a", MaxLength-2) + "щ",
+			want: strings.Repeat("a", MaxLength-2) + "sh",
+		},
+		{
+			name: "inside a run of multi-byte letters",
+			in:   "a" + strings.Repeat("щ", 30),
+			want: "a" + strings.Repeat("shch", 24) + "shc",
+		},
+		{name: "two-letter transliterations", in: strings.Repeat("ж", 60), want: strings.Repeat("zh", 50)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Make(tt.in)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !Valid(got) {
+				t.Errorf("%q is not a valid slug", got)
+			}
+		})
+	}
+}
+
+func TestWithSuffixKeepsTheLimit(t *testing.T) {
+	if got := WithSuffix("iphone-15", 2); got != "iphone-15-2" {
+		t.Errorf("got %q, want %q", got, "iphone-15-2")
+	}
+
+	long := strings.Repeat("a", MaxLength-3) + "-bc"
+	got := WithSuffix(long, 12)
+	if len(got) > MaxLength || !Valid(got) {
+		t.Errorf("%q is not a valid slug of at most %d characters", got, MaxLength)
+	}
+	if want := strings.Repeat("a", MaxLength-3) + "-12"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package tax
+
+import (
+	"github.com/ilkinabd/goods-manager/app/pkg/money"
+)
+
+// BasisPoints is a rate in hundredths of a percent, e.g. 1900 is 19% and 550 is 5.5%
+type BasisPoints uint32
+
+// Percent100 is the whole price, no VAT rate is higher than that
+const Percent100 BasisPoints = 10000
+
+// Breakdown splits a price into the amount before tax and the tax on it
+type Breakdown struct {
+	Net   money.Money
+	Tax   money.Money
+	Gross money.Money
+}
+
+// Split computes the breakdown of the price. An inclusive price is the gross one
+// and the tax is extracted from it, otherwise the tax is added on top of it.
+// The tax is rounded half up to the minor unit of the price currency, e.g. to a cent
+// for EUR and to a yen for JPY, and Net + Tax always equals Gross.
+func Split(price money.Money, rate BasisPoints, inclusive bool) (Breakdown, error) {
+	if inclusive {
+		tax, err := price.MulFrac(int64(rate), int64(Percent100+rate), money.HalfUp)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		net, err := price.Sub(tax)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		return Breakdown{Net: net, Tax: tax, Gross: price}, nil
+	}
+
+	tax, err := price.MulFrac(int64(rate), int64(Percent100), money.HalfUp)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	gross, err := price.Add(tax)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	return Breakdown{Net: price, Tax: tax, Gross: gross}, nil
+}
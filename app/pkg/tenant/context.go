@@ -0,0 +1,25 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/ilkinabd/goods-manager/app/pkg/errors"
+)
+
+// ErrNoTenant is returned when a tenant scoped operation is called without a tenant in context
+var ErrNoTenant = errors.Unauthenticated("no tenant in context")
+
+type ctxTenant struct{}
+
+// ContextWithTenant adds tenant id to context
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, ctxTenant{}, tenantID)
+}
+
+// FromContext returns tenant id from context
+func FromContext(ctx context.Context) (string, error) {
+	if tenantID, ok := ctx.Value(ctxTenant{}).(string); ok && tenantID != "" {
+		return tenantID, nil
+	}
+	return "", ErrNoTenant
+}